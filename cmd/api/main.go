@@ -1,14 +1,39 @@
 package main
 
 import (
-	"fmt"
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/vs0uz4/weatherzip/configs"
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/admission"
+	"github.com/vs0uz4/weatherzip/internal/infra/auth"
+	"github.com/vs0uz4/weatherzip/internal/infra/cache"
+	"github.com/vs0uz4/weatherzip/internal/infra/cgroup"
+	"github.com/vs0uz4/weatherzip/internal/infra/checker"
+	"github.com/vs0uz4/weatherzip/internal/infra/httpclient"
+	"github.com/vs0uz4/weatherzip/internal/infra/keypool"
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/metrics"
+	"github.com/vs0uz4/weatherzip/internal/infra/probe"
+	"github.com/vs0uz4/weatherzip/internal/infra/ratelimit"
+	"github.com/vs0uz4/weatherzip/internal/infra/repository"
+	"github.com/vs0uz4/weatherzip/internal/infra/sampler"
+	"github.com/vs0uz4/weatherzip/internal/infra/scheduler"
+	"github.com/vs0uz4/weatherzip/internal/infra/tracing"
 	"github.com/vs0uz4/weatherzip/internal/infra/web"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
 	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
 	"github.com/vs0uz4/weatherzip/internal/service"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
 	"github.com/vs0uz4/weatherzip/internal/usecase"
+	usecasecontracts "github.com/vs0uz4/weatherzip/internal/usecase/contracts"
 )
 
 func main() {
@@ -17,16 +42,226 @@ func main() {
 		panic(err)
 	}
 
-	httpClient := &http.Client{}
+	logger, err := logging.New(os.Stdout, cfg.LogLevel, cfg.LogFormat)
+	if err != nil {
+		panic(err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		ServiceName: cfg.TracingServiceName,
+		Endpoint:    cfg.TracingEndpoint,
+		SampleRatio: cfg.TracingSampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	appCache := cache.NewMemoryCache()
+
+	upstreamTransport := httpclient.TransportConfig{
+		MaxIdleConns:        cfg.UpstreamMaxIdleConns,
+		MaxIdleConnsPerHost: cfg.UpstreamMaxIdleConnsPerHost,
+		MaxConnsPerHost:     cfg.UpstreamMaxConnsPerHost,
+		IdleConnTimeout:     cfg.UpstreamIdleConnTimeout,
+		HTTP2:               cfg.UpstreamHTTP2,
+		ProxyURL:            cfg.UpstreamProxyURL,
+		CABundle:            cfg.UpstreamCABundle,
+	}
+	cepTransport := upstreamTransport
+	cepTransport.DialTimeout = cfg.CepHTTPDialTimeout
+	cepTransport.TLSHandshakeTimeout = cfg.CepHTTPTLSHandshakeTimeout
+	cepTransport.ResponseHeaderTimeout = cfg.CepHTTPResponseHeaderTimeout
+	cepTransport.Timeout = cfg.CepHTTPTimeout
+	cepUpstreamClient, err := httpclient.NewUpstreamClient(cepTransport)
+	if err != nil {
+		panic(err)
+	}
+	weatherTransport := upstreamTransport
+	weatherTransport.DialTimeout = cfg.WeatherHTTPDialTimeout
+	weatherTransport.TLSHandshakeTimeout = cfg.WeatherHTTPTLSHandshakeTimeout
+	weatherTransport.ResponseHeaderTimeout = cfg.WeatherHTTPResponseHeaderTimeout
+	weatherTransport.Timeout = cfg.WeatherHTTPTimeout
+	weatherUpstreamClient, err := httpclient.NewUpstreamClient(weatherTransport)
+	if err != nil {
+		panic(err)
+	}
 
 	cpuService := service.NewCPUService()
 	memoryService := service.NewMemoryService()
+	diskService := service.NewDiskService()
 	uptimeService := service.NewUptimeService()
-	cepService := service.NewCepService(httpClient, cfg.CepAPIUrl)
-	weatherService := service.NewWeatherService(httpClient, cfg.WeatherAPIUrl, cfg.WeatherAPIKey, cfg.WeatherAPILanguage)
+	resourceSampler, err := sampler.NewSampler(cpuService, memoryService, cfg.HealthSampleInterval, cfg.HealthSampleRetention)
+	if err != nil {
+		panic(err)
+	}
+	resourceSamplerScheduler := scheduler.NewScheduler(cfg.HealthSampleInterval, resourceSampler.Collect)
+	cepRetryPolicy, err := httpclient.NewRetryPolicy(cfg.CepRetryMaxAttempts, cfg.CepRetryBaseBackoff, cfg.CepRetryMaxBackoff, cfg.CepRetryStatuses, cfg.CepRetryErrors)
+	if err != nil {
+		panic(err)
+	}
+	weatherRetryPolicy, err := httpclient.NewRetryPolicy(cfg.WeatherRetryMaxAttempts, cfg.WeatherRetryBaseBackoff, cfg.WeatherRetryMaxBackoff, cfg.WeatherRetryStatuses, cfg.WeatherRetryErrors)
+	if err != nil {
+		panic(err)
+	}
 
-	healthCheckUseCase := usecase.NewHealthCheckUseCase(cpuService, memoryService, uptimeService)
-	wheaterByCepUseCase := usecase.NewWeatherByCepUsecase(cepService, weatherService)
+	breakerConfig := httpclient.BreakerConfig{
+		Window:         cfg.BreakerWindow,
+		MinRequests:    cfg.BreakerMinRequests,
+		FailureRate:    cfg.BreakerFailureRate,
+		Cooldown:       cfg.BreakerCooldown,
+		HalfOpenProbes: cfg.BreakerHalfOpenProbes,
+	}
+	if err := breakerConfig.Validate(); err != nil {
+		panic(err)
+	}
+
+	appMetrics := metrics.New()
+	onBreakerStateChange := func(name string, from, to httpclient.BreakerState) {
+		logger.Warn("circuit breaker state changed", slog.String("upstream", name), slog.String("from", from.String()), slog.String("to", to.String()))
+		appMetrics.SetBreakerState(name, to.String())
+	}
+
+	hedgeConfig := httpclient.HedgeConfig{
+		Percentile: cfg.HedgePercentile,
+		MinDelay:   cfg.HedgeMinDelay,
+		MaxDelay:   cfg.HedgeMaxDelay,
+		Budget:     cfg.HedgeBudget,
+	}
+	if err := hedgeConfig.Validate(); err != nil {
+		panic(err)
+	}
+	weatherKeys, err := keypool.ParseKeys(cfg.WeatherAPIKeys)
+	if err != nil {
+		panic(err)
+	}
+	if len(weatherKeys) == 0 {
+		weatherKeys = []keypool.Key{{Value: cfg.WeatherAPIKey, Weight: 1}}
+	}
+	weatherKeyPool, err := keypool.New(metrics.UpstreamWeatherAPI, weatherKeys, repository.NewUpstreamKeyUsageRepository(filepath.Join(cfg.DataDir, "upstream_key_usage.json")))
+	if err != nil {
+		panic(err)
+	}
+
+	cepBreaker := httpclient.NewCircuitBreaker(metrics.UpstreamViaCep, httpclient.NewRetryClient(tracing.NewHttpClient(cepUpstreamClient), cepRetryPolicy), breakerConfig)
+	cepBreaker.OnStateChange = onBreakerStateChange
+	weatherBreaker := httpclient.NewCircuitBreaker(metrics.UpstreamWeatherAPI, httpclient.NewRetryClient(keypool.NewClient(tracing.NewHttpClient(weatherUpstreamClient), weatherKeyPool, "key"), weatherRetryPolicy), breakerConfig)
+	weatherBreaker.OnStateChange = onBreakerStateChange
+	for _, breaker := range []*httpclient.CircuitBreaker{cepBreaker, weatherBreaker} {
+		appMetrics.SetBreakerState(breaker.Name, breaker.State().String())
+	}
+
+	cepBulkhead, err := httpclient.NewBulkhead(cepBreaker.Name, cepBreaker, cfg.BulkheadCepMaxConcurrent, cfg.BulkheadMaxWait)
+	if err != nil {
+		panic(err)
+	}
+	weatherBulkhead, err := httpclient.NewBulkhead(weatherBreaker.Name, weatherBreaker, cfg.BulkheadWeatherMaxConcurrent, cfg.BulkheadMaxWait)
+	if err != nil {
+		panic(err)
+	}
+
+	var cepHTTPClient contracts.HttpClient = cepBulkhead
+	if cfg.CepHedgeEnabled {
+		cepHedge := httpclient.NewHedgingClient(metrics.UpstreamViaCep, cepBulkhead, hedgeConfig)
+		cepHedge.OnHedge = appMetrics.ObserveHedge
+		cepHTTPClient = cepHedge
+	}
+	var weatherHTTPClient contracts.HttpClient = weatherBulkhead
+	if cfg.WeatherHedgeEnabled {
+		weatherHedge := httpclient.NewHedgingClient(metrics.UpstreamWeatherAPI, weatherBulkhead, hedgeConfig)
+		weatherHedge.OnHedge = appMetrics.ObserveHedge
+		weatherHTTPClient = weatherHedge
+	}
+
+	cepService := service.NewCepService(cepHTTPClient, cfg.CepAPIUrl)
+	weatherService := service.NewWeatherService(weatherHTTPClient, cfg.WeatherAPIUrl, cfg.WeatherAPIKey, cfg.WeatherAPILanguage)
+	weatherService.Keys = weatherKeyPool
+	weatherKeyPoolFlusher := scheduler.NewScheduler(cfg.WeatherAPIKeysFlushInterval, weatherKeyPool.Flush)
+	webhookService := service.NewWebhookService(httpclient.NewWebhookClient(cfg.WebhookTimeout), cfg.WebhookMaxAttempts, cfg.WebhookBaseBackoff, cfg.WebhookMaxBackoff)
+
+	subscriptionRepository := repository.NewSubscriptionRepository(filepath.Join(cfg.DataDir, "subscriptions.json"))
+	deadLetterRepository := repository.NewDeadLetterRepository(filepath.Join(cfg.DataDir, "dead_letters.json"))
+	deadLetterRepository.Limit = cfg.WebhookDeadLetterLimit
+	apiKeyRepository := repository.NewAPIKeyRepository(filepath.Join(cfg.DataDir, "api_keys.json"))
+
+	var cepClient contracts.CepService = tracing.TraceCepService(cepService)
+	var weatherClient contracts.WeatherService = tracing.TraceWeatherService(weatherService)
+	if cfg.MetricsEnabled {
+		if err := appMetrics.RegisterSystemCollector(resourceSampler, memoryService); err != nil {
+			panic(err)
+		}
+		if err := appMetrics.RegisterKeyPoolCollector(weatherKeyPool.Name, weatherKeyPool.Stats); err != nil {
+			panic(err)
+		}
+		cepClient = metrics.InstrumentCepService(cepClient, appMetrics)
+		weatherClient = metrics.InstrumentWeatherService(weatherClient, appMetrics)
+	}
+
+	healthProber := probe.NewProber(cfg.HealthProbeTimeout)
+	healthProber.Register(metrics.UpstreamViaCep, probe.HTTPCheck(cepUpstreamClient, probe.Origin(cfg.CepAPIUrl)))
+	healthProber.Register(metrics.UpstreamWeatherAPI, probe.HTTPCheck(weatherUpstreamClient, probe.Origin(cfg.WeatherAPIUrl)))
+	healthProber.RegisterCritical("cache", probe.CacheCheck(appCache))
+	healthProbeScheduler := scheduler.NewScheduler(cfg.HealthProbeInterval, healthProber.Refresh)
+
+	healthRegistry := checker.NewRegistry(cfg.HealthCheckTimeout)
+	hysteresis := cfg.HealthThresholdHysteresis
+	cpuChecker := checker.NewCPUChecker(resourceSampler)
+	cpuChecker.StatsFunc = resourceSampler.Stats
+	cpuChecker.Average = checker.NewThreshold(cfg.HealthCPUWarn, cfg.HealthCPUFail, hysteresis)
+	cpuChecker.PerCore = checker.NewThreshold(cfg.HealthCPUCoreWarn, cfg.HealthCPUCoreFail, hysteresis)
+	memoryChecker := checker.NewMemoryChecker(memoryService)
+	memoryChecker.Percent = checker.NewThreshold(cfg.HealthMemoryWarn, cfg.HealthMemoryFail, hysteresis)
+	memoryChecker.AvailableMB = checker.NewLowerThreshold(cfg.HealthMemoryAvailableWarnMB, cfg.HealthMemoryAvailableFailMB, hysteresis)
+	diskChecker := checker.NewDiskChecker(diskService, cfg.HealthDiskPath)
+	diskChecker.Percent = checker.NewThreshold(cfg.HealthDiskWarn, cfg.HealthDiskFail, hysteresis)
+	goroutineChecker := checker.NewGoroutineChecker()
+	goroutineChecker.Count = checker.NewThreshold(cfg.HealthGoroutinesWarn, cfg.HealthGoroutinesFail, hysteresis)
+	fdChecker := checker.NewFileDescriptorChecker()
+	fdChecker.Count = checker.NewThreshold(cfg.HealthFDsWarn, cfg.HealthFDsFail, hysteresis)
+
+	healthCheckers := []contracts.Checker{
+		cpuChecker,
+		memoryChecker,
+		diskChecker,
+		goroutineChecker,
+		fdChecker,
+		checker.NewProbeChecker(healthProber, "cache", checker.ComponentDatastore),
+	}
+	cgroupReader := cgroup.NewReader(cfg.HealthCgroupRoot)
+	if _, err := cgroupReader.Read(); err == nil {
+		memoryChecker.Cgroup = cgroupReader
+		resourceSampler.Cgroup = cgroupReader
+		healthCheckers = append(healthCheckers, checker.NewContainerChecker(cgroupReader))
+	} else if !errors.Is(err, cgroup.ErrUnavailable) {
+		logger.Warn("unable to read cgroup stats, using host values", slog.String("error", err.Error()))
+	}
+	resourceSampler.Collect(context.Background())
+	for _, upstream := range []string{metrics.UpstreamViaCep, metrics.UpstreamWeatherAPI} {
+		upstreamChecker := checker.NewProbeChecker(healthProber, upstream, checker.ComponentUpstream)
+		upstreamChecker.FailStatus = health.StatusWarn
+		healthCheckers = append(healthCheckers, upstreamChecker)
+	}
+	for _, healthChecker := range healthCheckers {
+		if err := healthRegistry.Register(healthChecker); err != nil {
+			panic(err)
+		}
+	}
+
+	healthCheckUseCase := usecase.NewHealthCheckUseCase(healthRegistry, uptimeService)
+	wheaterByCepUseCase := usecase.NewWeatherByCepUsecase(cepClient, weatherClient)
+	var weatherByCep usecasecontracts.WeatherByCepUsecase = wheaterByCepUseCase
+	var weatherCached func(ctx context.Context, cep string) bool
+	if cfg.WeatherCacheEnabled {
+		cachedWeatherByCep := usecase.NewCachedWeatherByCepUsecase(wheaterByCepUseCase, appCache, usecase.WeatherCacheConfig{
+			TTL:                  cfg.WeatherCacheTTL,
+			StaleWhileRevalidate: cfg.WeatherCacheStaleWhileRevalidate,
+			StaleIfError:         cfg.WeatherCacheStaleIfError,
+		})
+		weatherByCep = cachedWeatherByCep
+		weatherCached = cachedWeatherByCep.Cached
+	}
+	subscriptionUseCase := usecase.NewSubscriptionUsecase(subscriptionRepository, deadLetterRepository, webhookService, weatherByCep)
+	apiKeyUseCase := usecase.NewAPIKeyUsecase(apiKeyRepository)
 
 	handlerRoot := func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -35,15 +270,199 @@ func main() {
 		}
 	}
 
-	handlerHealth := web.NewHealthHandler(healthCheckUseCase).GetHealth
-	handlerWeather := web.NewWeatherHandler(wheaterByCepUseCase).GetWeatherByCep
+	healthHandler := web.NewHealthHandler(healthCheckUseCase)
+	healthHandler.ServiceInfo = health.ServiceInfo{
+		Version:     cfg.HealthVersion,
+		ReleaseID:   cfg.HealthReleaseID,
+		ServiceID:   cfg.HealthServiceID,
+		Description: cfg.HealthDescription,
+	}
+	handlerHealth := healthHandler.GetHealth
+	weatherHandler := web.NewWeatherHandler(tracing.TraceWeatherByCepUsecase(weatherByCep))
+	weatherHandler.RefreshInterval = cfg.WeatherRefreshInterval
+	weatherHandler.CepCacheMaxAge = cfg.CepCacheMaxAge
+	weatherHandler.DegradedMode = cfg.WeatherDegradedMode
+	handlerWeather := weatherHandler.GetWeatherByCep
+	subscriptionHandler := web.NewSubscriptionHandler(subscriptionUseCase)
+	apiKeyHandler := web.NewAPIKeyHandler(apiKeyUseCase)
+	upstreamKeyHandler := web.NewUpstreamKeyHandler(map[string]func() []domain.UpstreamKeyStats{
+		weatherKeyPool.Name: weatherKeyPool.Stats,
+	})
+
+	subscriptionScheduler := scheduler.NewScheduler(cfg.SubscriptionCheckInterval, subscriptionUseCase.EvaluateSubscriptions)
+
+	webServer := webserver.NewWebServer(cfg.WebServerPort,
+		webserver.WithTimeouts(cfg.ServerReadHeaderTimeout, cfg.ServerReadTimeout, cfg.ServerWriteTimeout, cfg.ServerIdleTimeout),
+		webserver.WithMaxHeaderBytes(cfg.ServerMaxHeaderBytes),
+		webserver.WithMaxBodyBytes(cfg.ServerMaxBodyBytes),
+		webserver.WithShutdownTimeouts(cfg.ShutdownPreStopDelay, cfg.ShutdownDrainTimeout),
+		webserver.WithLogger(logger, cfg.LogTrustedHops),
+	)
+	healthHandler.DrainingFunc = webServer.IsDraining
+	healthHandler.ReadinessFunc = healthProber.Results
+	healthHandler.ResourcesFunc = resourceSampler.Stats
+	healthHandler.HistoryFunc = resourceSampler.History
+	healthHandler.UpstreamsFunc = func() map[string]string {
+		return map[string]string{
+			cepBreaker.Name:     cepBreaker.State().String(),
+			weatherBreaker.Name: weatherBreaker.State().String(),
+		}
+	}
 
-	webserver := webserver.NewWebServer(cfg.WebServerPort)
-	webserver.AddHandler("/weather/{cep}", handlerWeather, "GET")
-	webserver.AddHandler("/health", handlerHealth, "GET")
-	webserver.AddHandler("/", handlerRoot, "GET")
+	webServer.Use(webserver.NamedMiddleware("tracing", tracing.Middleware))
+	if cfg.MetricsEnabled {
+		webServer.Use(webserver.NamedMiddleware("metrics", appMetrics.Middleware))
+	}
 
-	fmt.Println("Starting web server on port", cfg.WebServerPort)
-	webserver.Start()
-	webserver.Run()
+	if cfg.AdmissionEnabled {
+		admissionConfig := admission.Config{
+			MaxInFlight:          cfg.AdmissionMaxInFlight,
+			TargetLatency:        cfg.AdmissionTargetLatency,
+			CPUThreshold:         cfg.AdmissionCPUThreshold,
+			MemoryThreshold:      cfg.AdmissionMemoryThreshold,
+			LowPriorityThreshold: cfg.AdmissionLowPriorityThreshold,
+			RetryAfter:           cfg.AdmissionRetryAfter,
+		}
+		if err := admissionConfig.Validate(); err != nil {
+			panic(err)
+		}
+		admissionController := admission.NewController(admissionConfig, resourceSampler, memoryService)
+		admissionSampler := scheduler.NewScheduler(cfg.AdmissionSampleInterval, func(ctx context.Context) {
+			admissionController.Sample()
+		})
+		admissionSampler.Start()
+		webServer.RegisterShutdownHook("admission sampler", func(ctx context.Context) error {
+			admissionSampler.Stop()
+			return nil
+		})
+
+		webServer.Use(webserver.NamedMiddleware("admission", middleware.Admission(middleware.AdmissionConfig{
+			Controller: admissionController,
+			Critical:   []string{"/health", "/metrics"},
+			Low:        []string{"/subscriptions", "/admin", "/routes"},
+			Cached: func(r *http.Request) bool {
+				cep, ok := strings.CutPrefix(r.URL.Path, "/weather/")
+				return ok && weatherCached != nil && weatherCached(r.Context(), cep)
+			},
+		})))
+	}
+
+	var rateLimitConfig middleware.RateLimitConfig
+	if cfg.RateLimitEnabled {
+		ipLimit, err := ratelimit.ParseLimit(cfg.RateLimitIP)
+		if err != nil {
+			panic(err)
+		}
+		apiKeyLimit, err := ratelimit.ParseLimit(cfg.RateLimitAPIKey)
+		if err != nil {
+			panic(err)
+		}
+		routeLimits, err := ratelimit.ParseRouteLimits(cfg.RateLimitRoutes)
+		if err != nil {
+			panic(err)
+		}
+
+		rateLimitConfig = middleware.RateLimitConfig{
+			Store:       ratelimit.NewMemoryStore(),
+			IP:          ipLimit,
+			APIKey:      apiKeyLimit,
+			Routes:      routeLimits,
+			Exempt:      []string{"/health", "/metrics"},
+			TrustedHops: cfg.RateLimitTrustedHops,
+		}
+		webServer.Use(webserver.NamedMiddleware("rate_limit_ip", middleware.RateLimitIP(rateLimitConfig)))
+	}
+
+	authConfig := middleware.AuthConfig{
+		Authenticate: apiKeyUseCase.Authenticate,
+		Required:     cfg.AuthRequired,
+		Exempt:       []string{"/", "/health", "/metrics", "/routes", "/admin"},
+	}
+	if cfg.JWTJWKSSource != "" {
+		jwks := auth.NewJWKS(cfg.JWTJWKSSource, &http.Client{Timeout: cfg.JWTJWKSTimeout}, cfg.JWTJWKSRefreshInterval)
+		jwks.Timeout = cfg.JWTJWKSTimeout
+		authConfig.ValidateToken = auth.NewJWTValidator(jwks, cfg.JWTIssuer, cfg.JWTAudience, cfg.JWTLeeway).Validate
+	}
+	webServer.Use(webserver.NamedMiddleware("authenticate", middleware.Authenticate(authConfig)))
+	if cfg.RateLimitEnabled {
+		webServer.Use(webserver.NamedMiddleware("rate_limit_api_key", middleware.RateLimitAPIKey(rateLimitConfig)))
+	}
+
+	var weatherScopes, subscriptionScopes []webserver.Middleware
+	if cfg.AuthRequired {
+		weatherScopes = append(weatherScopes, webserver.NamedMiddleware("require_scope:"+domain.ScopeWeatherRead, middleware.RequireScope(domain.ScopeWeatherRead)))
+		subscriptionScopes = append(subscriptionScopes, webserver.NamedMiddleware("require_scope:"+domain.ScopeSubscriptionsWrite, middleware.RequireScope(domain.ScopeSubscriptionsWrite)))
+		healthHandler.DetailAuthorizer = func(r *http.Request) bool {
+			principal, ok := middleware.PrincipalFromContext(r.Context())
+			return ok && principal.HasScope(domain.ScopeHealthDetail)
+		}
+	}
+
+	webServer.Get("/weather/{cep}", handlerWeather).Name("weather.get").Timeout(cfg.WeatherRouteTimeout).Use(weatherScopes...)
+	webServer.Get("/health", handlerHealth).Name("health")
+	webServer.Get("/health/live", healthHandler.GetLiveness).Name("health.live")
+	webServer.Get("/health/ready", healthHandler.GetReadiness).Name("health.ready")
+	webServer.Get("/health/startup", healthHandler.GetStartup).Name("health.startup")
+	webServer.Get("/health/history", healthHandler.GetHistory).Name("health.history")
+	if cfg.MetricsEnabled {
+		webServer.Handle(http.MethodGet, "/metrics", appMetrics.Handler()).Name("metrics")
+	}
+	webServer.Group("/subscriptions", func(subscriptions *webserver.RouteGroup) {
+		subscriptions.Use(subscriptionScopes...)
+		subscriptions.Post("/", subscriptionHandler.CreateSubscription).Name("subscriptions.create")
+		subscriptions.Get("/", subscriptionHandler.ListSubscriptions).Name("subscriptions.list")
+		subscriptions.Get("/dead-letters", subscriptionHandler.ListDeadLetters).Name("subscriptions.dead_letters")
+		subscriptions.Get("/{id}", subscriptionHandler.GetSubscription).Name("subscriptions.get")
+		subscriptions.Delete("/{id}", subscriptionHandler.DeleteSubscription).Name("subscriptions.delete")
+	})
+	webServer.Group("/", func(admin *webserver.RouteGroup) {
+		admin.Use(webserver.NamedMiddleware("admin_token", middleware.RequireAdminToken(cfg.AdminToken)))
+		admin.Get("/routes", webServer.RoutesHandler).Name("routes")
+		admin.Group("/admin/api-keys", func(apiKeys *webserver.RouteGroup) {
+			apiKeys.Post("/", apiKeyHandler.CreateAPIKey).Name("api_keys.create")
+			apiKeys.Get("/", apiKeyHandler.ListAPIKeys).Name("api_keys.list")
+			apiKeys.Post("/{id}/rotate", apiKeyHandler.RotateAPIKey).Name("api_keys.rotate")
+			apiKeys.Delete("/{id}", apiKeyHandler.RevokeAPIKey).Name("api_keys.revoke")
+		})
+		admin.Get("/admin/upstream-keys", upstreamKeyHandler.ListUpstreamKeys).Name("upstream_keys.list")
+	})
+	webServer.Get("/", handlerRoot).Name("root")
+
+	webServer.RegisterShutdownHook("subscription scheduler", func(ctx context.Context) error {
+		subscriptionScheduler.Stop()
+		return nil
+	})
+	webServer.RegisterShutdownHook("health probes", func(ctx context.Context) error {
+		healthProbeScheduler.Stop()
+		return nil
+	})
+	webServer.RegisterShutdownHook("resource sampler", func(ctx context.Context) error {
+		resourceSamplerScheduler.Stop()
+		return nil
+	})
+	webServer.RegisterShutdownHook("upstream key usage", func(ctx context.Context) error {
+		weatherKeyPoolFlusher.Stop()
+		weatherKeyPool.Flush(ctx)
+		return nil
+	})
+	webServer.RegisterShutdownHook("tracing", shutdownTracing)
+	webServer.RegisterShutdownHook("logs", func(ctx context.Context) error {
+		logger.Info("web server stopped")
+		_ = os.Stderr.Sync()
+		return nil
+	})
+
+	logger.Info("starting web server", slog.String("port", cfg.WebServerPort))
+	webServer.Start()
+	subscriptionScheduler.Start()
+	healthProber.Refresh(context.Background())
+	healthProbeScheduler.Start()
+	resourceSamplerScheduler.Start()
+	weatherKeyPoolFlusher.Start()
+	healthHandler.MarkStarted()
+
+	if err := webServer.Run(); err != nil {
+		logger.Error("web server stopped with error", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 }
@@ -1,6 +1,8 @@
 package configs
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
@@ -11,7 +13,267 @@ type conf struct {
 	CepAPIUrl          string `mapstructure:"CEP_API_URL"`
 	WeatherAPIUrl      string `mapstructure:"WEATHER_API_URL"`
 	WeatherAPIKey      string `mapstructure:"WEATHER_API_KEY"`
+	WeatherAPIKeys     string `mapstructure:"WEATHER_API_KEYS"`
 	WeatherAPILanguage string `mapstructure:"WEATHER_LANGUAGE"`
+
+	WeatherAPIKeysFlushInterval time.Duration `mapstructure:"WEATHER_API_KEYS_FLUSH_INTERVAL"`
+
+	ServerReadHeaderTimeout time.Duration `mapstructure:"SERVER_READ_HEADER_TIMEOUT"`
+	ServerReadTimeout       time.Duration `mapstructure:"SERVER_READ_TIMEOUT"`
+	ServerWriteTimeout      time.Duration `mapstructure:"SERVER_WRITE_TIMEOUT"`
+	ServerIdleTimeout       time.Duration `mapstructure:"SERVER_IDLE_TIMEOUT"`
+	ServerMaxHeaderBytes    int           `mapstructure:"SERVER_MAX_HEADER_BYTES"`
+	ServerMaxBodyBytes      int64         `mapstructure:"SERVER_MAX_BODY_BYTES"`
+	WeatherRouteTimeout     time.Duration `mapstructure:"WEATHER_ROUTE_TIMEOUT"`
+
+	ShutdownPreStopDelay time.Duration `mapstructure:"SHUTDOWN_PRE_STOP_DELAY"`
+	ShutdownDrainTimeout time.Duration `mapstructure:"SHUTDOWN_DRAIN_TIMEOUT"`
+
+	WeatherRefreshInterval time.Duration `mapstructure:"WEATHER_REFRESH_INTERVAL"`
+	CepCacheMaxAge         time.Duration `mapstructure:"CEP_CACHE_MAX_AGE"`
+
+	DataDir                   string        `mapstructure:"DATA_DIR"`
+	SubscriptionCheckInterval time.Duration `mapstructure:"SUBSCRIPTION_CHECK_INTERVAL"`
+	WebhookMaxAttempts        int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBaseBackoff        time.Duration `mapstructure:"WEBHOOK_BASE_BACKOFF"`
+	WebhookMaxBackoff         time.Duration `mapstructure:"WEBHOOK_MAX_BACKOFF"`
+	WebhookTimeout            time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookDeadLetterLimit    int           `mapstructure:"WEBHOOK_DEAD_LETTER_LIMIT"`
+
+	AdminToken   string `mapstructure:"ADMIN_TOKEN"`
+	AuthRequired bool   `mapstructure:"AUTH_REQUIRED"`
+
+	JWTJWKSSource          string        `mapstructure:"JWT_JWKS_SOURCE"`
+	JWTJWKSRefreshInterval time.Duration `mapstructure:"JWT_JWKS_REFRESH_INTERVAL"`
+	JWTJWKSTimeout         time.Duration `mapstructure:"JWT_JWKS_TIMEOUT"`
+	JWTIssuer              string        `mapstructure:"JWT_ISSUER"`
+	JWTAudience            string        `mapstructure:"JWT_AUDIENCE"`
+	JWTLeeway              time.Duration `mapstructure:"JWT_LEEWAY"`
+
+	RateLimitEnabled     bool   `mapstructure:"RATE_LIMIT_ENABLED"`
+	RateLimitIP          string `mapstructure:"RATE_LIMIT_IP"`
+	RateLimitAPIKey      string `mapstructure:"RATE_LIMIT_API_KEY"`
+	RateLimitRoutes      string `mapstructure:"RATE_LIMIT_ROUTES"`
+	RateLimitTrustedHops int    `mapstructure:"RATE_LIMIT_TRUSTED_HOPS"`
+
+	LogLevel       string `mapstructure:"LOG_LEVEL"`
+	LogFormat      string `mapstructure:"LOG_FORMAT"`
+	LogTrustedHops int    `mapstructure:"LOG_TRUSTED_HOPS"`
+
+	MetricsEnabled bool `mapstructure:"METRICS_ENABLED"`
+
+	TracingEndpoint    string  `mapstructure:"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"`
+	TracingServiceName string  `mapstructure:"OTEL_SERVICE_NAME"`
+	TracingSampleRatio float64 `mapstructure:"TRACING_SAMPLE_RATIO"`
+
+	CepRetryMaxAttempts     int           `mapstructure:"CEP_RETRY_MAX_ATTEMPTS"`
+	CepRetryBaseBackoff     time.Duration `mapstructure:"CEP_RETRY_BASE_BACKOFF"`
+	CepRetryMaxBackoff      time.Duration `mapstructure:"CEP_RETRY_MAX_BACKOFF"`
+	CepRetryStatuses        string        `mapstructure:"CEP_RETRY_STATUSES"`
+	CepRetryErrors          string        `mapstructure:"CEP_RETRY_ERRORS"`
+	WeatherRetryMaxAttempts int           `mapstructure:"WEATHER_RETRY_MAX_ATTEMPTS"`
+	WeatherRetryBaseBackoff time.Duration `mapstructure:"WEATHER_RETRY_BASE_BACKOFF"`
+	WeatherRetryMaxBackoff  time.Duration `mapstructure:"WEATHER_RETRY_MAX_BACKOFF"`
+	WeatherRetryStatuses    string        `mapstructure:"WEATHER_RETRY_STATUSES"`
+	WeatherRetryErrors      string        `mapstructure:"WEATHER_RETRY_ERRORS"`
+
+	CepHTTPDialTimeout               time.Duration `mapstructure:"CEP_HTTP_DIAL_TIMEOUT"`
+	CepHTTPTLSHandshakeTimeout       time.Duration `mapstructure:"CEP_HTTP_TLS_HANDSHAKE_TIMEOUT"`
+	CepHTTPResponseHeaderTimeout     time.Duration `mapstructure:"CEP_HTTP_RESPONSE_HEADER_TIMEOUT"`
+	CepHTTPTimeout                   time.Duration `mapstructure:"CEP_HTTP_TIMEOUT"`
+	WeatherHTTPDialTimeout           time.Duration `mapstructure:"WEATHER_HTTP_DIAL_TIMEOUT"`
+	WeatherHTTPTLSHandshakeTimeout   time.Duration `mapstructure:"WEATHER_HTTP_TLS_HANDSHAKE_TIMEOUT"`
+	WeatherHTTPResponseHeaderTimeout time.Duration `mapstructure:"WEATHER_HTTP_RESPONSE_HEADER_TIMEOUT"`
+	WeatherHTTPTimeout               time.Duration `mapstructure:"WEATHER_HTTP_TIMEOUT"`
+	UpstreamMaxIdleConns             int           `mapstructure:"UPSTREAM_MAX_IDLE_CONNS"`
+	UpstreamMaxIdleConnsPerHost      int           `mapstructure:"UPSTREAM_MAX_IDLE_CONNS_PER_HOST"`
+	UpstreamMaxConnsPerHost          int           `mapstructure:"UPSTREAM_MAX_CONNS_PER_HOST"`
+	UpstreamIdleConnTimeout          time.Duration `mapstructure:"UPSTREAM_IDLE_CONN_TIMEOUT"`
+	UpstreamHTTP2                    bool          `mapstructure:"UPSTREAM_HTTP2"`
+	UpstreamProxyURL                 string        `mapstructure:"UPSTREAM_PROXY_URL"`
+	UpstreamCABundle                 string        `mapstructure:"UPSTREAM_CA_BUNDLE"`
+
+	CepHedgeEnabled     bool          `mapstructure:"CEP_HEDGE_ENABLED"`
+	WeatherHedgeEnabled bool          `mapstructure:"WEATHER_HEDGE_ENABLED"`
+	HedgePercentile     float64       `mapstructure:"HEDGE_PERCENTILE"`
+	HedgeMinDelay       time.Duration `mapstructure:"HEDGE_MIN_DELAY"`
+	HedgeMaxDelay       time.Duration `mapstructure:"HEDGE_MAX_DELAY"`
+	HedgeBudget         float64       `mapstructure:"HEDGE_BUDGET"`
+
+	BreakerWindow         time.Duration `mapstructure:"BREAKER_WINDOW"`
+	BreakerMinRequests    int           `mapstructure:"BREAKER_MIN_REQUESTS"`
+	BreakerFailureRate    float64       `mapstructure:"BREAKER_FAILURE_RATE"`
+	BreakerCooldown       time.Duration `mapstructure:"BREAKER_COOLDOWN"`
+	BreakerHalfOpenProbes int           `mapstructure:"BREAKER_HALF_OPEN_PROBES"`
+
+	BulkheadCepMaxConcurrent     int           `mapstructure:"BULKHEAD_CEP_MAX_CONCURRENT"`
+	BulkheadWeatherMaxConcurrent int           `mapstructure:"BULKHEAD_WEATHER_MAX_CONCURRENT"`
+	BulkheadMaxWait              time.Duration `mapstructure:"BULKHEAD_MAX_WAIT"`
+
+	AdmissionEnabled              bool          `mapstructure:"ADMISSION_ENABLED"`
+	AdmissionMaxInFlight          int           `mapstructure:"ADMISSION_MAX_IN_FLIGHT"`
+	AdmissionTargetLatency        time.Duration `mapstructure:"ADMISSION_TARGET_LATENCY"`
+	AdmissionCPUThreshold         float64       `mapstructure:"ADMISSION_CPU_THRESHOLD"`
+	AdmissionMemoryThreshold      float64       `mapstructure:"ADMISSION_MEMORY_THRESHOLD"`
+	AdmissionLowPriorityThreshold float64       `mapstructure:"ADMISSION_LOW_PRIORITY_THRESHOLD"`
+	AdmissionRetryAfter           time.Duration `mapstructure:"ADMISSION_RETRY_AFTER"`
+	AdmissionSampleInterval       time.Duration `mapstructure:"ADMISSION_SAMPLE_INTERVAL"`
+
+	WeatherCacheEnabled              bool          `mapstructure:"WEATHER_CACHE_ENABLED"`
+	WeatherCacheTTL                  time.Duration `mapstructure:"WEATHER_CACHE_TTL"`
+	WeatherCacheStaleWhileRevalidate time.Duration `mapstructure:"WEATHER_CACHE_STALE_WHILE_REVALIDATE"`
+	WeatherCacheStaleIfError         time.Duration `mapstructure:"WEATHER_CACHE_STALE_IF_ERROR"`
+	WeatherDegradedMode              bool          `mapstructure:"WEATHER_DEGRADED_MODE"`
+
+	HealthProbeInterval time.Duration `mapstructure:"HEALTH_PROBE_INTERVAL"`
+	HealthProbeTimeout  time.Duration `mapstructure:"HEALTH_PROBE_TIMEOUT"`
+	HealthCheckTimeout  time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthDiskPath      string        `mapstructure:"HEALTH_DISK_PATH"`
+	HealthCgroupRoot    string        `mapstructure:"HEALTH_CGROUP_ROOT"`
+	HealthVersion       string        `mapstructure:"HEALTH_VERSION"`
+	HealthReleaseID     string        `mapstructure:"HEALTH_RELEASE_ID"`
+	HealthServiceID     string        `mapstructure:"HEALTH_SERVICE_ID"`
+	HealthDescription   string        `mapstructure:"HEALTH_DESCRIPTION"`
+
+	HealthSampleInterval  time.Duration `mapstructure:"HEALTH_SAMPLE_INTERVAL"`
+	HealthSampleRetention time.Duration `mapstructure:"HEALTH_SAMPLE_RETENTION"`
+
+	HealthThresholdHysteresis   float64 `mapstructure:"HEALTH_THRESHOLD_HYSTERESIS"`
+	HealthCPUWarn               float64 `mapstructure:"HEALTH_CPU_WARN"`
+	HealthCPUFail               float64 `mapstructure:"HEALTH_CPU_FAIL"`
+	HealthCPUCoreWarn           float64 `mapstructure:"HEALTH_CPU_CORE_WARN"`
+	HealthCPUCoreFail           float64 `mapstructure:"HEALTH_CPU_CORE_FAIL"`
+	HealthMemoryWarn            float64 `mapstructure:"HEALTH_MEMORY_WARN"`
+	HealthMemoryFail            float64 `mapstructure:"HEALTH_MEMORY_FAIL"`
+	HealthMemoryAvailableWarnMB float64 `mapstructure:"HEALTH_MEMORY_AVAILABLE_WARN_MB"`
+	HealthMemoryAvailableFailMB float64 `mapstructure:"HEALTH_MEMORY_AVAILABLE_FAIL_MB"`
+	HealthDiskWarn              float64 `mapstructure:"HEALTH_DISK_WARN"`
+	HealthDiskFail              float64 `mapstructure:"HEALTH_DISK_FAIL"`
+	HealthGoroutinesWarn        float64 `mapstructure:"HEALTH_GOROUTINES_WARN"`
+	HealthGoroutinesFail        float64 `mapstructure:"HEALTH_GOROUTINES_FAIL"`
+	HealthFDsWarn               float64 `mapstructure:"HEALTH_FDS_WARN"`
+	HealthFDsFail               float64 `mapstructure:"HEALTH_FDS_FAIL"`
+}
+
+func setDefaults() {
+	viper.SetDefault("WEATHER_API_KEYS", "")
+	viper.SetDefault("WEATHER_API_KEYS_FLUSH_INTERVAL", 10*time.Second)
+	viper.SetDefault("SERVER_READ_HEADER_TIMEOUT", 5*time.Second)
+	viper.SetDefault("SERVER_READ_TIMEOUT", 15*time.Second)
+	viper.SetDefault("SERVER_WRITE_TIMEOUT", 30*time.Second)
+	viper.SetDefault("SERVER_IDLE_TIMEOUT", 60*time.Second)
+	viper.SetDefault("SERVER_MAX_HEADER_BYTES", 1<<20)
+	viper.SetDefault("SERVER_MAX_BODY_BYTES", 1<<20)
+	viper.SetDefault("WEATHER_ROUTE_TIMEOUT", 20*time.Second)
+	viper.SetDefault("SHUTDOWN_PRE_STOP_DELAY", 0)
+	viper.SetDefault("SHUTDOWN_DRAIN_TIMEOUT", 10*time.Second)
+	viper.SetDefault("WEATHER_REFRESH_INTERVAL", 15*time.Minute)
+	viper.SetDefault("CEP_CACHE_MAX_AGE", 24*time.Hour)
+	viper.SetDefault("DATA_DIR", "data")
+	viper.SetDefault("SUBSCRIPTION_CHECK_INTERVAL", 5*time.Minute)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 5)
+	viper.SetDefault("WEBHOOK_BASE_BACKOFF", time.Second)
+	viper.SetDefault("WEBHOOK_MAX_BACKOFF", 30*time.Second)
+	viper.SetDefault("WEBHOOK_TIMEOUT", 10*time.Second)
+	viper.SetDefault("WEBHOOK_DEAD_LETTER_LIMIT", 100)
+	viper.SetDefault("ADMIN_TOKEN", "")
+	viper.SetDefault("AUTH_REQUIRED", false)
+	viper.SetDefault("JWT_JWKS_SOURCE", "")
+	viper.SetDefault("JWT_JWKS_REFRESH_INTERVAL", 15*time.Minute)
+	viper.SetDefault("JWT_JWKS_TIMEOUT", 5*time.Second)
+	viper.SetDefault("JWT_ISSUER", "")
+	viper.SetDefault("JWT_AUDIENCE", "")
+	viper.SetDefault("JWT_LEEWAY", 30*time.Second)
+	viper.SetDefault("RATE_LIMIT_ENABLED", true)
+	viper.SetDefault("RATE_LIMIT_IP", "60/1m")
+	viper.SetDefault("RATE_LIMIT_API_KEY", "600/1m")
+	viper.SetDefault("RATE_LIMIT_ROUTES", "")
+	viper.SetDefault("RATE_LIMIT_TRUSTED_HOPS", 0)
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("LOG_FORMAT", "json")
+	viper.SetDefault("LOG_TRUSTED_HOPS", 0)
+	viper.SetDefault("METRICS_ENABLED", true)
+	viper.SetDefault("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	viper.SetDefault("OTEL_SERVICE_NAME", "weatherzip")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	viper.SetDefault("CEP_RETRY_MAX_ATTEMPTS", 3)
+	viper.SetDefault("CEP_RETRY_BASE_BACKOFF", 100*time.Millisecond)
+	viper.SetDefault("CEP_RETRY_MAX_BACKOFF", time.Second)
+	viper.SetDefault("CEP_RETRY_STATUSES", "408,429,502,503,504")
+	viper.SetDefault("CEP_RETRY_ERRORS", "timeout,reset,refused,eof")
+	viper.SetDefault("WEATHER_RETRY_MAX_ATTEMPTS", 3)
+	viper.SetDefault("WEATHER_RETRY_BASE_BACKOFF", 200*time.Millisecond)
+	viper.SetDefault("WEATHER_RETRY_MAX_BACKOFF", 2*time.Second)
+	viper.SetDefault("WEATHER_RETRY_STATUSES", "408,429,502,503,504")
+	viper.SetDefault("WEATHER_RETRY_ERRORS", "timeout,reset,refused,eof")
+	viper.SetDefault("CEP_HTTP_DIAL_TIMEOUT", 2*time.Second)
+	viper.SetDefault("CEP_HTTP_TLS_HANDSHAKE_TIMEOUT", 2*time.Second)
+	viper.SetDefault("CEP_HTTP_RESPONSE_HEADER_TIMEOUT", 3*time.Second)
+	viper.SetDefault("CEP_HTTP_TIMEOUT", 5*time.Second)
+	viper.SetDefault("WEATHER_HTTP_DIAL_TIMEOUT", 2*time.Second)
+	viper.SetDefault("WEATHER_HTTP_TLS_HANDSHAKE_TIMEOUT", 2*time.Second)
+	viper.SetDefault("WEATHER_HTTP_RESPONSE_HEADER_TIMEOUT", 5*time.Second)
+	viper.SetDefault("WEATHER_HTTP_TIMEOUT", 8*time.Second)
+	viper.SetDefault("UPSTREAM_MAX_IDLE_CONNS", 100)
+	viper.SetDefault("UPSTREAM_MAX_IDLE_CONNS_PER_HOST", 10)
+	viper.SetDefault("UPSTREAM_MAX_CONNS_PER_HOST", 0)
+	viper.SetDefault("UPSTREAM_IDLE_CONN_TIMEOUT", 90*time.Second)
+	viper.SetDefault("UPSTREAM_HTTP2", true)
+	viper.SetDefault("UPSTREAM_PROXY_URL", "")
+	viper.SetDefault("UPSTREAM_CA_BUNDLE", "")
+	viper.SetDefault("CEP_HEDGE_ENABLED", false)
+	viper.SetDefault("WEATHER_HEDGE_ENABLED", false)
+	viper.SetDefault("HEDGE_PERCENTILE", 0.95)
+	viper.SetDefault("HEDGE_MIN_DELAY", 50*time.Millisecond)
+	viper.SetDefault("HEDGE_MAX_DELAY", time.Second)
+	viper.SetDefault("HEDGE_BUDGET", 0.1)
+	viper.SetDefault("BREAKER_WINDOW", 30*time.Second)
+	viper.SetDefault("BREAKER_MIN_REQUESTS", 10)
+	viper.SetDefault("BREAKER_FAILURE_RATE", 0.5)
+	viper.SetDefault("BREAKER_COOLDOWN", 15*time.Second)
+	viper.SetDefault("BREAKER_HALF_OPEN_PROBES", 1)
+	viper.SetDefault("BULKHEAD_CEP_MAX_CONCURRENT", 50)
+	viper.SetDefault("BULKHEAD_WEATHER_MAX_CONCURRENT", 50)
+	viper.SetDefault("BULKHEAD_MAX_WAIT", 100*time.Millisecond)
+	viper.SetDefault("ADMISSION_ENABLED", true)
+	viper.SetDefault("ADMISSION_MAX_IN_FLIGHT", 200)
+	viper.SetDefault("ADMISSION_TARGET_LATENCY", 2*time.Second)
+	viper.SetDefault("ADMISSION_CPU_THRESHOLD", 90.0)
+	viper.SetDefault("ADMISSION_MEMORY_THRESHOLD", 90.0)
+	viper.SetDefault("ADMISSION_LOW_PRIORITY_THRESHOLD", 0.8)
+	viper.SetDefault("ADMISSION_RETRY_AFTER", 5*time.Second)
+	viper.SetDefault("ADMISSION_SAMPLE_INTERVAL", time.Second)
+	viper.SetDefault("WEATHER_CACHE_ENABLED", true)
+	viper.SetDefault("WEATHER_CACHE_TTL", 10*time.Minute)
+	viper.SetDefault("WEATHER_CACHE_STALE_WHILE_REVALIDATE", 10*time.Minute)
+	viper.SetDefault("WEATHER_CACHE_STALE_IF_ERROR", time.Hour)
+	viper.SetDefault("WEATHER_DEGRADED_MODE", false)
+	viper.SetDefault("HEALTH_PROBE_INTERVAL", 30*time.Second)
+	viper.SetDefault("HEALTH_PROBE_TIMEOUT", 3*time.Second)
+	viper.SetDefault("HEALTH_CHECK_TIMEOUT", 2*time.Second)
+	viper.SetDefault("HEALTH_DISK_PATH", "/")
+	viper.SetDefault("HEALTH_CGROUP_ROOT", "/sys/fs/cgroup")
+	viper.SetDefault("HEALTH_VERSION", "1")
+	viper.SetDefault("HEALTH_RELEASE_ID", "")
+	viper.SetDefault("HEALTH_SERVICE_ID", "weatherzip")
+	viper.SetDefault("HEALTH_DESCRIPTION", "Current weather by brazilian zipcode")
+	viper.SetDefault("HEALTH_SAMPLE_INTERVAL", 5*time.Second)
+	viper.SetDefault("HEALTH_SAMPLE_RETENTION", time.Hour)
+	viper.SetDefault("HEALTH_THRESHOLD_HYSTERESIS", 0.05)
+	viper.SetDefault("HEALTH_CPU_WARN", 80.0)
+	viper.SetDefault("HEALTH_CPU_FAIL", 95.0)
+	viper.SetDefault("HEALTH_CPU_CORE_WARN", 95.0)
+	viper.SetDefault("HEALTH_CPU_CORE_FAIL", 0.0)
+	viper.SetDefault("HEALTH_MEMORY_WARN", 85.0)
+	viper.SetDefault("HEALTH_MEMORY_FAIL", 95.0)
+	viper.SetDefault("HEALTH_MEMORY_AVAILABLE_WARN_MB", 256.0)
+	viper.SetDefault("HEALTH_MEMORY_AVAILABLE_FAIL_MB", 64.0)
+	viper.SetDefault("HEALTH_DISK_WARN", 85.0)
+	viper.SetDefault("HEALTH_DISK_FAIL", 95.0)
+	viper.SetDefault("HEALTH_GOROUTINES_WARN", 5000.0)
+	viper.SetDefault("HEALTH_GOROUTINES_FAIL", 20000.0)
+	viper.SetDefault("HEALTH_FDS_WARN", 1000.0)
+	viper.SetDefault("HEALTH_FDS_FAIL", 10000.0)
 }
 
 func LoadConfig(path string) (*conf, error) {
@@ -21,6 +283,7 @@ func LoadConfig(path string) (*conf, error) {
 	viper.AddConfigPath(path)
 	viper.SetConfigFile(".env")
 	viper.AutomaticEnv()
+	setDefaults()
 	err := viper.ReadInConfig()
 	if err != nil {
 		panic(err)
@@ -30,9 +293,15 @@ func LoadConfig(path string) (*conf, error) {
 		panic(err)
 	}
 
-	if cfg.WebServerPort == "" || cfg.CepAPIUrl == "" || cfg.WeatherAPIUrl == "" || cfg.WeatherAPIKey == "" {
+	if cfg.WebServerPort == "" || cfg.CepAPIUrl == "" || cfg.WeatherAPIUrl == "" || (cfg.WeatherAPIKey == "" && cfg.WeatherAPIKeys == "") {
 		panic("missing required configuration")
 	}
+	if cfg.JWTJWKSSource != "" && (cfg.JWTIssuer == "" || cfg.JWTAudience == "") {
+		panic("JWT_ISSUER and JWT_AUDIENCE are required when JWT_JWKS_SOURCE is set")
+	}
+	if cfg.HealthSampleInterval <= 0 || cfg.HealthSampleRetention < cfg.HealthSampleInterval {
+		panic("HEALTH_SAMPLE_INTERVAL must be positive and HEALTH_SAMPLE_RETENTION must not be shorter than it")
+	}
 
 	return cfg, err
 }
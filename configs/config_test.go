@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -66,6 +67,52 @@ WEATHER_LANGUAGE=pt
 	}, "LoadConfig should panic when Unmarshal fails")
 }
 
+func TestLoadConfigJWTRequiresIssuerAndAudience(t *testing.T) {
+	envContent := `
+WEB_SERVER_PORT=8080
+CEP_API_URL=http://example.com/cep
+WEATHER_API_URL=http://example.com/weather
+WEATHER_API_KEY=testkey
+JWT_JWKS_SOURCE=https://idp.example.com/jwks.json
+JWT_ISSUER=https://idp.example.com
+`
+	envFilePath := ".env"
+	err := os.WriteFile(envFilePath, []byte(envContent), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(envFilePath)
+
+	assert.Panics(t, func() {
+		_, _ = LoadConfig(".")
+	}, "LoadConfig should panic when JWT is enabled without an audience")
+
+	err = os.WriteFile(envFilePath, []byte(envContent+"JWT_AUDIENCE=weatherzip\n"), 0644)
+	assert.NoError(t, err)
+
+	cfg, err := LoadConfig(".")
+	assert.NoError(t, err)
+	assert.Equal(t, "weatherzip", cfg.JWTAudience)
+}
+
+func TestLoadConfigValidatesSampleWindow(t *testing.T) {
+	envContent := `
+WEB_SERVER_PORT=8080
+CEP_API_URL=http://example.com/cep
+WEATHER_API_URL=http://example.com/weather
+WEATHER_API_KEY=testkey
+`
+	envFilePath := ".env"
+	defer os.Remove(envFilePath)
+
+	for _, invalid := range []string{"HEALTH_SAMPLE_INTERVAL=0\n", "HEALTH_SAMPLE_INTERVAL=1m\nHEALTH_SAMPLE_RETENTION=30s\n"} {
+		err := os.WriteFile(envFilePath, []byte(envContent+invalid), 0644)
+		assert.NoError(t, err)
+
+		assert.Panics(t, func() {
+			_, _ = LoadConfig(".")
+		}, "LoadConfig should panic for %q", invalid)
+	}
+}
+
 func TestLoadConfig(t *testing.T) {
 	envContent := `
 WEB_SERVER_PORT=8080
@@ -89,3 +136,181 @@ WEATHER_LANGUAGE=en
 	assert.Equal(t, "testkey", cfg.WeatherAPIKey)
 	assert.Equal(t, "en", cfg.WeatherAPILanguage)
 }
+
+func TestLoadConfigDefaults(t *testing.T) {
+	envContent := `
+WEB_SERVER_PORT=8080
+CEP_API_URL=http://example.com/cep
+WEATHER_API_URL=http://example.com/weather
+WEATHER_API_KEY=testkey
+`
+	envFilePath := ".env"
+	err := os.WriteFile(envFilePath, []byte(envContent), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(envFilePath)
+
+	cfg, err := LoadConfig(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 5*time.Second, cfg.ServerReadHeaderTimeout)
+	assert.Equal(t, 15*time.Second, cfg.ServerReadTimeout)
+	assert.Equal(t, 30*time.Second, cfg.ServerWriteTimeout)
+	assert.Equal(t, 60*time.Second, cfg.ServerIdleTimeout)
+	assert.Equal(t, 1<<20, cfg.ServerMaxHeaderBytes)
+	assert.Equal(t, int64(1<<20), cfg.ServerMaxBodyBytes)
+	assert.Equal(t, 20*time.Second, cfg.WeatherRouteTimeout)
+	assert.Equal(t, time.Duration(0), cfg.ShutdownPreStopDelay)
+	assert.Equal(t, 10*time.Second, cfg.ShutdownDrainTimeout)
+	assert.Equal(t, 15*time.Minute, cfg.WeatherRefreshInterval)
+	assert.Equal(t, 24*time.Hour, cfg.CepCacheMaxAge)
+	assert.Equal(t, "data", cfg.DataDir)
+	assert.Empty(t, cfg.WeatherAPIKeys)
+	assert.Equal(t, 10*time.Second, cfg.WeatherAPIKeysFlushInterval)
+	assert.Equal(t, 5*time.Minute, cfg.SubscriptionCheckInterval)
+	assert.Equal(t, 5, cfg.WebhookMaxAttempts)
+	assert.Equal(t, time.Second, cfg.WebhookBaseBackoff)
+	assert.Equal(t, 30*time.Second, cfg.WebhookMaxBackoff)
+	assert.Equal(t, 10*time.Second, cfg.WebhookTimeout)
+	assert.Equal(t, 100, cfg.WebhookDeadLetterLimit)
+	assert.Empty(t, cfg.AdminToken)
+	assert.False(t, cfg.AuthRequired)
+	assert.Empty(t, cfg.JWTJWKSSource)
+	assert.Equal(t, 15*time.Minute, cfg.JWTJWKSRefreshInterval)
+	assert.Equal(t, 5*time.Second, cfg.JWTJWKSTimeout)
+	assert.Equal(t, 30*time.Second, cfg.JWTLeeway)
+	assert.True(t, cfg.RateLimitEnabled)
+	assert.Equal(t, "60/1m", cfg.RateLimitIP)
+	assert.Equal(t, "600/1m", cfg.RateLimitAPIKey)
+	assert.Empty(t, cfg.RateLimitRoutes)
+	assert.Zero(t, cfg.RateLimitTrustedHops)
+	assert.Equal(t, "info", cfg.LogLevel)
+	assert.Equal(t, "json", cfg.LogFormat)
+	assert.Zero(t, cfg.LogTrustedHops)
+	assert.True(t, cfg.MetricsEnabled)
+	assert.Empty(t, cfg.TracingEndpoint)
+	assert.Equal(t, "weatherzip", cfg.TracingServiceName)
+	assert.Equal(t, 1.0, cfg.TracingSampleRatio)
+	assert.Equal(t, 3, cfg.CepRetryMaxAttempts)
+	assert.Equal(t, 100*time.Millisecond, cfg.CepRetryBaseBackoff)
+	assert.Equal(t, "408,429,502,503,504", cfg.CepRetryStatuses)
+	assert.Equal(t, 2*time.Second, cfg.WeatherRetryMaxBackoff)
+	assert.Equal(t, "timeout,reset,refused,eof", cfg.WeatherRetryErrors)
+	assert.Equal(t, 2*time.Second, cfg.CepHTTPDialTimeout)
+	assert.Equal(t, 5*time.Second, cfg.CepHTTPTimeout)
+	assert.Equal(t, 5*time.Second, cfg.WeatherHTTPResponseHeaderTimeout)
+	assert.Equal(t, 8*time.Second, cfg.WeatherHTTPTimeout)
+	assert.Equal(t, 10, cfg.UpstreamMaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, cfg.UpstreamIdleConnTimeout)
+	assert.True(t, cfg.UpstreamHTTP2)
+	assert.Empty(t, cfg.UpstreamProxyURL)
+	assert.Empty(t, cfg.UpstreamCABundle)
+	assert.False(t, cfg.CepHedgeEnabled)
+	assert.False(t, cfg.WeatherHedgeEnabled)
+	assert.Equal(t, 0.95, cfg.HedgePercentile)
+	assert.Equal(t, 50*time.Millisecond, cfg.HedgeMinDelay)
+	assert.Equal(t, time.Second, cfg.HedgeMaxDelay)
+	assert.Equal(t, 0.1, cfg.HedgeBudget)
+	assert.Equal(t, 30*time.Second, cfg.BreakerWindow)
+	assert.Equal(t, 10, cfg.BreakerMinRequests)
+	assert.Equal(t, 0.5, cfg.BreakerFailureRate)
+	assert.Equal(t, 15*time.Second, cfg.BreakerCooldown)
+	assert.Equal(t, 1, cfg.BreakerHalfOpenProbes)
+	assert.Equal(t, 50, cfg.BulkheadCepMaxConcurrent)
+	assert.Equal(t, 50, cfg.BulkheadWeatherMaxConcurrent)
+	assert.Equal(t, 100*time.Millisecond, cfg.BulkheadMaxWait)
+	assert.True(t, cfg.AdmissionEnabled)
+	assert.Equal(t, 200, cfg.AdmissionMaxInFlight)
+	assert.Equal(t, 2*time.Second, cfg.AdmissionTargetLatency)
+	assert.Equal(t, 90.0, cfg.AdmissionCPUThreshold)
+	assert.Equal(t, 90.0, cfg.AdmissionMemoryThreshold)
+	assert.Equal(t, 0.8, cfg.AdmissionLowPriorityThreshold)
+	assert.Equal(t, 5*time.Second, cfg.AdmissionRetryAfter)
+	assert.Equal(t, time.Second, cfg.AdmissionSampleInterval)
+	assert.True(t, cfg.WeatherCacheEnabled)
+	assert.Equal(t, 10*time.Minute, cfg.WeatherCacheTTL)
+	assert.Equal(t, 10*time.Minute, cfg.WeatherCacheStaleWhileRevalidate)
+	assert.Equal(t, time.Hour, cfg.WeatherCacheStaleIfError)
+	assert.False(t, cfg.WeatherDegradedMode)
+	assert.Equal(t, 30*time.Second, cfg.HealthProbeInterval)
+	assert.Equal(t, 3*time.Second, cfg.HealthProbeTimeout)
+	assert.Equal(t, 2*time.Second, cfg.HealthCheckTimeout)
+	assert.Equal(t, "/", cfg.HealthDiskPath)
+	assert.Equal(t, "/sys/fs/cgroup", cfg.HealthCgroupRoot)
+	assert.Equal(t, 5*time.Second, cfg.HealthSampleInterval)
+	assert.Equal(t, time.Hour, cfg.HealthSampleRetention)
+	assert.Equal(t, "1", cfg.HealthVersion)
+	assert.Empty(t, cfg.HealthReleaseID)
+	assert.Equal(t, "weatherzip", cfg.HealthServiceID)
+	assert.Equal(t, "Current weather by brazilian zipcode", cfg.HealthDescription)
+	assert.Equal(t, 0.05, cfg.HealthThresholdHysteresis)
+	assert.Equal(t, 80.0, cfg.HealthCPUWarn)
+	assert.Equal(t, 95.0, cfg.HealthCPUFail)
+	assert.Equal(t, 95.0, cfg.HealthCPUCoreWarn)
+	assert.Zero(t, cfg.HealthCPUCoreFail)
+	assert.Equal(t, 85.0, cfg.HealthMemoryWarn)
+	assert.Equal(t, 95.0, cfg.HealthMemoryFail)
+	assert.Equal(t, 256.0, cfg.HealthMemoryAvailableWarnMB)
+	assert.Equal(t, 64.0, cfg.HealthMemoryAvailableFailMB)
+	assert.Equal(t, 85.0, cfg.HealthDiskWarn)
+	assert.Equal(t, 95.0, cfg.HealthDiskFail)
+	assert.Equal(t, 5000.0, cfg.HealthGoroutinesWarn)
+	assert.Equal(t, 20000.0, cfg.HealthGoroutinesFail)
+	assert.Equal(t, 1000.0, cfg.HealthFDsWarn)
+	assert.Equal(t, 10000.0, cfg.HealthFDsFail)
+}
+
+func TestLoadConfigWithWeatherAPIKeyPool(t *testing.T) {
+	envContent := `
+WEB_SERVER_PORT=8080
+CEP_API_URL=http://example.com/cep
+WEATHER_API_URL=http://example.com/weather
+WEATHER_API_KEYS=key-a:3:100:2000,key-b
+`
+	envFilePath := ".env"
+	err := os.WriteFile(envFilePath, []byte(envContent), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(envFilePath)
+
+	cfg, err := LoadConfig(".")
+	assert.NoError(t, err)
+
+	assert.Empty(t, cfg.WeatherAPIKey)
+	assert.Equal(t, "key-a:3:100:2000,key-b", cfg.WeatherAPIKeys)
+}
+
+func TestLoadConfigOverridesDefaults(t *testing.T) {
+	envContent := `
+WEB_SERVER_PORT=8080
+CEP_API_URL=http://example.com/cep
+WEATHER_API_URL=http://example.com/weather
+WEATHER_API_KEY=testkey
+DATA_DIR=/var/lib/weatherzip
+SUBSCRIPTION_CHECK_INTERVAL=30s
+WEBHOOK_MAX_ATTEMPTS=2
+ADMIN_TOKEN=s3cret
+AUTH_REQUIRED=true
+JWT_JWKS_SOURCE=https://idp.example.com/.well-known/jwks.json
+JWT_ISSUER=https://idp.example.com
+JWT_AUDIENCE=weatherzip
+RATE_LIMIT_ENABLED=false
+RATE_LIMIT_ROUTES=/weather=30/1m,300/1m
+`
+	envFilePath := ".env"
+	err := os.WriteFile(envFilePath, []byte(envContent), 0644)
+	assert.NoError(t, err)
+	defer os.Remove(envFilePath)
+
+	cfg, err := LoadConfig(".")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/var/lib/weatherzip", cfg.DataDir)
+	assert.Equal(t, 30*time.Second, cfg.SubscriptionCheckInterval)
+	assert.Equal(t, 2, cfg.WebhookMaxAttempts)
+	assert.Equal(t, "s3cret", cfg.AdminToken)
+	assert.True(t, cfg.AuthRequired)
+	assert.Equal(t, "https://idp.example.com/.well-known/jwks.json", cfg.JWTJWKSSource)
+	assert.Equal(t, "https://idp.example.com", cfg.JWTIssuer)
+	assert.Equal(t, "weatherzip", cfg.JWTAudience)
+	assert.False(t, cfg.RateLimitEnabled)
+	assert.Equal(t, "/weather=30/1m,300/1m", cfg.RateLimitRoutes)
+}
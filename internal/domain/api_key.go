@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	ScopeWeatherRead        = "weather:read"
+	ScopeSubscriptionsWrite = "subscriptions:write"
+	ScopeHealthDetail       = "health:detail"
+	ScopeAdmin              = "admin"
+)
+
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+var knownScopes = []string{ScopeWeatherRead, ScopeSubscriptionsWrite, ScopeHealthDetail, ScopeAdmin}
+
+type APIKeyRequest struct {
+	Tenant    string     `json:"tenant"`
+	Scopes    []string   `json:"scopes"`
+	Quota     int        `json:"quota"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKey struct {
+	ID        string     `json:"id"`
+	Tenant    string     `json:"tenant"`
+	Scopes    []string   `json:"scopes"`
+	Quota     int        `json:"quota"`
+	Prefix    string     `json:"prefix"`
+	Hash      string     `json:"hash,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type Principal struct {
+	ID     string   `json:"id"`
+	Tenant string   `json:"tenant"`
+	Scopes []string `json:"scopes"`
+	Quota  int      `json:"quota,omitempty"`
+	Method string   `json:"method"`
+}
+
+func IsKnownScope(scope string) bool {
+	return slices.Contains(knownScopes, scope)
+}
+
+func (k APIKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+func (k APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k APIKey) Principal() Principal {
+	return Principal{
+		ID:     k.ID,
+		Tenant: k.Tenant,
+		Scopes: k.Scopes,
+		Quota:  k.Quota,
+		Method: AuthMethodAPIKey,
+	}
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsKnownScope(t *testing.T) {
+	assert.True(t, IsKnownScope(ScopeWeatherRead))
+	assert.True(t, IsKnownScope(ScopeAdmin))
+	assert.False(t, IsKnownScope("weather:write"))
+}
+
+func TestAPIKeyState(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(time.Hour)
+	key := APIKey{ID: "key-1", Tenant: "partner", Scopes: []string{ScopeWeatherRead}, Quota: 100, ExpiresAt: &expiresAt}
+
+	assert.False(t, key.IsExpired(now))
+	assert.True(t, key.IsExpired(now.Add(time.Hour)))
+	assert.False(t, key.IsRevoked())
+	assert.False(t, APIKey{}.IsExpired(now), "Keys without expiry never expire")
+
+	key.RevokedAt = &now
+	assert.True(t, key.IsRevoked())
+}
+
+func TestAPIKeyPrincipal(t *testing.T) {
+	key := APIKey{ID: "key-1", Tenant: "partner", Scopes: []string{ScopeWeatherRead}, Quota: 100}
+
+	principal := key.Principal()
+
+	assert.Equal(t, Principal{ID: "key-1", Tenant: "partner", Scopes: []string{ScopeWeatherRead}, Quota: 100, Method: AuthMethodAPIKey}, principal)
+	assert.True(t, principal.HasScope(ScopeWeatherRead))
+	assert.False(t, principal.HasScope(ScopeAdmin))
+}
@@ -10,6 +10,24 @@ type CepResponse struct {
 	Regiao     string `json:"regiao,omitempty"`
 }
 
+type Address struct {
+	Zipcode      string `json:"zipcode"`
+	Street       string `json:"street"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+}
+
+func (c CepResponse) Address() Address {
+	return Address{
+		Zipcode:      c.Cep,
+		Street:       c.Logradouro,
+		Neighborhood: c.Bairro,
+		City:         c.Localidade,
+		State:        c.Uf,
+	}
+}
+
 func (c *CepResponse) PopulateFromMap(data map[string]interface{}) error {
 	if erro, ok := data["erro"].(string); ok && erro == "true" {
 		return ErrZipcodeNotFound
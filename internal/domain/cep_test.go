@@ -71,3 +71,12 @@ func TestCepResponsePopulateFromMap(t *testing.T) {
 		})
 	}
 }
+
+func TestCepResponseAddress(t *testing.T) {
+	location := CepResponse{Cep: "01001-000", Logradouro: "Praça da Sé", Bairro: "Sé", Localidade: "São Paulo", Uf: "SP", Estado: "São Paulo", Regiao: "Sudeste"}
+	expected := Address{Zipcode: "01001-000", Street: "Praça da Sé", Neighborhood: "Sé", City: "São Paulo", State: "SP"}
+
+	if address := location.Address(); address != expected {
+		t.Errorf("Expected address %+v, got %+v", expected, address)
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{ErrInvalidZipcode, "invalid_zipcode"},
+	{ErrZipcodeNotFound, "zipcode_not_found"},
+	{ErrLocationNotFound, "location_not_found"},
+	{ErrParameterNotProvided, "parameter_not_provided"},
+	{ErrApiUrlIsInvalid, "api_url_invalid"},
+	{ErrJsonBodyIsInvalid, "json_body_invalid"},
+	{ErrTooManyLocations, "too_many_locations"},
+	{ErrInternalApplication, "internal_application"},
+	{ErrUnexpectedBadRequest, "unexpected_bad_request"},
+	{ErrUpstreamUnavailable, "upstream_unavailable"},
+	{ErrUpstreamQuotaExceeded, "upstream_quota_exceeded"},
+	{ErrUpstreamKeyRejected, "upstream_key_rejected"},
+	{context.DeadlineExceeded, "timeout"},
+	{context.Canceled, "canceled"},
+}
+
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for _, known := range errorCodes {
+		if errors.Is(err, known.err) {
+			return known.code
+		}
+	}
+	return "other"
+}
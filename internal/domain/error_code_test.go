@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{nil, ""},
+		{ErrLocationNotFound, "location_not_found"},
+		{ErrUpstreamQuotaExceeded, "upstream_quota_exceeded"},
+		{NewFailedToMakeRequestError(context.DeadlineExceeded), "timeout"},
+		{errors.New("boom"), "other"},
+	}
+
+	for _, tt := range tests {
+		if code := ErrorCode(tt.err); code != tt.expected {
+			t.Errorf("Expected code %q for %v, got %q", tt.expected, tt.err, code)
+		}
+	}
+}
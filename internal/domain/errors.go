@@ -3,6 +3,9 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 )
 
 var (
@@ -23,6 +26,21 @@ var (
 	ErrInvalidStreetData         = errors.New("invalid street data")
 	ErrInvalidNeighborhoodData   = errors.New("invalid neighborhood data")
 	ErrInvalidFederativeUnitData = errors.New("invalid federative unit data")
+	ErrSubscriptionNotFound      = errors.New("subscription not found")
+	ErrInvalidCondition          = errors.New("invalid subscription condition")
+	ErrInvalidCallbackURL        = errors.New("invalid callback url")
+	ErrAPIKeyNotFound            = errors.New("api key not found")
+	ErrInvalidAPIKey             = errors.New("invalid api key")
+	ErrAPIKeyExpired             = errors.New("api key expired")
+	ErrAPIKeyRevoked             = errors.New("api key revoked")
+	ErrInvalidTenant             = errors.New("invalid tenant")
+	ErrInvalidScope              = errors.New("invalid scope")
+	ErrInvalidQuota              = errors.New("invalid quota")
+	ErrInvalidToken              = errors.New("invalid token")
+	ErrUpstreamUnavailable       = errors.New("upstream unavailable")
+	ErrUpstreamQuotaExceeded     = errors.New("upstream quota exceeded")
+	ErrUpstreamKeyRejected       = errors.New("upstream api key rejected")
+	ErrInvalidUpstreamKeySpec    = errors.New("invalid upstream api key spec")
 )
 
 func NewUnexpectedStatusCodeError(statusCode int) error {
@@ -40,3 +58,67 @@ func NewFailedToMakeRequestError(err error) error {
 func NewFailedToDecodeResponseError(err error) error {
 	return fmt.Errorf("failed to decode response: %w", err)
 }
+
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func RedactURLError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	raw := urlErr.URL
+	urlErr.URL = redactURL(raw)
+	if raw == urlErr.URL {
+		return err
+	}
+
+	message := strings.ReplaceAll(err.Error(), strconv.Quote(raw), strconv.Quote(urlErr.URL))
+	return &redactedError{message: strings.ReplaceAll(message, raw, urlErr.URL), err: err}
+}
+
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	parsed.User = nil
+	parsed.RawQuery = ""
+	parsed.ForceQuery = false
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+func NewWebhookDeliveryError(statusCode int) error {
+	return fmt.Errorf("webhook delivery failed with status code: %d", statusCode)
+}
+
+type WeatherStepError struct {
+	Location CepResponse
+	Err      error
+}
+
+func (e *WeatherStepError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *WeatherStepError) Unwrap() error {
+	return e.Err
+}
+
+func IsTransientWeatherError(err error) bool {
+	return !errors.Is(err, ErrInvalidZipcode) &&
+		!errors.Is(err, ErrZipcodeNotFound) &&
+		!errors.Is(err, ErrLocationNotFound)
+}
@@ -0,0 +1,223 @@
+package domain
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EventConditionStarted = "condition.started"
+	EventConditionStopped = "condition.stopped"
+	EventConditionChanged = "condition.changed"
+)
+
+var conditionFields = map[string]bool{
+	"temp_C":    true,
+	"temp_F":    true,
+	"temp_K":    true,
+	"humidity":  true,
+	"wind_kph":  true,
+	"condition": true,
+}
+
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+type Condition struct {
+	Field    string `json:"field"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+}
+
+type SubscriptionRequest struct {
+	Cep         string `json:"cep"`
+	Condition   string `json:"condition"`
+	CallbackURL string `json:"callback_url"`
+	Secret      string `json:"secret,omitempty"`
+}
+
+type Subscription struct {
+	ID            string    `json:"id"`
+	Tenant        string    `json:"tenant,omitempty"`
+	Cep           string    `json:"cep"`
+	Condition     Condition `json:"condition"`
+	Expression    string    `json:"expression"`
+	CallbackURL   string    `json:"callback_url"`
+	Secret        string    `json:"secret,omitempty"`
+	Matching      bool      `json:"matching"`
+	LastObserved  string    `json:"last_observed,omitempty"`
+	LastCheckedAt time.Time `json:"last_checked_at,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type WebhookEvent struct {
+	ID             string         `json:"id"`
+	Type           string         `json:"type"`
+	SubscriptionID string         `json:"subscription_id"`
+	Cep            string         `json:"cep"`
+	Condition      string         `json:"condition"`
+	Weather        WebhookWeather `json:"weather"`
+	Timestamp      time.Time      `json:"timestamp"`
+}
+
+type WebhookWeather struct {
+	TempC     float64 `json:"temp_C"`
+	TempF     float64 `json:"temp_F"`
+	TempK     float64 `json:"temp_K"`
+	Humidity  int     `json:"humidity"`
+	WindKph   float64 `json:"wind_kph"`
+	Condition string  `json:"condition"`
+}
+
+type DeadLetter struct {
+	ID             string       `json:"id"`
+	Tenant         string       `json:"tenant,omitempty"`
+	SubscriptionID string       `json:"subscription_id"`
+	CallbackURL    string       `json:"callback_url"`
+	Event          WebhookEvent `json:"event"`
+	Attempts       int          `json:"attempts"`
+	LastError      string       `json:"last_error"`
+	FailedAt       time.Time    `json:"failed_at"`
+}
+
+func IsPublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func IsPublicCallbackHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return IsPublicIP(ip)
+	}
+	return true
+}
+
+func ParseCondition(expression string) (Condition, error) {
+	parts := strings.Fields(expression)
+
+	if len(parts) == 2 && parts[0] == "condition" && parts[1] == "changes" {
+		return Condition{Field: "condition", Operator: "changes"}, nil
+	}
+
+	if len(parts) < 3 || !conditionFields[parts[0]] {
+		return Condition{}, ErrInvalidCondition
+	}
+
+	field, operator := parts[0], parts[1]
+	value := strings.Trim(strings.Join(parts[2:], " "), `"'`)
+
+	if field == "condition" {
+		if operator != "==" && operator != "!=" {
+			return Condition{}, ErrInvalidCondition
+		}
+		return Condition{Field: field, Operator: operator, Value: value}, nil
+	}
+
+	switch operator {
+	case ">", ">=", "<", "<=", "==", "!=":
+	default:
+		return Condition{}, ErrInvalidCondition
+	}
+
+	if len(parts) != 3 {
+		return Condition{}, ErrInvalidCondition
+	}
+
+	if _, err := strconv.ParseFloat(value, 64); err != nil {
+		return Condition{}, ErrInvalidCondition
+	}
+
+	return Condition{Field: field, Operator: operator, Value: value}, nil
+}
+
+func (c Condition) IsChangeTrigger() bool {
+	return c.Operator == "changes"
+}
+
+func (c Condition) Observe(current CurrentWeather) string {
+	switch c.Field {
+	case "temp_C":
+		return strconv.FormatFloat(current.TempC, 'f', -1, 64)
+	case "temp_F":
+		return strconv.FormatFloat(current.TempF, 'f', -1, 64)
+	case "temp_K":
+		return strconv.FormatFloat(current.TempK, 'f', -1, 64)
+	case "humidity":
+		return strconv.Itoa(current.Humidity)
+	case "wind_kph":
+		return strconv.FormatFloat(current.WindKph, 'f', -1, 64)
+	default:
+		return current.Condition.Text
+	}
+}
+
+func (c Condition) Matches(current CurrentWeather) bool {
+	observed := c.Observe(current)
+
+	if c.Field == "condition" {
+		switch c.Operator {
+		case "==":
+			return strings.EqualFold(observed, c.Value)
+		case "!=":
+			return !strings.EqualFold(observed, c.Value)
+		default:
+			return false
+		}
+	}
+
+	actual, err := strconv.ParseFloat(observed, 64)
+	if err != nil {
+		return false
+	}
+	expected, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return false
+	}
+
+	switch c.Operator {
+	case ">":
+		return actual > expected
+	case ">=":
+		return actual >= expected
+	case "<":
+		return actual < expected
+	case "<=":
+		return actual <= expected
+	case "==":
+		return actual == expected
+	case "!=":
+		return actual != expected
+	default:
+		return false
+	}
+}
+
+func NewWebhookWeather(current CurrentWeather) WebhookWeather {
+	return WebhookWeather{
+		TempC:     current.TempC,
+		TempF:     current.TempF,
+		TempK:     current.TempK,
+		Humidity:  current.Humidity,
+		WindKph:   current.WindKph,
+		Condition: current.Condition.Text,
+	}
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expectErr  error
+		output     Condition
+	}{
+		{
+			name:       "Numeric Threshold",
+			expression: "temp_C > 35",
+			output:     Condition{Field: "temp_C", Operator: ">", Value: "35"},
+		},
+		{
+			name:       "Decimal Threshold",
+			expression: "wind_kph <= 12.5",
+			output:     Condition{Field: "wind_kph", Operator: "<=", Value: "12.5"},
+		},
+		{
+			name:       "Condition Text Equality",
+			expression: `condition == "Partly cloudy"`,
+			output:     Condition{Field: "condition", Operator: "==", Value: "Partly cloudy"},
+		},
+		{
+			name:       "Condition Text Changes",
+			expression: "condition changes",
+			output:     Condition{Field: "condition", Operator: "changes"},
+		},
+		{
+			name:       "Unknown Field",
+			expression: "pressure > 1000",
+			expectErr:  ErrInvalidCondition,
+		},
+		{
+			name:       "Unknown Operator",
+			expression: "temp_C => 35",
+			expectErr:  ErrInvalidCondition,
+		},
+		{
+			name:       "Non Numeric Threshold",
+			expression: "temp_C > hot",
+			expectErr:  ErrInvalidCondition,
+		},
+		{
+			name:       "Too Many Tokens",
+			expression: "temp_C > 35 40",
+			expectErr:  ErrInvalidCondition,
+		},
+		{
+			name:       "Ordering On Condition Text",
+			expression: "condition > Sunny",
+			expectErr:  ErrInvalidCondition,
+		},
+		{
+			name:       "Empty Expression",
+			expression: "",
+			expectErr:  ErrInvalidCondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseCondition(tt.expression)
+
+			if !errors.Is(err, tt.expectErr) {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+
+			if result != tt.output {
+				t.Errorf("Expected output %+v, got %+v", tt.output, result)
+			}
+		})
+	}
+}
+
+func TestConditionMatches(t *testing.T) {
+	current := CurrentWeather{
+		TempC:     36.5,
+		TempF:     97.7,
+		TempK:     309.65,
+		Humidity:  40,
+		WindKph:   10,
+		Condition: WeatherCondition{Text: "Sunny"},
+	}
+
+	tests := []struct {
+		condition Condition
+		expected  bool
+	}{
+		{Condition{Field: "temp_C", Operator: ">", Value: "35"}, true},
+		{Condition{Field: "temp_C", Operator: "<", Value: "35"}, false},
+		{Condition{Field: "temp_F", Operator: ">=", Value: "97.7"}, true},
+		{Condition{Field: "temp_K", Operator: "<=", Value: "300"}, false},
+		{Condition{Field: "humidity", Operator: "==", Value: "40"}, true},
+		{Condition{Field: "wind_kph", Operator: "!=", Value: "10"}, false},
+		{Condition{Field: "condition", Operator: "==", Value: "sunny"}, true},
+		{Condition{Field: "condition", Operator: "!=", Value: "Sunny"}, false},
+		{Condition{Field: "condition", Operator: "changes"}, false},
+		{Condition{Field: "temp_C", Operator: "??", Value: "35"}, false},
+		{Condition{Field: "temp_C", Operator: ">", Value: "invalid"}, false},
+	}
+
+	for _, tt := range tests {
+		if result := tt.condition.Matches(current); result != tt.expected {
+			t.Errorf("For condition %+v, expected %v, got %v", tt.condition, tt.expected, result)
+		}
+	}
+}
+
+func TestConditionObserve(t *testing.T) {
+	current := CurrentWeather{TempC: 25.5, Humidity: 80, Condition: WeatherCondition{Text: "Mist"}}
+
+	if observed := (Condition{Field: "temp_C"}).Observe(current); observed != "25.5" {
+		t.Errorf("Expected observed value %q, got %q", "25.5", observed)
+	}
+
+	if observed := (Condition{Field: "humidity"}).Observe(current); observed != "80" {
+		t.Errorf("Expected observed value %q, got %q", "80", observed)
+	}
+
+	if observed := (Condition{Field: "condition", Operator: "changes"}).Observe(current); observed != "Mist" {
+		t.Errorf("Expected observed value %q, got %q", "Mist", observed)
+	}
+
+	if !(Condition{Operator: "changes"}).IsChangeTrigger() {
+		t.Errorf("Expected 'changes' operator to be a change trigger")
+	}
+}
+
+func TestNewWebhookWeather(t *testing.T) {
+	current := CurrentWeather{
+		TempC:     25,
+		TempF:     77,
+		TempK:     298.15,
+		Humidity:  60,
+		WindKph:   5.4,
+		Condition: WeatherCondition{Text: "Sunny", Icon: "icon_url"},
+	}
+
+	expected := WebhookWeather{TempC: 25, TempF: 77, TempK: 298.15, Humidity: 60, WindKph: 5.4, Condition: "Sunny"}
+	if result := NewWebhookWeather(current); result != expected {
+		t.Errorf("Expected output %+v, got %+v", expected, result)
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"10.0.0.5", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if result := IsPublicIP(netip.MustParseAddr(tt.ip)); result != tt.expected {
+			t.Errorf("For ip %s, expected %v, got %v", tt.ip, tt.expected, result)
+		}
+	}
+}
+
+func TestIsPublicCallbackHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected bool
+	}{
+		{"example.com", true},
+		{"93.184.216.34", true},
+		{"localhost", false},
+		{"api.localhost", false},
+		{"LOCALHOST.", false},
+		{"169.254.169.254", false},
+		{"::1", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if result := IsPublicCallbackHost(tt.host); result != tt.expected {
+			t.Errorf("For host %q, expected %v, got %v", tt.host, tt.expected, result)
+		}
+	}
+}
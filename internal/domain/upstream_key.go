@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+const (
+	UpstreamKeyActive    = "active"
+	UpstreamKeyExhausted = "exhausted"
+	UpstreamKeyDisabled  = "disabled"
+)
+
+type UpstreamKeyUsage struct {
+	ID             string     `json:"id"`
+	Day            string     `json:"day"`
+	DailyCalls     int64      `json:"daily_calls"`
+	Month          string     `json:"month"`
+	MonthlyCalls   int64      `json:"monthly_calls"`
+	ExhaustedUntil *time.Time `json:"exhausted_until,omitempty"`
+}
+
+type UpstreamKeyStats struct {
+	ID               string     `json:"id"`
+	Key              string     `json:"key"`
+	Weight           int        `json:"weight"`
+	Status           string     `json:"status"`
+	DailyCalls       int64      `json:"daily_calls"`
+	DailyBudget      int64      `json:"daily_budget,omitempty"`
+	DailyRemaining   *int64     `json:"daily_remaining,omitempty"`
+	MonthlyCalls     int64      `json:"monthly_calls"`
+	MonthlyBudget    int64      `json:"monthly_budget,omitempty"`
+	MonthlyRemaining *int64     `json:"monthly_remaining,omitempty"`
+	ExhaustedUntil   *time.Time `json:"exhausted_until,omitempty"`
+}
+
+func (u *UpstreamKeyUsage) Roll(now time.Time) {
+	now = now.UTC()
+	if day := now.Format(time.DateOnly); u.Day != day {
+		u.Day = day
+		u.DailyCalls = 0
+	}
+	if month := now.Format("2006-01"); u.Month != month {
+		u.Month = month
+		u.MonthlyCalls = 0
+	}
+	if u.ExhaustedUntil != nil && !now.Before(*u.ExhaustedUntil) {
+		u.ExhaustedUntil = nil
+	}
+}
+
+func (u UpstreamKeyUsage) IsExhausted(now time.Time) bool {
+	return u.ExhaustedUntil != nil && now.Before(*u.ExhaustedUntil)
+}
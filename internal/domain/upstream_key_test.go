@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamKeyUsageRoll(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	usage := UpstreamKeyUsage{ID: "key-1"}
+
+	usage.Roll(now)
+	assert.Equal(t, "2024-12-10", usage.Day)
+	assert.Equal(t, "2024-12", usage.Month)
+
+	usage.DailyCalls, usage.MonthlyCalls = 5, 50
+	usage.Roll(now.Add(time.Hour))
+	assert.Equal(t, int64(5), usage.DailyCalls, "Counters should be kept within the same day")
+
+	usage.Roll(now.Add(24 * time.Hour))
+	assert.Equal(t, int64(0), usage.DailyCalls, "Daily counter should reset on a new day")
+	assert.Equal(t, int64(50), usage.MonthlyCalls)
+
+	usage.Roll(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, int64(0), usage.MonthlyCalls, "Monthly counter should reset on a new month")
+	assert.Equal(t, "2025-01", usage.Month)
+}
+
+func TestUpstreamKeyUsageExhaustion(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+	usage := UpstreamKeyUsage{ID: "key-1", ExhaustedUntil: &until}
+
+	assert.True(t, usage.IsExhausted(now))
+	assert.False(t, usage.IsExhausted(until))
+	assert.False(t, UpstreamKeyUsage{}.IsExhausted(now))
+
+	usage.Roll(until)
+	assert.Nil(t, usage.ExhaustedUntil, "Exhaustion should clear once it expires")
+}
@@ -1,8 +1,29 @@
 package domain
 
+import "time"
+
+const lastUpdatedLayout = "2006-01-02 15:04"
+
+const (
+	CacheStatusMiss         = "miss"
+	CacheStatusHit          = "hit"
+	CacheStatusStale        = "stale"
+	CacheStatusStaleIfError = "stale_if_error"
+)
+
 type WeatherResponse struct {
 	Location LocationData   `json:"location"`
 	Current  CurrentWeather `json:"current"`
+	Cache    CacheInfo      `json:"-"`
+}
+
+type CacheInfo struct {
+	Status string
+	Age    time.Duration
+}
+
+func (c CacheInfo) IsStale() bool {
+	return c.Status == CacheStatusStale || c.Status == CacheStatusStaleIfError
 }
 
 type LocationData struct {
@@ -20,13 +41,36 @@ type WeatherCondition struct {
 }
 
 type CurrentWeather struct {
-	TempK       float64
-	TempC       float64          `json:"temp_c"`
-	TempF       float64          `json:"temp_f"`
-	Humidity    int              `json:"humidity"`
-	WindKph     float64          `json:"wind_kph"`
-	Condition   WeatherCondition `json:"condition"`
-	LastUpdated string           `json:"last_updated"`
+	TempK            float64
+	TempC            float64          `json:"temp_c"`
+	TempF            float64          `json:"temp_f"`
+	Humidity         int              `json:"humidity"`
+	WindKph          float64          `json:"wind_kph"`
+	Condition        WeatherCondition `json:"condition"`
+	LastUpdated      string           `json:"last_updated"`
+	LastUpdatedEpoch int64            `json:"last_updated_epoch"`
+}
+
+func (c CurrentWeather) LastUpdatedAt(timezone string) (time.Time, bool) {
+	if c.LastUpdatedEpoch > 0 {
+		return time.Unix(c.LastUpdatedEpoch, 0).UTC(), true
+	}
+
+	if c.LastUpdated == "" {
+		return time.Time{}, false
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil || timezone == "" {
+		location = time.UTC
+	}
+
+	updatedAt, err := time.ParseInLocation(lastUpdatedLayout, c.LastUpdated, location)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return updatedAt.UTC(), true
 }
 
 func (w *WeatherResponse) PopulateFromMap(data map[string]interface{}) error {
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestWeatherResponsePopulateFromMap(t *testing.T) {
@@ -69,3 +70,63 @@ func TestWeatherResponsePopulateFromMap(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrentWeatherLastUpdatedAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  CurrentWeather
+		timezone string
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "Epoch Takes Precedence",
+			current:  CurrentWeather{LastUpdated: "2024-12-10 13:45", LastUpdatedEpoch: 1733849100},
+			timezone: "America/Sao_Paulo",
+			expected: time.Date(2024, 12, 10, 16, 45, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Local Time With Timezone",
+			current:  CurrentWeather{LastUpdated: "2024-12-10 13:45"},
+			timezone: "America/Sao_Paulo",
+			expected: time.Date(2024, 12, 10, 16, 45, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Local Time Without Timezone",
+			current:  CurrentWeather{LastUpdated: "2024-12-10 13:45"},
+			expected: time.Date(2024, 12, 10, 13, 45, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:     "Unknown Timezone",
+			current:  CurrentWeather{LastUpdated: "2024-12-10 13:45"},
+			timezone: "Mars/Olympus_Mons",
+			expected: time.Date(2024, 12, 10, 13, 45, 0, 0, time.UTC),
+			ok:       true,
+		},
+		{
+			name:    "Invalid Format",
+			current: CurrentWeather{LastUpdated: "yesterday"},
+		},
+		{
+			name:    "Missing",
+			current: CurrentWeather{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := tt.current.LastUpdatedAt(tt.timezone)
+
+			if ok != tt.ok {
+				t.Errorf("Expected ok %v, got %v", tt.ok, ok)
+			}
+
+			if !result.Equal(tt.expected) {
+				t.Errorf("Expected time %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package admission
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service"
+)
+
+type Priority int
+
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityCritical
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+var ErrInvalidConfig = errors.New("invalid admission config")
+
+type Config struct {
+	MaxInFlight          int
+	TargetLatency        time.Duration
+	CPUThreshold         float64
+	MemoryThreshold      float64
+	LowPriorityThreshold float64
+	RetryAfter           time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxInFlight:          200,
+		TargetLatency:        2 * time.Second,
+		CPUThreshold:         90,
+		MemoryThreshold:      90,
+		LowPriorityThreshold: 0.8,
+		RetryAfter:           5 * time.Second,
+	}
+}
+
+func (c Config) Validate() error {
+	if c.MaxInFlight < 0 || c.TargetLatency < 0 || c.CPUThreshold < 0 || c.MemoryThreshold < 0 || c.RetryAfter < 0 {
+		return ErrInvalidConfig
+	}
+	if c.LowPriorityThreshold <= 0 || c.LowPriorityThreshold > 1 {
+		return ErrInvalidConfig
+	}
+	return nil
+}
+
+type Controller struct {
+	Config        Config
+	cpu           service.CPUService
+	memory        service.MemoryService
+	mu            sync.Mutex
+	inFlight      int
+	latencySum    time.Duration
+	latencyCount  int
+	latency       time.Duration
+	cpuPercent    float64
+	memoryPercent float64
+}
+
+func NewController(config Config, cpu service.CPUService, memory service.MemoryService) *Controller {
+	return &Controller{
+		Config: config,
+		cpu:    cpu,
+		memory: memory,
+	}
+}
+
+func (c *Controller) Sample() {
+	cpuPercent, cpuOK := c.sampleCPU()
+	memoryPercent, memoryOK := c.sampleMemory()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cpuOK {
+		c.cpuPercent = cpuPercent
+	}
+	if memoryOK {
+		c.memoryPercent = memoryPercent
+	}
+
+	c.latency = 0
+	if c.latencyCount > 0 {
+		c.latency = c.latencySum / time.Duration(c.latencyCount)
+	}
+	c.latencySum, c.latencyCount = 0, 0
+}
+
+func (c *Controller) Admit(priority Priority) (func(), bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if priority != PriorityCritical {
+		threshold := 1.0
+		if priority == PriorityLow {
+			threshold = c.Config.LowPriorityThreshold
+		}
+		if c.pressure() >= threshold {
+			return nil, false
+		}
+	}
+
+	c.inFlight++
+	start := time.Now()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			c.inFlight--
+			if priority != PriorityCritical {
+				c.latencySum += time.Since(start)
+				c.latencyCount++
+			}
+		})
+	}, true
+}
+
+func (c *Controller) Pressure() float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.pressure()
+}
+
+func (c *Controller) pressure() float64 {
+	pressure := 0.0
+	if c.Config.MaxInFlight > 0 {
+		pressure = max(pressure, float64(c.inFlight)/float64(c.Config.MaxInFlight))
+	}
+	if c.Config.TargetLatency > 0 {
+		pressure = max(pressure, float64(c.latency)/float64(c.Config.TargetLatency))
+	}
+	if c.Config.CPUThreshold > 0 {
+		pressure = max(pressure, c.cpuPercent/c.Config.CPUThreshold)
+	}
+	if c.Config.MemoryThreshold > 0 {
+		pressure = max(pressure, c.memoryPercent/c.Config.MemoryThreshold)
+	}
+	return pressure
+}
+
+func (c *Controller) sampleCPU() (float64, bool) {
+	if c.cpu == nil || c.Config.CPUThreshold == 0 {
+		return 0, false
+	}
+
+	_, percentUsed, err := c.cpu.GetCPUStats()
+	if err != nil || len(percentUsed) == 0 {
+		return 0, false
+	}
+
+	total := 0.0
+	for _, percent := range percentUsed {
+		total += percent
+	}
+	return total / float64(len(percentUsed)), true
+}
+
+func (c *Controller) sampleMemory() (float64, bool) {
+	if c.memory == nil || c.Config.MemoryThreshold == 0 {
+		return 0, false
+	}
+
+	_, _, _, _, percentUsed, err := c.memory.GetMemoryStats()
+	if err != nil {
+		return 0, false
+	}
+	return percentUsed, true
+}
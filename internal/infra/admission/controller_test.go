@@ -0,0 +1,126 @@
+package admission
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestController(config Config, cpu *float64, memory *float64) *Controller {
+	return NewController(config,
+		&mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+			return 2, []float64{*cpu, *cpu}, nil
+		}},
+		&mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+			return 0, 0, 0, 0, *memory, nil
+		}},
+	)
+}
+
+func TestConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultConfig().Validate())
+
+	config := DefaultConfig()
+	config.MaxInFlight = -1
+	assert.ErrorIs(t, config.Validate(), ErrInvalidConfig)
+
+	config = DefaultConfig()
+	config.LowPriorityThreshold = 1.5
+	assert.ErrorIs(t, config.Validate(), ErrInvalidConfig)
+}
+
+func TestControllerShedsByQueueDepth(t *testing.T) {
+	cpu, memory := 0.0, 0.0
+	config := DefaultConfig()
+	config.MaxInFlight = 5
+	controller := newTestController(config, &cpu, &memory)
+
+	var releases []func()
+	for i := 0; i < 4; i++ {
+		release, ok := controller.Admit(PriorityNormal)
+		require.True(t, ok)
+		releases = append(releases, release)
+	}
+
+	_, ok := controller.Admit(PriorityLow)
+	assert.False(t, ok, "Low priority requests should be shed first")
+	release, ok := controller.Admit(PriorityNormal)
+	require.True(t, ok)
+	releases = append(releases, release)
+
+	_, ok = controller.Admit(PriorityNormal)
+	assert.False(t, ok, "Normal requests should be shed at full capacity")
+	release, ok = controller.Admit(PriorityCritical)
+	assert.True(t, ok, "Critical requests are never shed")
+	release()
+
+	for _, release := range releases {
+		release()
+	}
+	releases[0]()
+	assert.Zero(t, controller.Pressure(), "Releasing twice should not corrupt the in-flight count")
+}
+
+func TestControllerShedsBySystemReadings(t *testing.T) {
+	cpu, memory := 50.0, 40.0
+	controller := newTestController(DefaultConfig(), &cpu, &memory)
+	controller.Sample()
+
+	assert.InDelta(t, 50.0/90, controller.Pressure(), 0.001)
+	_, ok := controller.Admit(PriorityLow)
+	assert.True(t, ok)
+
+	cpu = 81
+	controller.Sample()
+	_, ok = controller.Admit(PriorityLow)
+	assert.False(t, ok)
+	_, ok = controller.Admit(PriorityNormal)
+	assert.True(t, ok)
+
+	memory = 95
+	controller.Sample()
+	_, ok = controller.Admit(PriorityNormal)
+	assert.False(t, ok)
+}
+
+func TestControllerShedsByLatency(t *testing.T) {
+	cpu, memory := 0.0, 0.0
+	config := DefaultConfig()
+	config.TargetLatency = time.Millisecond
+	controller := newTestController(config, &cpu, &memory)
+
+	release, ok := controller.Admit(PriorityNormal)
+	require.True(t, ok)
+	time.Sleep(2 * time.Millisecond)
+	release()
+	controller.Sample()
+
+	_, ok = controller.Admit(PriorityNormal)
+	assert.False(t, ok, "Slow requests should raise pressure")
+
+	controller.Sample()
+	_, ok = controller.Admit(PriorityNormal)
+	assert.True(t, ok, "Latency pressure should clear once no slow requests complete")
+}
+
+func TestControllerIgnoresFailedReadings(t *testing.T) {
+	controller := NewController(DefaultConfig(),
+		&mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+			return 0, nil, errors.New("mock error")
+		}},
+		&mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+			return 0, 0, 0, 0, 0, errors.New("mock error")
+		}},
+	)
+	controller.Sample()
+
+	assert.Zero(t, controller.Pressure())
+	assert.Equal(t, "low", PriorityLow.String())
+	assert.Equal(t, "normal", PriorityNormal.String())
+	assert.Equal(t, "critical", PriorityCritical.String())
+}
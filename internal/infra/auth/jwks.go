@@ -0,0 +1,275 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	DefaultJWKSRefreshInterval = 15 * time.Minute
+	DefaultJWKSTimeout         = 5 * time.Second
+	minUnknownKidRefresh       = time.Minute
+	minFailureBackoff          = time.Second
+	maxFailureBackoff          = time.Minute
+)
+
+var ErrKeySetUnavailable = fmt.Errorf("%w: jwks unavailable", domain.ErrUpstreamUnavailable)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type JWKS struct {
+	Source          string
+	HttpClient      contracts.HttpClient
+	RefreshInterval time.Duration
+	Timeout         time.Duration
+	mu              sync.RWMutex
+	keys            map[string]crypto.PublicKey
+	fetchedAt       time.Time
+	attemptedAt     time.Time
+	retryAt         time.Time
+	failures        int
+	inflight        *jwksFetch
+	nowFunc         func() time.Time
+}
+
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+func NewJWKS(source string, client contracts.HttpClient, refreshInterval time.Duration) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	return &JWKS{
+		Source:          source,
+		HttpClient:      client,
+		RefreshInterval: refreshInterval,
+		Timeout:         DefaultJWKSTimeout,
+		nowFunc:         time.Now,
+	}
+}
+
+func (j *JWKS) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	now := j.nowFunc()
+	j.mu.RLock()
+	key, found := j.keys[kid]
+	stale := now.Sub(j.fetchedAt) >= j.RefreshInterval
+	recent := now.Sub(j.attemptedAt) < minUnknownKidRefresh
+	backoff := now.Before(j.retryAt)
+	loaded := j.keys != nil
+	j.mu.RUnlock()
+
+	if found && (!stale || backoff) {
+		return key, nil
+	}
+
+	if !found && loaded && (recent || backoff) {
+		return nil, fmt.Errorf("%w: unknown key id %q", domain.ErrInvalidToken, kid)
+	}
+
+	if backoff {
+		return nil, fmt.Errorf("%w: waiting to retry after a failed fetch", ErrKeySetUnavailable)
+	}
+
+	if err := j.Refresh(ctx); err != nil {
+		if found {
+			slog.Warn("jwks refresh failed, using cached keys", slog.String("error", err.Error()))
+			return key, nil
+		}
+		return nil, err
+	}
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	key, found = j.keys[kid]
+	if !found {
+		return nil, fmt.Errorf("%w: unknown key id %q", domain.ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+func (j *JWKS) Refresh(ctx context.Context) error {
+	j.mu.Lock()
+	if call := j.inflight; call != nil {
+		j.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrKeySetUnavailable, ctx.Err())
+		}
+	}
+	call := &jwksFetch{done: make(chan struct{})}
+	j.inflight = call
+	j.attemptedAt = j.nowFunc()
+	j.mu.Unlock()
+
+	keys, err := j.fetch(ctx)
+
+	j.mu.Lock()
+	switch {
+	case err == nil:
+		j.keys = keys
+		j.fetchedAt = j.nowFunc()
+		j.failures = 0
+		j.retryAt = time.Time{}
+	case ctx.Err() == nil:
+		j.failures++
+		j.retryAt = j.nowFunc().Add(failureBackoff(j.failures))
+	}
+	j.inflight = nil
+	call.err = err
+	close(call.done)
+	j.mu.Unlock()
+
+	return err
+}
+
+func (j *JWKS) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	data, err := j.read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeySetUnavailable, err)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeySetUnavailable, err)
+	}
+	return keys, nil
+}
+
+func failureBackoff(failures int) time.Duration {
+	backoff := minFailureBackoff << min(failures-1, 6)
+	return min(backoff, maxFailureBackoff)
+}
+
+func (j *JWKS) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.Source, "http://") && !strings.HasPrefix(j.Source, "https://") {
+		return os.ReadFile(j.Source)
+	}
+
+	if j.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.Source, nil)
+	if err != nil {
+		return nil, domain.NewFailedToCreateRequestError(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := j.HttpClient.Do(req)
+	if err != nil {
+		return nil, domain.NewFailedToMakeRequestError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, domain.NewUnexpectedStatusCodeError(resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func ParseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
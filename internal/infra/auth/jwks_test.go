@@ -0,0 +1,267 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   encodeBigInt(key.N),
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   encodeBigInt(key.X),
+		"y":   encodeBigInt(key.Y),
+	}
+}
+
+func jwksDocument(t *testing.T, keys ...map[string]string) []byte {
+	data, err := json.Marshal(map[string]interface{}{"keys": keys})
+	require.NoError(t, err)
+	return data
+}
+
+func generateKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return rsaKey, ecKey
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, ecKey := generateKeys(t)
+
+	keys, err := ParseJWKS(jwksDocument(t, rsaJWK("rsa-1", rsaKey), ecJWK("ec-1", ecKey),
+		map[string]string{"kty": "RSA", "kid": "enc", "use": "enc"}))
+	require.NoError(t, err)
+
+	assert.Len(t, keys, 2, "Encryption keys should be ignored")
+	assert.True(t, rsaKey.PublicKey.Equal(keys["rsa-1"]))
+	assert.True(t, ecKey.PublicKey.Equal(keys["ec-1"]))
+
+	_, err = ParseJWKS([]byte("invalid_json"))
+	assert.Error(t, err)
+
+	_, err = ParseJWKS(jwksDocument(t, map[string]string{"kty": "oct", "kid": "hmac"}))
+	assert.ErrorContains(t, err, "unsupported key type")
+
+	_, err = ParseJWKS(jwksDocument(t, map[string]string{"kty": "EC", "kid": "ec", "crv": "P-521"}))
+	assert.ErrorContains(t, err, "unsupported curve")
+
+	_, err = ParseJWKS(jwksDocument(t, map[string]string{"kty": "RSA", "kid": "rsa", "n": "", "e": "AQAB"}))
+	assert.Error(t, err)
+}
+
+func TestJWKSFromFile(t *testing.T) {
+	rsaKey, _ := generateKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksDocument(t, rsaJWK("rsa-1", rsaKey)), 0o644))
+
+	jwks := NewJWKS(path, nil, 0)
+
+	key, err := jwks.Key(context.Background(), "rsa-1")
+	require.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(key))
+	assert.Equal(t, DefaultJWKSRefreshInterval, jwks.RefreshInterval)
+
+	_, err = jwks.Key(context.Background(), "unknown")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken)
+
+	_, err = NewJWKS(filepath.Join(t.TempDir(), "missing.json"), nil, 0).Key(context.Background(), "rsa-1")
+	assert.ErrorIs(t, err, ErrKeySetUnavailable)
+}
+
+func TestJWKSFromURLRefresh(t *testing.T) {
+	firstKey, _ := generateKeys(t)
+	secondKey, _ := generateKeys(t)
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if rotated.Load() {
+			_, _ = w.Write(jwksDocument(t, rsaJWK("rsa-2", secondKey)))
+			return
+		}
+		_, _ = w.Write(jwksDocument(t, rsaJWK("rsa-1", firstKey)))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	jwks := NewJWKS(server.URL, server.Client(), time.Hour)
+	jwks.nowFunc = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, err := jwks.Key(ctx, "rsa-1")
+	require.NoError(t, err)
+	_, err = jwks.Key(ctx, "rsa-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load(), "Fresh keys should be served from memory")
+
+	rotated.Store(true)
+	_, err = jwks.Key(ctx, "rsa-2")
+	assert.ErrorIs(t, err, domain.ErrInvalidToken, "Unknown kids should not refetch right after a refresh")
+
+	now = now.Add(2 * time.Minute)
+	key, err := jwks.Key(ctx, "rsa-2")
+	require.NoError(t, err, "Unknown kid should trigger a refresh")
+	assert.True(t, secondKey.PublicKey.Equal(key))
+
+	failing.Store(true)
+	now = now.Add(2 * time.Hour)
+	key, err = jwks.Key(ctx, "rsa-2")
+	require.NoError(t, err, "Stale keys should be kept when refresh fails")
+	assert.True(t, secondKey.PublicKey.Equal(key))
+}
+
+func TestJWKSFetchTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	jwks := NewJWKS(server.URL, server.Client(), time.Hour)
+	jwks.Timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := jwks.Key(context.Background(), "rsa-1")
+	assert.ErrorIs(t, err, ErrKeySetUnavailable)
+	assert.Less(t, time.Since(start), time.Second, "A hung JWKS endpoint should not stall verification")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	jwks = NewJWKS(server.URL, server.Client(), time.Hour)
+	jwks.Timeout = time.Hour
+	_, err = jwks.Key(ctx, "rsa-1")
+	assert.ErrorIs(t, err, ErrKeySetUnavailable, "The request context should bound the fetch")
+}
+
+func TestJWKSCollapsesConcurrentRefreshes(t *testing.T) {
+	rsaKey, _ := generateKeys(t)
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		_, _ = w.Write(jwksDocument(t, rsaJWK("rsa-1", rsaKey)))
+	}))
+	defer server.Close()
+
+	jwks := NewJWKS(server.URL, server.Client(), time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := jwks.Key(context.Background(), "rsa-1")
+			errs <- err
+		}()
+	}
+	require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), fetches.Load(), "Concurrent lookups should share one fetch")
+}
+
+func TestJWKSBacksOffAfterFailures(t *testing.T) {
+	rsaKey, _ := generateKeys(t)
+	var fetches atomic.Int32
+	var failing atomic.Bool
+	failing.Store(true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(jwksDocument(t, rsaJWK("rsa-1", rsaKey)))
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	jwks := NewJWKS(server.URL, server.Client(), time.Hour)
+	jwks.nowFunc = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		_, err := jwks.Key(ctx, "rsa-1")
+		assert.ErrorIs(t, err, ErrKeySetUnavailable)
+		assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	}
+	assert.Equal(t, int32(1), fetches.Load(), "Failed fetches should not be retried on every request")
+
+	now = now.Add(minFailureBackoff)
+	_, err := jwks.Key(ctx, "rsa-1")
+	assert.ErrorIs(t, err, ErrKeySetUnavailable)
+	assert.Equal(t, int32(2), fetches.Load())
+
+	now = now.Add(minFailureBackoff)
+	_, err = jwks.Key(ctx, "rsa-1")
+	assert.ErrorIs(t, err, ErrKeySetUnavailable)
+	assert.Equal(t, int32(2), fetches.Load(), "Backoff should grow with consecutive failures")
+
+	failing.Store(false)
+	now = now.Add(2 * minFailureBackoff)
+	_, err = jwks.Key(ctx, "rsa-1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), fetches.Load())
+
+	for i := 0; i < 5; i++ {
+		_, err = jwks.Key(ctx, fmt.Sprintf("unknown-%d", i))
+		assert.ErrorIs(t, err, domain.ErrInvalidToken)
+	}
+	assert.Equal(t, int32(3), fetches.Load(), "Unknown kids should not force a refetch on every request")
+}
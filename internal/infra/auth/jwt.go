@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type KeyProvider interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	Scope    string   `json:"scope"`
+	Scp      []string `json:"scp"`
+	Tenant   string   `json:"tenant"`
+	Azp      string   `json:"azp"`
+	ClientID string   `json:"client_id"`
+}
+
+type JWTValidator struct {
+	Keys     KeyProvider
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	nowFunc  func() time.Time
+}
+
+func NewJWTValidator(keys KeyProvider, issuer, audience string, leeway time.Duration) *JWTValidator {
+	return &JWTValidator{
+		Keys:     keys,
+		Issuer:   issuer,
+		Audience: audience,
+		Leeway:   leeway,
+		nowFunc:  time.Now,
+	}
+}
+
+func (v *JWTValidator) Validate(ctx context.Context, raw string) (domain.Principal, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.Leeway),
+		jwt.WithTimeFunc(v.nowFunc),
+		jwt.WithIssuer(v.Issuer),
+		jwt.WithAudience(v.Audience),
+	}
+
+	var tokenClaims claims
+	_, err := jwt.ParseWithClaims(raw, &tokenClaims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.Keys.Key(ctx, kid)
+	}, options...)
+	if err != nil {
+		if errors.Is(err, ErrKeySetUnavailable) {
+			return domain.Principal{}, err
+		}
+		return domain.Principal{}, fmt.Errorf("%w: %v", domain.ErrInvalidToken, err)
+	}
+
+	return tokenClaims.principal(), nil
+}
+
+func (c claims) principal() domain.Principal {
+	scopes := c.Scp
+	if c.Scope != "" {
+		scopes = strings.Fields(c.Scope)
+	}
+
+	tenant := c.Tenant
+	for _, candidate := range []string{c.Azp, c.ClientID, c.Subject} {
+		if tenant == "" {
+			tenant = candidate
+		}
+	}
+
+	return domain.Principal{
+		ID:     c.Subject,
+		Tenant: tenant,
+		Scopes: scopes,
+		Method: domain.AuthMethodJWT,
+	}
+}
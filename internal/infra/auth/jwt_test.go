@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticKeys map[string]crypto.PublicKey
+
+func (s staticKeys) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	key, ok := s[kid]
+	if !ok {
+		return nil, errors.New("unknown key")
+	}
+	return key, nil
+}
+
+type unavailableKeys struct{}
+
+func (unavailableKeys) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	return nil, ErrKeySetUnavailable
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestJWTValidatorValidate(t *testing.T) {
+	rsaKey, ecKey := generateKeys(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	validator := NewJWTValidator(staticKeys{"rsa-1": &rsaKey.PublicKey, "ec-1": &ecKey.PublicKey}, "https://idp.example.com", "weatherzip", 30*time.Second)
+	validator.nowFunc = func() time.Time { return now }
+
+	baseClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   "https://idp.example.com",
+			"aud":   "weatherzip",
+			"sub":   "service-a",
+			"exp":   now.Add(time.Hour).Unix(),
+			"nbf":   now.Add(-time.Minute).Unix(),
+			"scope": "weather:read admin",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		token    func() string
+		expected domain.Principal
+		wantErr  bool
+	}{
+		{
+			name:     "Valid RS256",
+			token:    func() string { return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, baseClaims()) },
+			expected: domain.Principal{ID: "service-a", Tenant: "service-a", Scopes: []string{"weather:read", "admin"}, Method: domain.AuthMethodJWT},
+		},
+		{
+			name: "Valid ES256 With Scp And Tenant",
+			token: func() string {
+				claims := baseClaims()
+				delete(claims, "scope")
+				claims["scp"] = []string{"health:detail"}
+				claims["tenant"] = "internal"
+				return signToken(t, jwt.SigningMethodES256, "ec-1", ecKey, claims)
+			},
+			expected: domain.Principal{ID: "service-a", Tenant: "internal", Scopes: []string{"health:detail"}, Method: domain.AuthMethodJWT},
+		},
+		{
+			name: "Wrong Issuer",
+			token: func() string {
+				claims := baseClaims()
+				claims["iss"] = "https://evil.example.com"
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing Issuer",
+			token: func() string {
+				claims := baseClaims()
+				delete(claims, "iss")
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing Audience",
+			token: func() string {
+				claims := baseClaims()
+				delete(claims, "aud")
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Wrong Audience",
+			token: func() string {
+				claims := baseClaims()
+				claims["aud"] = "other"
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Expired",
+			token: func() string {
+				claims := baseClaims()
+				claims["exp"] = now.Add(-time.Minute).Unix()
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Missing Expiration",
+			token: func() string {
+				claims := baseClaims()
+				delete(claims, "exp")
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Not Yet Valid",
+			token: func() string {
+				claims := baseClaims()
+				claims["nbf"] = now.Add(time.Hour).Unix()
+				return signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, claims)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "HS256 Rejected",
+			token:   func() string { return signToken(t, jwt.SigningMethodHS256, "rsa-1", []byte("secret"), baseClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "Unknown Key",
+			token:   func() string { return signToken(t, jwt.SigningMethodRS256, "rsa-2", rsaKey, baseClaims()) },
+			wantErr: true,
+		},
+		{
+			name:    "Malformed",
+			token:   func() string { return "not-a-token" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Validate(context.Background(), tt.token())
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, principal)
+		})
+	}
+}
+
+func TestJWTValidatorKeySetUnavailable(t *testing.T) {
+	rsaKey, _ := generateKeys(t)
+	validator := NewJWTValidator(unavailableKeys{}, "", "", 0)
+
+	token := signToken(t, jwt.SigningMethodRS256, "rsa-1", rsaKey, jwt.MapClaims{"sub": "a", "exp": time.Now().Add(time.Hour).Unix()})
+	_, err := validator.Validate(context.Background(), token)
+
+	assert.ErrorIs(t, err, ErrKeySetUnavailable)
+	assert.NotErrorIs(t, err, domain.ErrInvalidToken)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const sweepInterval = time.Minute
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+type MemoryCache struct {
+	mu        sync.Mutex
+	items     map[string]entry
+	lastSweep time.Time
+	nowFunc   func() time.Time
+}
+
+var _ contracts.Cache = (*MemoryCache)(nil)
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		items:   make(map[string]entry),
+		nowFunc: time.Now,
+	}
+}
+
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !item.expiresAt.IsZero() && !c.nowFunc().Before(item.expiresAt) {
+		delete(c.items, key)
+		return nil, false, nil
+	}
+
+	return append([]byte(nil), item.value...), true, nil
+}
+
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.nowFunc()
+	c.evictExpired(now)
+
+	item := entry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		item.expiresAt = now.Add(ttl)
+	}
+	c.items[key] = item
+
+	return nil
+}
+
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.items, key)
+	return nil
+}
+
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+func (c *MemoryCache) evictExpired(now time.Time) {
+	if now.Sub(c.lastSweep) < sweepInterval {
+		return
+	}
+	c.lastSweep = now
+
+	for key, item := range c.items {
+		if !item.expiresAt.IsZero() && !now.Before(item.expiresAt) {
+			delete(c.items, key)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCacheSetGetDelete(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "key", []byte("value"), 0))
+
+	value, ok, err := cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), value)
+
+	require.NoError(t, cache.Delete(ctx, "key"))
+
+	_, ok, err = cache.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.False(t, ok, "Deleted key should not be found")
+}
+
+func TestMemoryCacheExpiration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache()
+	cache.nowFunc = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, cache.Set(ctx, "short", []byte("a"), time.Second))
+	require.NoError(t, cache.Set(ctx, "long", []byte("b"), time.Hour))
+
+	now = now.Add(2 * time.Second)
+
+	_, ok, _ := cache.Get(ctx, "short")
+	assert.False(t, ok, "Expired key should not be found")
+	_, ok, _ = cache.Get(ctx, "long")
+	assert.True(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, cache.Set(ctx, "fresh", []byte("c"), 0))
+	assert.Equal(t, 1, cache.Len(), "Expired entries should be swept on write")
+}
+
+func TestMemoryCacheReturnsCopies(t *testing.T) {
+	cache := NewMemoryCache()
+	ctx := context.Background()
+
+	value := []byte("value")
+	require.NoError(t, cache.Set(ctx, "key", value, 0))
+	value[0] = 'X'
+
+	stored, _, _ := cache.Get(ctx, "key")
+	assert.Equal(t, []byte("value"), stored)
+}
@@ -0,0 +1,296 @@
+package cgroup
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRoot = "/sys/fs/cgroup"
+	SelfCgroup  = "/proc/self/cgroup"
+
+	unlimitedV1 = 1 << 62
+)
+
+var ErrUnavailable = errors.New("cgroup stats unavailable")
+
+type Stats struct {
+	Version          int
+	CPUQuota         int64
+	CPUPeriod        uint64
+	CPUUsage         time.Duration
+	CPUPeriods       uint64
+	CPUThrottled     uint64
+	CPUThrottledTime time.Duration
+	MemoryLimit      uint64
+	MemoryUsage      uint64
+	OOMEvents        uint64
+	OOMKills         uint64
+}
+
+func (s Stats) CPULimit() float64 {
+	if s.CPUQuota <= 0 || s.CPUPeriod == 0 {
+		return 0
+	}
+	return float64(s.CPUQuota) / float64(s.CPUPeriod)
+}
+
+func (s Stats) MemoryPercentUsed() float64 {
+	if s.MemoryLimit == 0 {
+		return 0
+	}
+	return float64(s.MemoryUsage) / float64(s.MemoryLimit) * 100
+}
+
+func (s Stats) MemoryAvailable() uint64 {
+	if s.MemoryUsage >= s.MemoryLimit {
+		return 0
+	}
+	return s.MemoryLimit - s.MemoryUsage
+}
+
+type Reader struct {
+	root       string
+	selfCgroup string
+}
+
+func NewReader(root string) *Reader {
+	return &Reader{root: root, selfCgroup: SelfCgroup}
+}
+
+func (r *Reader) Read() (Stats, error) {
+	paths := readSelfCgroup(r.selfCgroup)
+	if exists(filepath.Join(r.root, "cgroup.controllers")) {
+		return r.readV2(resolve(r.root, paths[""]))
+	}
+	if exists(filepath.Join(r.root, "memory", "memory.limit_in_bytes")) {
+		return r.readV1(paths)
+	}
+	return Stats{}, ErrUnavailable
+}
+
+func (r *Reader) readV2(dir string) (Stats, error) {
+	stats := Stats{Version: 2}
+
+	cpuMax, err := readFields(filepath.Join(dir, "cpu.max"))
+	if err != nil {
+		return Stats{}, err
+	}
+	if len(cpuMax) != 2 {
+		return Stats{}, fmt.Errorf("%w: malformed cpu.max", ErrUnavailable)
+	}
+	if cpuMax[0] != "max" {
+		if stats.CPUQuota, err = strconv.ParseInt(cpuMax[0], 10, 64); err != nil {
+			return Stats{}, err
+		}
+	}
+	if stats.CPUPeriod, err = strconv.ParseUint(cpuMax[1], 10, 64); err != nil {
+		return Stats{}, err
+	}
+
+	cpuStat, err := readKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.CPUUsage = time.Duration(cpuStat["usage_usec"]) * time.Microsecond
+	stats.CPUPeriods = cpuStat["nr_periods"]
+	stats.CPUThrottled = cpuStat["nr_throttled"]
+	stats.CPUThrottledTime = time.Duration(cpuStat["throttled_usec"]) * time.Microsecond
+
+	if stats.MemoryLimit, err = readLimit(filepath.Join(dir, "memory.max")); err != nil {
+		return Stats{}, err
+	}
+	if stats.MemoryUsage, err = readUint(filepath.Join(dir, "memory.current")); err != nil {
+		return Stats{}, err
+	}
+	memoryStat, err := readKeyValues(filepath.Join(dir, "memory.stat"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+	stats.MemoryUsage = workingSet(stats.MemoryUsage, memoryStat["inactive_file"])
+
+	events, err := readKeyValues(filepath.Join(dir, "memory.events"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+	stats.OOMEvents = events["oom"]
+	stats.OOMKills = events["oom_kill"]
+
+	return stats, nil
+}
+
+func (r *Reader) readV1(paths map[string]string) (Stats, error) {
+	stats := Stats{Version: 1}
+
+	cpuDir := resolve(r.controller("cpu", "cpu,cpuacct"), paths["cpu"])
+	quota, err := readInt(filepath.Join(cpuDir, "cpu.cfs_quota_us"))
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.CPUQuota = max(quota, 0)
+	if stats.CPUPeriod, err = readUint(filepath.Join(cpuDir, "cpu.cfs_period_us")); err != nil {
+		return Stats{}, err
+	}
+
+	cpuStat, err := readKeyValues(filepath.Join(cpuDir, "cpu.stat"))
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.CPUPeriods = cpuStat["nr_periods"]
+	stats.CPUThrottled = cpuStat["nr_throttled"]
+	stats.CPUThrottledTime = time.Duration(cpuStat["throttled_time"])
+
+	usage, err := readUint(filepath.Join(resolve(r.controller("cpuacct", "cpu,cpuacct"), paths["cpuacct"]), "cpuacct.usage"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+	stats.CPUUsage = time.Duration(usage)
+
+	memoryDir := resolve(filepath.Join(r.root, "memory"), paths["memory"])
+	if stats.MemoryLimit, err = readUint(filepath.Join(memoryDir, "memory.limit_in_bytes")); err != nil {
+		return Stats{}, err
+	}
+	if stats.MemoryLimit >= unlimitedV1 {
+		stats.MemoryLimit = 0
+	}
+	if stats.MemoryUsage, err = readUint(filepath.Join(memoryDir, "memory.usage_in_bytes")); err != nil {
+		return Stats{}, err
+	}
+	memoryStat, err := readKeyValues(filepath.Join(memoryDir, "memory.stat"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+	stats.MemoryUsage = workingSet(stats.MemoryUsage, memoryStat["total_inactive_file"])
+
+	oomControl, err := readKeyValues(filepath.Join(memoryDir, "memory.oom_control"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+	stats.OOMKills = oomControl["oom_kill"]
+
+	if stats.OOMEvents, err = readUint(filepath.Join(memoryDir, "memory.failcnt")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Stats{}, err
+	}
+
+	return stats, nil
+}
+
+func (r *Reader) controller(names ...string) string {
+	for _, name := range names {
+		dir := filepath.Join(r.root, name)
+		if exists(dir) {
+			return dir
+		}
+	}
+	return filepath.Join(r.root, names[0])
+}
+
+func readSelfCgroup(path string) map[string]string {
+	paths := make(map[string]string)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return paths
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[1] == "" {
+			paths[""] = fields[2]
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			paths[controller] = fields[2]
+		}
+	}
+	return paths
+}
+
+func resolve(mount, path string) string {
+	if path == "" || path == "/" {
+		return mount
+	}
+	if dir := filepath.Join(mount, path); exists(dir) {
+		return dir
+	}
+	return mount
+}
+
+func workingSet(usage, inactiveFile uint64) uint64 {
+	if inactiveFile >= usage {
+		return usage
+	}
+	return usage - inactiveFile
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func readFields(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(data)), nil
+}
+
+func readUint(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func readInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+func readLimit(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func readKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
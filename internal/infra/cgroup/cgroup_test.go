@@ -0,0 +1,151 @@
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadV2(t *testing.T) {
+	stats, err := NewReader("testdata/v2").Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{
+		Version:          2,
+		CPUQuota:         150000,
+		CPUPeriod:        100000,
+		CPUUsage:         8200 * time.Millisecond,
+		CPUPeriods:       420,
+		CPUThrottled:     21,
+		CPUThrottledTime: 1250 * time.Millisecond,
+		MemoryLimit:      512 << 20,
+		MemoryUsage:      386 << 20,
+		OOMEvents:        2,
+		OOMKills:         1,
+	}, stats, "Memory usage should exclude inactive page cache")
+	assert.Equal(t, 1.5, stats.CPULimit())
+	assert.InDelta(t, 75.39, stats.MemoryPercentUsed(), 0.01)
+	assert.Equal(t, uint64(126<<20), stats.MemoryAvailable())
+}
+
+func TestReadV2Unlimited(t *testing.T) {
+	stats, err := NewReader("testdata/v2-unlimited").Read()
+	require.NoError(t, err)
+
+	assert.Zero(t, stats.CPUQuota)
+	assert.Zero(t, stats.CPULimit())
+	assert.Zero(t, stats.MemoryLimit)
+	assert.Zero(t, stats.MemoryPercentUsed())
+	assert.Equal(t, uint64(100<<20), stats.MemoryUsage)
+	assert.Zero(t, stats.OOMEvents, "Missing optional files should not fail the read")
+}
+
+func TestReadV1(t *testing.T) {
+	stats, err := NewReader("testdata/v1").Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, Stats{
+		Version:          1,
+		CPUQuota:         50000,
+		CPUPeriod:        100000,
+		CPUUsage:         4500 * time.Millisecond,
+		CPUPeriods:       1000,
+		CPUThrottled:     150,
+		CPUThrottledTime: 3 * time.Second,
+		MemoryLimit:      256 << 20,
+		MemoryUsage:      180 << 20,
+		OOMEvents:        12,
+		OOMKills:         3,
+	}, stats, "OOM events should come from memory.failcnt")
+	assert.Equal(t, 0.5, stats.CPULimit())
+}
+
+func TestReadV1Unlimited(t *testing.T) {
+	stats, err := NewReader("testdata/v1-unlimited").Read()
+	require.NoError(t, err)
+
+	assert.Zero(t, stats.CPUQuota, "A -1 quota means no limit")
+	assert.Zero(t, stats.MemoryLimit, "The v1 sentinel limit means no limit")
+	assert.Equal(t, time.Duration(123456789), stats.CPUUsage, "Combined cpu,cpuacct controllers should be supported")
+	assert.Zero(t, stats.MemoryAvailable())
+}
+
+func TestReadResolvesOwnCgroupV2(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"cgroup.controllers": "cpu memory\n",
+		"app/cpu.max":        "200000 100000\n",
+		"app/cpu.stat":       "usage_usec 1000\n",
+		"app/memory.max":     "1048576\n",
+		"app/memory.current": "524288\n",
+		"proc/cgroup":        "0::/app\n",
+	})
+
+	reader := NewReader(root)
+	reader.selfCgroup = filepath.Join(root, "proc", "cgroup")
+	stats, err := reader.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2.0, stats.CPULimit(), "Stats should come from the process cgroup, not the root")
+	assert.Equal(t, uint64(1048576), stats.MemoryLimit)
+}
+
+func TestReadResolvesOwnCgroupV1(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"memory/memory.limit_in_bytes":             "9223372036854771712\n",
+		"memory/memory.usage_in_bytes":             "1\n",
+		"memory/docker/abc/memory.limit_in_bytes":  "268435456\n",
+		"memory/docker/abc/memory.usage_in_bytes":  "1048576\n",
+		"memory/docker/abc/memory.failcnt":         "4\n",
+		"cpu,cpuacct/cpu.cfs_quota_us":             "-1\n",
+		"cpu,cpuacct/cpu.cfs_period_us":            "100000\n",
+		"cpu,cpuacct/cpu.stat":                     "nr_periods 0\n",
+		"cpu,cpuacct/docker/abc/cpu.cfs_quota_us":  "50000\n",
+		"cpu,cpuacct/docker/abc/cpu.cfs_period_us": "100000\n",
+		"cpu,cpuacct/docker/abc/cpu.stat":          "nr_periods 10\n",
+		"cpu,cpuacct/docker/abc/cpuacct.usage":     "1000\n",
+		"proc/cgroup":                              "4:memory:/docker/abc\n3:cpu,cpuacct:/docker/abc\n1:name=systemd:/docker/abc\n",
+	})
+
+	reader := NewReader(root)
+	reader.selfCgroup = filepath.Join(root, "proc", "cgroup")
+	stats, err := reader.Read()
+	require.NoError(t, err)
+
+	assert.Equal(t, 0.5, stats.CPULimit())
+	assert.Equal(t, uint64(10), stats.CPUPeriods)
+	assert.Equal(t, time.Duration(1000), stats.CPUUsage)
+	assert.Equal(t, uint64(268435456), stats.MemoryLimit)
+	assert.Equal(t, uint64(4), stats.OOMEvents)
+}
+
+func TestReadUnavailable(t *testing.T) {
+	_, err := NewReader(t.TempDir()).Read()
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestReadMalformed(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cpu.max"), []byte("max\n"), 0o644))
+
+	_, err := NewReader(root).Read()
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cpu.max"), []byte("max 100000\n"), 0o644))
+	_, err = NewReader(root).Read()
+	assert.ErrorIs(t, err, os.ErrNotExist, "Required files should be reported when missing")
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
@@ -0,0 +1,331 @@
+package checker
+
+import (
+	"context"
+	"math"
+	"os"
+	"runtime"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/cgroup"
+	"github.com/vs0uz4/weatherzip/internal/infra/probe"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+
+	"github.com/shirou/gopsutil/process"
+)
+
+var (
+	_ contracts.Checker = (*Func)(nil)
+	_ contracts.Checker = (*CPUChecker)(nil)
+	_ contracts.Checker = (*MemoryChecker)(nil)
+	_ contracts.Checker = (*DiskChecker)(nil)
+	_ contracts.Checker = (*GoroutineChecker)(nil)
+	_ contracts.Checker = (*FileDescriptorChecker)(nil)
+	_ contracts.Checker = (*ContainerChecker)(nil)
+	_ contracts.Checker = (*ProbeChecker)(nil)
+)
+
+type Func struct {
+	name          string
+	componentType string
+	check         func(ctx context.Context) health.CheckResult
+}
+
+func NewFunc(name, componentType string, check func(ctx context.Context) health.CheckResult) *Func {
+	return &Func{name: name, componentType: componentType, check: check}
+}
+
+func (f *Func) Name() string {
+	return f.name
+}
+
+func (f *Func) ComponentType() string {
+	return f.componentType
+}
+
+func (f *Func) Check(ctx context.Context) health.CheckResult {
+	return f.check(ctx)
+}
+
+type CPUChecker struct {
+	Average   *Threshold
+	PerCore   *Threshold
+	StatsFunc func() *health.ResourceStats
+	cpu       service.CPUService
+}
+
+func NewCPUChecker(cpu service.CPUService) *CPUChecker {
+	return &CPUChecker{cpu: cpu}
+}
+
+func (c *CPUChecker) Name() string {
+	return "cpu:utilization"
+}
+
+func (c *CPUChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *CPUChecker) Check(ctx context.Context) health.CheckResult {
+	cores, percentUsed, err := c.cpu.GetCPUStats()
+	if err != nil {
+		return failed(err)
+	}
+
+	sum, busiestCore := 0.0, 0.0
+	for _, percent := range percentUsed {
+		sum += percent
+		busiestCore = max(busiestCore, percent)
+	}
+	average := 0.0
+	if len(percentUsed) > 0 {
+		average = sum / float64(len(percentUsed))
+	}
+	if c.StatsFunc != nil {
+		if stats := c.StatsFunc(); stats != nil {
+			average = stats.CPUPercent.Avg1m
+		}
+	}
+
+	var evaluation evaluation
+	evaluation.check(c.Average, "cpu average", average, "%")
+	evaluation.check(c.PerCore, "busiest cpu core", busiestCore, "%")
+
+	return evaluation.apply(health.CheckResult{
+		ObservedValue: average,
+		ObservedUnit:  "%",
+		Details:       health.CPUStats{Cores: cores, PercentUsed: percentUsed},
+	})
+}
+
+type MemoryChecker struct {
+	Percent     *Threshold
+	AvailableMB *Threshold
+	Cgroup      *cgroup.Reader
+	memory      service.MemoryService
+}
+
+func NewMemoryChecker(memory service.MemoryService) *MemoryChecker {
+	return &MemoryChecker{memory: memory}
+}
+
+func (c *MemoryChecker) Name() string {
+	return "memory:utilization"
+}
+
+func (c *MemoryChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *MemoryChecker) Check(ctx context.Context) health.CheckResult {
+	total, used, free, available, percentUsed, err := c.memory.GetMemoryStats()
+	if err != nil {
+		return failed(err)
+	}
+
+	observed, availableBytes, label := percentUsed, available, ""
+	if c.Cgroup != nil {
+		if stats, err := c.Cgroup.Read(); err == nil && stats.MemoryLimit > 0 {
+			observed, availableBytes, label = stats.MemoryPercentUsed(), stats.MemoryAvailable(), "container "
+		}
+	}
+
+	var evaluation evaluation
+	evaluation.check(c.Percent, label+"memory usage", observed, "%")
+	evaluation.check(c.AvailableMB, label+"available memory", float64(availableBytes)/(1<<20), "MB")
+
+	return evaluation.apply(health.CheckResult{
+		ObservedValue: observed,
+		ObservedUnit:  "%",
+		Details: health.MemoryStats{
+			Total:       total,
+			Used:        used,
+			Free:        free,
+			Available:   available,
+			PercentUsed: percentUsed,
+		},
+	})
+}
+
+type DiskChecker struct {
+	Percent *Threshold
+	disk    service.DiskService
+	path    string
+}
+
+func NewDiskChecker(disk service.DiskService, path string) *DiskChecker {
+	return &DiskChecker{disk: disk, path: path}
+}
+
+func (c *DiskChecker) Name() string {
+	return "disk:utilization"
+}
+
+func (c *DiskChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *DiskChecker) Check(ctx context.Context) health.CheckResult {
+	_, _, _, percentUsed, err := c.disk.GetDiskStats(c.path)
+	if err != nil {
+		return failed(err)
+	}
+	var evaluation evaluation
+	evaluation.check(c.Percent, "disk usage", percentUsed, "%")
+	return evaluation.apply(health.CheckResult{ObservedValue: percentUsed, ObservedUnit: "%"})
+}
+
+type GoroutineChecker struct {
+	Count     *Threshold
+	countFunc func() int
+}
+
+func NewGoroutineChecker() *GoroutineChecker {
+	return &GoroutineChecker{countFunc: runtime.NumGoroutine}
+}
+
+func (c *GoroutineChecker) Name() string {
+	return "goroutines:count"
+}
+
+func (c *GoroutineChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *GoroutineChecker) Check(ctx context.Context) health.CheckResult {
+	count := c.countFunc()
+	var evaluation evaluation
+	evaluation.check(c.Count, "goroutine count", float64(count), "")
+	return evaluation.apply(health.CheckResult{ObservedValue: count, ObservedUnit: "goroutines"})
+}
+
+type FileDescriptorChecker struct {
+	Count     *Threshold
+	countFunc func() (int32, error)
+}
+
+func NewFileDescriptorChecker() *FileDescriptorChecker {
+	return &FileDescriptorChecker{countFunc: openFileDescriptors}
+}
+
+func (c *FileDescriptorChecker) Name() string {
+	return "fds:count"
+}
+
+func (c *FileDescriptorChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *FileDescriptorChecker) Check(ctx context.Context) health.CheckResult {
+	count, err := c.countFunc()
+	if err != nil {
+		return failed(err)
+	}
+
+	var evaluation evaluation
+	evaluation.check(c.Count, "open file descriptors", float64(count), "")
+	return evaluation.apply(health.CheckResult{ObservedValue: count, ObservedUnit: "fds"})
+}
+
+func openFileDescriptors() (int32, error) {
+	current, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return 0, err
+	}
+	return current.NumFDs()
+}
+
+type ContainerChecker struct {
+	cgroup *cgroup.Reader
+}
+
+func NewContainerChecker(reader *cgroup.Reader) *ContainerChecker {
+	return &ContainerChecker{cgroup: reader}
+}
+
+func (c *ContainerChecker) Name() string {
+	return "container:cpuThrottling"
+}
+
+func (c *ContainerChecker) ComponentType() string {
+	return ComponentSystem
+}
+
+func (c *ContainerChecker) Check(ctx context.Context) health.CheckResult {
+	stats, err := c.cgroup.Read()
+	if err != nil {
+		return failed(err)
+	}
+
+	throttled := 0.0
+	if stats.CPUPeriods > 0 {
+		throttled = float64(stats.CPUThrottled) / float64(stats.CPUPeriods) * 100
+	}
+
+	return health.CheckResult{
+		ObservedValue: throttled,
+		ObservedUnit:  "%",
+		Details: health.ContainerStats{
+			CgroupVersion:       stats.Version,
+			CPULimit:            stats.CPULimit(),
+			CPUQuota:            stats.CPUQuota,
+			CPUPeriod:           stats.CPUPeriod,
+			CPUPeriods:          stats.CPUPeriods,
+			CPUThrottledPeriods: stats.CPUThrottled,
+			CPUThrottledTime:    stats.CPUThrottledTime.String(),
+			MemoryLimit:         stats.MemoryLimit,
+			MemoryUsage:         stats.MemoryUsage,
+			MemoryPercentUsed:   math.Round(stats.MemoryPercentUsed()*10) / 10,
+			OOMEvents:           stats.OOMEvents,
+			OOMKills:            stats.OOMKills,
+		},
+	}
+}
+
+type ProbeChecker struct {
+	FailStatus    string
+	prober        *probe.Prober
+	probeName     string
+	componentType string
+}
+
+func NewProbeChecker(prober *probe.Prober, probeName, componentType string) *ProbeChecker {
+	return &ProbeChecker{
+		FailStatus:    health.StatusFail,
+		prober:        prober,
+		probeName:     probeName,
+		componentType: componentType,
+	}
+}
+
+func (c *ProbeChecker) Name() string {
+	return c.probeName + ":responseTime"
+}
+
+func (c *ProbeChecker) ComponentType() string {
+	return c.componentType
+}
+
+func (c *ProbeChecker) Check(ctx context.Context) health.CheckResult {
+	result, ok := c.prober.Result(c.probeName)
+	if !ok || result.Status == probe.StatusUnknown {
+		return health.CheckResult{Status: health.StatusWarn, Output: "not probed yet"}
+	}
+
+	checkResult := health.CheckResult{
+		ObservedValue: float64(result.Latency.Microseconds()) / 1000,
+		ObservedUnit:  "ms",
+		Status:        health.StatusPass,
+		Output:        result.Error,
+		Time:          result.CheckedAt,
+	}
+	if result.Status != probe.StatusPass {
+		checkResult.Status = c.FailStatus
+	}
+	return checkResult
+}
+
+func failed(err error) health.CheckResult {
+	return health.CheckResult{Status: health.StatusFail, Output: err.Error()}
+}
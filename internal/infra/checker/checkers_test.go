@@ -0,0 +1,236 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/cgroup"
+	"github.com/vs0uz4/weatherzip/internal/infra/probe"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCgroupV2(t *testing.T, root string, usage time.Duration, memoryCurrent uint64) {
+	files := map[string]string{
+		"cgroup.controllers": "cpu memory\n",
+		"cpu.max":            "200000 100000\n",
+		"cpu.stat":           fmt.Sprintf("usage_usec %d\nnr_periods 200\nnr_throttled 50\nthrottled_usec 900000\n", usage.Microseconds()),
+		"memory.max":         fmt.Sprintf("%d\n", 1<<30),
+		"memory.current":     fmt.Sprintf("%d\n", memoryCurrent),
+		"memory.events":      "oom 1\noom_kill 1\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+}
+
+func TestCPUChecker(t *testing.T) {
+	cpu := &mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+		return 2, []float64{20, 40}, nil
+	}}
+	checker := NewCPUChecker(cpu)
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, "cpu:utilization", checker.Name())
+	assert.Equal(t, ComponentSystem, checker.ComponentType())
+	assert.Equal(t, 30.0, result.ObservedValue)
+	assert.Equal(t, "%", result.ObservedUnit)
+	assert.Equal(t, health.CPUStats{Cores: 2, PercentUsed: []float64{20, 40}}, result.Details)
+
+	cpu.GetCPUStatsFunc = func() (int, []float64, error) {
+		return 0, nil, errors.New("mock error")
+	}
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusFail, result.Status)
+	assert.Equal(t, "mock error", result.Output)
+}
+
+func TestCPUCheckerUsesSamplerAverage(t *testing.T) {
+	var stats *health.ResourceStats
+	checker := NewCPUChecker(&mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+		return 2, []float64{90, 10}, nil
+	}})
+	checker.StatsFunc = func() *health.ResourceStats { return stats }
+	checker.Average = NewThreshold(60, 90, 0.05)
+	checker.PerCore = NewThreshold(85, 0, 0.05)
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, 50.0, result.ObservedValue, "Current usage should be used until the sampler has data")
+	assert.Equal(t, health.StatusWarn, result.Status, "A single saturated core should warn")
+	assert.Equal(t, "busiest cpu core 90% is above the warn threshold of 85%", result.Output)
+
+	stats = &health.ResourceStats{CPUPercent: health.ResourceAggregate{Last: 100, Avg1m: 75, P95: 100}}
+	result = checker.Check(context.Background())
+	assert.Equal(t, 75.0, result.ObservedValue, "Usage should come from the sampler one minute average")
+	assert.Equal(t, health.StatusWarn, result.Status, "A short spike should not fail the average")
+
+	stats.CPUPercent.Avg1m = 96
+	result = checker.Check(context.Background())
+	assert.Equal(t, 96.0, result.ObservedValue)
+	assert.Equal(t, health.StatusFail, result.Status)
+	assert.Contains(t, result.Output, "cpu average 96% is above the fail threshold of 90%")
+}
+
+func TestMemoryChecker(t *testing.T) {
+	memory := &mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 100, 60, 40, 45, 60, nil
+	}}
+	checker := NewMemoryChecker(memory)
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, "memory:utilization", checker.Name())
+	assert.Equal(t, 60.0, result.ObservedValue)
+	assert.Equal(t, health.MemoryStats{Total: 100, Used: 60, Free: 40, Available: 45, PercentUsed: 60}, result.Details)
+
+	memory.GetMemoryStatsFunc = func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 0, 0, 0, 0, 0, errors.New("mock error")
+	}
+	assert.Equal(t, health.StatusFail, checker.Check(context.Background()).Status)
+
+	memory.GetMemoryStatsFunc = func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 1 << 30, 900 << 20, 124 << 20, 124 << 20, 87.9, nil
+	}
+	checker.Percent = NewThreshold(85, 95, 0.05)
+	checker.AvailableMB = NewLowerThreshold(256, 64, 0.05)
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusWarn, result.Status)
+	assert.Equal(t, "memory usage 87.9% is above the warn threshold of 85%; available memory 124MB is below the warn threshold of 256MB", result.Output)
+}
+
+func TestMemoryCheckerUsesContainerLimit(t *testing.T) {
+	root := t.TempDir()
+	writeCgroupV2(t, root, 0, 1000<<20)
+
+	checker := NewMemoryChecker(&mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 64 << 30, 8 << 30, 56 << 30, 56 << 30, 12.5, nil
+	}})
+	checker.Cgroup = cgroup.NewReader(root)
+	checker.Percent = NewThreshold(85, 95, 0.05)
+	checker.AvailableMB = NewLowerThreshold(256, 64, 0.05)
+
+	result := checker.Check(context.Background())
+	assert.InDelta(t, 97.7, result.ObservedValue, 0.1)
+	assert.Equal(t, health.StatusFail, result.Status)
+	assert.Equal(t, "container memory usage 97.7% is above the fail threshold of 95%; container available memory 24MB is below the fail threshold of 64MB", result.Output)
+	assert.Equal(t, uint64(64<<30), result.Details.(health.MemoryStats).Total, "Host values should still be reported")
+
+	checker.Cgroup = cgroup.NewReader(t.TempDir())
+	assert.Equal(t, 12.5, checker.Check(context.Background()).ObservedValue, "Host values should be used outside a cgroup")
+}
+
+func TestContainerChecker(t *testing.T) {
+	root := t.TempDir()
+	writeCgroupV2(t, root, 3*time.Second, 512<<20)
+	checker := NewContainerChecker(cgroup.NewReader(root))
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, "container:cpuThrottling", checker.Name())
+	assert.Equal(t, 25.0, result.ObservedValue)
+	assert.Equal(t, health.ContainerStats{
+		CgroupVersion:       2,
+		CPULimit:            2,
+		CPUQuota:            200000,
+		CPUPeriod:           100000,
+		CPUPeriods:          200,
+		CPUThrottledPeriods: 50,
+		CPUThrottledTime:    "900ms",
+		MemoryLimit:         1 << 30,
+		MemoryUsage:         512 << 20,
+		MemoryPercentUsed:   50,
+		OOMEvents:           1,
+		OOMKills:            1,
+	}, result.Details)
+
+	assert.Equal(t, health.StatusFail, NewContainerChecker(cgroup.NewReader(t.TempDir())).Check(context.Background()).Status)
+}
+
+func TestDiskChecker(t *testing.T) {
+	var path string
+	disk := &mock.MockDiskService{GetDiskStatsFunc: func(p string) (uint64, uint64, uint64, float64, error) {
+		path = p
+		return 100, 70, 30, 70, nil
+	}}
+	checker := NewDiskChecker(disk, "/data")
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, "disk:utilization", checker.Name())
+	assert.Equal(t, "/data", path)
+	assert.Equal(t, 70.0, result.ObservedValue)
+
+	checker.Percent = NewThreshold(60, 70, 0.05)
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusFail, result.Status)
+	assert.Equal(t, "disk usage 70% is above the fail threshold of 70%", result.Output)
+
+	disk.GetDiskStatsFunc = func(p string) (uint64, uint64, uint64, float64, error) {
+		return 0, 0, 0, 0, errors.New("mock error")
+	}
+	assert.Equal(t, health.StatusFail, checker.Check(context.Background()).Status)
+}
+
+func TestGoroutineChecker(t *testing.T) {
+	checker := NewGoroutineChecker()
+	checker.countFunc = func() int { return 42 }
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, "goroutines:count", checker.Name())
+	assert.Equal(t, 42, result.ObservedValue)
+	assert.Equal(t, "goroutines", result.ObservedUnit)
+
+	checker.Count = NewThreshold(40, 100, 0.05)
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusWarn, result.Status)
+	assert.Equal(t, "goroutine count 42 is above the warn threshold of 40", result.Output)
+}
+
+func TestFileDescriptorChecker(t *testing.T) {
+	checker := NewFileDescriptorChecker()
+	assert.Equal(t, "fds:count", checker.Name())
+	assert.Equal(t, ComponentSystem, checker.ComponentType())
+
+	checker.countFunc = func() (int32, error) { return 120, nil }
+	checker.Count = NewThreshold(100, 1000, 0.05)
+	result := checker.Check(context.Background())
+	assert.Equal(t, int32(120), result.ObservedValue)
+	assert.Equal(t, health.StatusWarn, result.Status)
+
+	checker.countFunc = func() (int32, error) { return 0, errors.New("mock error") }
+	assert.Equal(t, health.StatusFail, checker.Check(context.Background()).Status)
+}
+
+func TestProbeChecker(t *testing.T) {
+	prober := probe.NewProber(time.Second)
+	var probeErr error
+	prober.Register("weatherapi", func(ctx context.Context) error { return probeErr })
+
+	checker := NewProbeChecker(prober, "weatherapi", ComponentUpstream)
+	checker.FailStatus = health.StatusWarn
+	assert.Equal(t, "weatherapi:responseTime", checker.Name())
+	assert.Equal(t, ComponentUpstream, checker.ComponentType())
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, health.StatusWarn, result.Status)
+	assert.Equal(t, "not probed yet", result.Output)
+
+	prober.Refresh(context.Background())
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusPass, result.Status)
+	assert.Equal(t, "ms", result.ObservedUnit)
+	assert.NotEmpty(t, result.Time, "The probe time should be reported, not the read time")
+
+	probeErr = errors.New("connection refused")
+	prober.Refresh(context.Background())
+	result = checker.Check(context.Background())
+	assert.Equal(t, health.StatusWarn, result.Status)
+	assert.Equal(t, "connection refused", result.Output)
+
+	assert.Equal(t, health.StatusWarn, NewProbeChecker(prober, "unknown", ComponentDatastore).Check(context.Background()).Status)
+}
@@ -0,0 +1,120 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	ComponentSystem    = "system"
+	ComponentUpstream  = "component"
+	ComponentDatastore = "datastore"
+)
+
+var ErrDuplicateChecker = errors.New("checker already registered")
+
+type registration struct {
+	checker contracts.Checker
+	timeout time.Duration
+}
+
+type Registry struct {
+	timeout       time.Duration
+	mu            sync.RWMutex
+	registrations []registration
+	nowFunc       func() time.Time
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		timeout: timeout,
+		nowFunc: time.Now,
+	}
+}
+
+func (r *Registry) Register(checker contracts.Checker) error {
+	return r.RegisterWithTimeout(checker, r.timeout)
+}
+
+func (r *Registry) RegisterWithTimeout(checker contracts.Checker, timeout time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, registered := range r.registrations {
+		if registered.checker.Name() == checker.Name() {
+			return ErrDuplicateChecker
+		}
+	}
+	r.registrations = append(r.registrations, registration{checker: checker, timeout: timeout})
+	return nil
+}
+
+func (r *Registry) Run(ctx context.Context) map[string]health.CheckResult {
+	r.mu.RLock()
+	registrations := append([]registration(nil), r.registrations...)
+	r.mu.RUnlock()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]health.CheckResult, len(registrations))
+	for _, registered := range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := r.run(ctx, registered)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[registered.checker.Name()] = result
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (r *Registry) run(ctx context.Context, registered registration) health.CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, registered.timeout)
+	defer cancel()
+
+	done := make(chan health.CheckResult, 1)
+	go func() {
+		done <- registered.checker.Check(ctx)
+	}()
+
+	var result health.CheckResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result = health.CheckResult{Status: health.StatusFail, Output: "check timed out"}
+	}
+
+	if result.ComponentType == "" {
+		result.ComponentType = registered.checker.ComponentType()
+	}
+	if result.Status == "" {
+		result.Status = health.StatusPass
+	}
+	if result.Time == "" {
+		result.Time = r.nowFunc().Format(time.RFC3339)
+	}
+	return result
+}
+
+func Status(results map[string]health.CheckResult) string {
+	status := health.StatusPass
+	for _, result := range results {
+		switch result.Status {
+		case health.StatusFail:
+			return health.StatusFail
+		case health.StatusWarn:
+			status = health.StatusWarn
+		}
+	}
+	return status
+}
@@ -0,0 +1,84 @@
+package checker
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryRejectsDuplicates(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	check := func(ctx context.Context) health.CheckResult { return health.CheckResult{} }
+
+	require.NoError(t, registry.Register(NewFunc("queue:depth", ComponentUpstream, check)))
+	assert.ErrorIs(t, registry.Register(NewFunc("queue:depth", ComponentSystem, check)), ErrDuplicateChecker)
+}
+
+func TestRegistryRunsChecksConcurrently(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.nowFunc = func() time.Time { return time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC) }
+
+	var running atomic.Int32
+	release := make(chan struct{})
+	slow := func(ctx context.Context) health.CheckResult {
+		if running.Add(1) == 2 {
+			close(release)
+		}
+		select {
+		case <-release:
+			return health.CheckResult{ObservedValue: 1}
+		case <-ctx.Done():
+			return health.CheckResult{Status: health.StatusFail}
+		}
+	}
+	require.NoError(t, registry.Register(NewFunc("first:check", ComponentSystem, slow)))
+	require.NoError(t, registry.Register(NewFunc("second:check", ComponentDatastore, slow)))
+
+	results := registry.Run(context.Background())
+
+	require.Len(t, results, 2)
+	assert.Equal(t, health.CheckResult{
+		ComponentType: ComponentDatastore,
+		ObservedValue: 1,
+		Status:        health.StatusPass,
+		Time:          "2024-12-10T16:00:00Z",
+	}, results["second:check"], "Missing fields should be filled by the registry")
+	assert.Equal(t, health.StatusPass, Status(results))
+}
+
+func TestRegistryAppliesPerCheckTimeout(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	require.NoError(t, registry.RegisterWithTimeout(NewFunc("stuck:check", ComponentSystem, func(ctx context.Context) health.CheckResult {
+		<-blocked
+		return health.CheckResult{}
+	}), 10*time.Millisecond))
+	require.NoError(t, registry.Register(NewFunc("fast:check", ComponentSystem, func(ctx context.Context) health.CheckResult {
+		return health.CheckResult{Status: health.StatusWarn}
+	})))
+
+	start := time.Now()
+	results := registry.Run(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second, "Checks ignoring their context should not block the run")
+	assert.Equal(t, health.StatusFail, results["stuck:check"].Status)
+	assert.Equal(t, "check timed out", results["stuck:check"].Output)
+	assert.Equal(t, health.StatusWarn, results["fast:check"].Status)
+	assert.Equal(t, health.StatusFail, Status(results))
+}
+
+func TestStatus(t *testing.T) {
+	assert.Equal(t, health.StatusPass, Status(nil))
+	assert.Equal(t, health.StatusWarn, Status(map[string]health.CheckResult{
+		"a": {Status: health.StatusPass},
+		"b": {Status: health.StatusWarn},
+	}))
+}
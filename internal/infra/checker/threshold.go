@@ -0,0 +1,98 @@
+package checker
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+)
+
+type Threshold struct {
+	Warn       float64
+	Fail       float64
+	Below      bool
+	Hysteresis float64
+	mu         sync.Mutex
+	status     string
+}
+
+func NewThreshold(warn, fail, hysteresis float64) *Threshold {
+	return &Threshold{Warn: warn, Fail: fail, Hysteresis: hysteresis}
+}
+
+func NewLowerThreshold(warn, fail, hysteresis float64) *Threshold {
+	return &Threshold{Warn: warn, Fail: fail, Below: true, Hysteresis: hysteresis}
+}
+
+func (t *Threshold) Evaluate(value float64) string {
+	if t == nil || (t.Warn == 0 && t.Fail == 0) {
+		return health.StatusPass
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	status := health.StatusPass
+	if t.Warn != 0 && t.crossed(value, t.Warn, t.status != health.StatusPass && t.status != "") {
+		status = health.StatusWarn
+	}
+	if t.Fail != 0 && t.crossed(value, t.Fail, t.status == health.StatusFail) {
+		status = health.StatusFail
+	}
+	t.status = status
+	return status
+}
+
+func (t *Threshold) crossed(value, limit float64, active bool) bool {
+	margin := 0.0
+	if active {
+		margin = math.Abs(limit) * t.Hysteresis
+	}
+	if t.Below {
+		return value <= limit+margin
+	}
+	return value >= limit-margin
+}
+
+func (t *Threshold) describe(label string, value float64, unit, status string) string {
+	limit, direction := t.Warn, "above"
+	if status == health.StatusFail {
+		limit = t.Fail
+	}
+	if t.Below {
+		direction = "below"
+	}
+	return fmt.Sprintf("%s %s%s is %s the %s threshold of %s%s", label, formatValue(value), unit, direction, status, formatValue(limit), unit)
+}
+
+type evaluation struct {
+	status  string
+	reasons []string
+}
+
+func (e *evaluation) check(threshold *Threshold, label string, value float64, unit string) {
+	status := threshold.Evaluate(value)
+	if status == health.StatusPass {
+		return
+	}
+	if e.status != health.StatusFail {
+		e.status = status
+	}
+	e.reasons = append(e.reasons, threshold.describe(label, value, unit, status))
+}
+
+func (e *evaluation) apply(result health.CheckResult) health.CheckResult {
+	if len(e.reasons) == 0 {
+		return result
+	}
+	result.Status = e.status
+	result.Output = strings.Join(e.reasons, "; ")
+	return result
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
@@ -0,0 +1,52 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdHysteresis(t *testing.T) {
+	threshold := NewThreshold(80, 90, 0.05)
+
+	steps := []struct {
+		value  float64
+		status string
+	}{
+		{70, health.StatusPass},
+		{80, health.StatusWarn},
+		{77, health.StatusWarn},
+		{75.9, health.StatusPass},
+		{79.9, health.StatusPass},
+		{92, health.StatusFail},
+		{86, health.StatusFail},
+		{85.4, health.StatusWarn},
+		{50, health.StatusPass},
+	}
+	for _, step := range steps {
+		assert.Equal(t, step.status, threshold.Evaluate(step.value), "value %v", step.value)
+	}
+}
+
+func TestLowerThreshold(t *testing.T) {
+	threshold := NewLowerThreshold(256, 64, 0.1)
+
+	assert.Equal(t, health.StatusPass, threshold.Evaluate(512))
+	assert.Equal(t, health.StatusWarn, threshold.Evaluate(200))
+	assert.Equal(t, health.StatusFail, threshold.Evaluate(64))
+	assert.Equal(t, health.StatusFail, threshold.Evaluate(70), "Recovery should wait for the hysteresis margin")
+	assert.Equal(t, health.StatusWarn, threshold.Evaluate(71))
+	assert.Equal(t, health.StatusWarn, threshold.Evaluate(280))
+	assert.Equal(t, health.StatusPass, threshold.Evaluate(282))
+}
+
+func TestThresholdDisabled(t *testing.T) {
+	var threshold *Threshold
+	assert.Equal(t, health.StatusPass, threshold.Evaluate(100))
+	assert.Equal(t, health.StatusPass, NewThreshold(0, 0, 0.05).Evaluate(100))
+
+	warnOnly := NewThreshold(10, 0, 0)
+	assert.Equal(t, health.StatusWarn, warnOnly.Evaluate(100), "A zero fail limit disables only the fail level")
+}
@@ -0,0 +1,225 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+type BreakerState int
+
+const (
+	StateClosed BreakerState = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+var ErrInvalidBreakerConfig = errors.New("invalid circuit breaker config")
+
+type BreakerConfig struct {
+	Window         time.Duration
+	MinRequests    int
+	FailureRate    float64
+	Cooldown       time.Duration
+	HalfOpenProbes int
+}
+
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		Window:         30 * time.Second,
+		MinRequests:    10,
+		FailureRate:    0.5,
+		Cooldown:       15 * time.Second,
+		HalfOpenProbes: 1,
+	}
+}
+
+func (c BreakerConfig) Validate() error {
+	if c.Window <= 0 || c.MinRequests < 1 || c.FailureRate <= 0 || c.FailureRate > 1 || c.Cooldown <= 0 || c.HalfOpenProbes < 1 {
+		return ErrInvalidBreakerConfig
+	}
+	return nil
+}
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+var _ contracts.HttpClient = (*CircuitBreaker)(nil)
+
+type CircuitBreaker struct {
+	Name          string
+	Next          contracts.HttpClient
+	Config        BreakerConfig
+	OnStateChange func(name string, from, to BreakerState)
+	mu            sync.Mutex
+	state         BreakerState
+	outcomes      []outcome
+	openedAt      time.Time
+	probes        int
+	probeSuccess  int
+	nowFunc       func() time.Time
+}
+
+func NewCircuitBreaker(name string, next contracts.HttpClient, config BreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		Name:    name,
+		Next:    next,
+		Config:  config,
+		nowFunc: time.Now,
+	}
+}
+
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refreshLocked(b.nowFunc())
+	return b.state
+}
+
+func (b *CircuitBreaker) Do(req *http.Request) (*http.Response, error) {
+	probe, err := b.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := b.Next.Do(req)
+	if err != nil && req.Context().Err() != nil {
+		b.release(probe)
+		return res, err
+	}
+	b.record(probe, isFailure(res, err))
+	return res, err
+}
+
+func (b *CircuitBreaker) release(probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probes--
+}
+
+func (b *CircuitBreaker) allow() (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refreshLocked(b.nowFunc())
+	switch b.state {
+	case StateOpen:
+		return false, b.openError()
+	case StateHalfOpen:
+		if b.probes >= b.Config.HalfOpenProbes {
+			return false, b.openError()
+		}
+		b.probes++
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (b *CircuitBreaker) record(probe, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.nowFunc()
+	if probe {
+		b.probes--
+		if b.state != StateHalfOpen {
+			return
+		}
+		if failed {
+			b.transitionLocked(StateOpen, now)
+			return
+		}
+		b.probeSuccess++
+		if b.probeSuccess >= b.Config.HalfOpenProbes {
+			b.transitionLocked(StateClosed, now)
+		}
+		return
+	}
+
+	if b.state != StateClosed {
+		return
+	}
+
+	b.outcomes = append(b.outcomes, outcome{at: now, failed: failed})
+	b.pruneLocked(now)
+
+	if len(b.outcomes) < b.Config.MinRequests {
+		return
+	}
+	failures := 0
+	for _, o := range b.outcomes {
+		if o.failed {
+			failures++
+		}
+	}
+	if float64(failures)/float64(len(b.outcomes)) >= b.Config.FailureRate {
+		b.transitionLocked(StateOpen, now)
+	}
+}
+
+func (b *CircuitBreaker) refreshLocked(now time.Time) {
+	if b.state == StateOpen && now.Sub(b.openedAt) >= b.Config.Cooldown {
+		b.transitionLocked(StateHalfOpen, now)
+	}
+}
+
+func (b *CircuitBreaker) pruneLocked(now time.Time) {
+	cutoff := now.Add(-b.Config.Window)
+	i := 0
+	for i < len(b.outcomes) && !b.outcomes[i].at.After(cutoff) {
+		i++
+	}
+	b.outcomes = b.outcomes[i:]
+}
+
+func (b *CircuitBreaker) transitionLocked(to BreakerState, now time.Time) {
+	from := b.state
+	if from == to {
+		return
+	}
+
+	b.state = to
+	b.outcomes = nil
+	b.probeSuccess = 0
+	if to == StateOpen {
+		b.openedAt = now
+	}
+
+	if b.OnStateChange != nil {
+		b.OnStateChange(b.Name, from, to)
+	}
+}
+
+func (b *CircuitBreaker) openError() error {
+	return fmt.Errorf("%w: circuit breaker for %s is open", domain.ErrUpstreamUnavailable, b.Name)
+}
+
+func isFailure(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return res.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,200 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUpstream = "weatherapi"
+
+type transition struct {
+	from, to BreakerState
+}
+
+func newTestBreaker(status *int) (*CircuitBreaker, *time.Time, *[]transition, *int) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	calls := 0
+	var transitions []transition
+
+	breaker := NewCircuitBreaker(testUpstream, &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if *status == 0 {
+				return nil, errors.New("connection refused")
+			}
+			return response(*status), nil
+		},
+	}, BreakerConfig{
+		Window:         10 * time.Second,
+		MinRequests:    4,
+		FailureRate:    0.5,
+		Cooldown:       5 * time.Second,
+		HalfOpenProbes: 1,
+	})
+	breaker.nowFunc = func() time.Time { return now }
+	breaker.OnStateChange = func(name string, from, to BreakerState) {
+		transitions = append(transitions, transition{from, to})
+	}
+	return breaker, &now, &transitions, &calls
+}
+
+func doRequest(t *testing.T, breaker *CircuitBreaker) error {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, "https://api.weatherapi.com", nil)
+	require.NoError(t, err)
+	_, err = breaker.Do(req)
+	return err
+}
+
+func TestCircuitBreakerOpensOnFailureRate(t *testing.T) {
+	status := http.StatusOK
+	breaker, _, transitions, calls := newTestBreaker(&status)
+
+	assert.NoError(t, doRequest(t, breaker))
+	assert.NoError(t, doRequest(t, breaker))
+	status = http.StatusBadGateway
+	assert.NoError(t, doRequest(t, breaker))
+	assert.Equal(t, StateClosed, breaker.State(), "Below the minimum number of requests")
+
+	assert.NoError(t, doRequest(t, breaker))
+	assert.Equal(t, StateOpen, breaker.State())
+
+	err := doRequest(t, breaker)
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+	assert.Equal(t, 4, *calls, "Open breaker must fail fast without calling upstream")
+	assert.Equal(t, []transition{{StateClosed, StateOpen}}, *transitions)
+}
+
+func TestCircuitBreakerSlidingWindow(t *testing.T) {
+	status := 0
+	breaker, now, _, _ := newTestBreaker(&status)
+
+	assert.Error(t, doRequest(t, breaker))
+	assert.Error(t, doRequest(t, breaker))
+	*now = now.Add(11 * time.Second)
+
+	status = http.StatusOK
+	assert.NoError(t, doRequest(t, breaker))
+	assert.NoError(t, doRequest(t, breaker))
+	status = 0
+	assert.Error(t, doRequest(t, breaker))
+
+	assert.Equal(t, StateClosed, breaker.State(), "Failures outside the window must not count")
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	breaker, now, transitions, _ := newTestBreaker(&status)
+	for i := 0; i < 4; i++ {
+		_ = doRequest(t, breaker)
+	}
+	require.Equal(t, StateOpen, breaker.State())
+
+	*now = now.Add(5 * time.Second)
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	assert.NoError(t, doRequest(t, breaker), "Probe request should reach upstream")
+	assert.Equal(t, StateOpen, breaker.State(), "Failed probe reopens the breaker")
+
+	*now = now.Add(5 * time.Second)
+	status = http.StatusOK
+	assert.NoError(t, doRequest(t, breaker))
+	assert.Equal(t, StateClosed, breaker.State())
+
+	assert.Equal(t, []transition{
+		{StateClosed, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateOpen},
+		{StateOpen, StateHalfOpen},
+		{StateHalfOpen, StateClosed},
+	}, *transitions)
+}
+
+func TestCircuitBreakerLimitsHalfOpenProbes(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	breaker, now, _, _ := newTestBreaker(&status)
+	for i := 0; i < 4; i++ {
+		_ = doRequest(t, breaker)
+	}
+	*now = now.Add(5 * time.Second)
+
+	probe, err := breaker.allow()
+	require.NoError(t, err)
+	assert.True(t, probe)
+
+	_, err = breaker.allow()
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+}
+
+func TestCircuitBreakerIgnoresCallerCancellation(t *testing.T) {
+	calls := 0
+	var transitions []transition
+	breaker := NewCircuitBreaker(testUpstream, &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return nil, req.Context().Err()
+		},
+	}, BreakerConfig{Window: 10 * time.Second, MinRequests: 2, FailureRate: 0.5, Cooldown: 5 * time.Second, HalfOpenProbes: 1})
+	breaker.OnStateChange = func(name string, from, to BreakerState) {
+		transitions = append(transitions, transition{from, to})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.weatherapi.com", nil)
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err = breaker.Do(req)
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, StateClosed, breaker.State(), "Canceled callers must not open the breaker")
+	assert.Empty(t, transitions)
+}
+
+func TestCircuitBreakerReleasesCanceledProbe(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	breaker, now, _, _ := newTestBreaker(&status)
+	for i := 0; i < 4; i++ {
+		_ = doRequest(t, breaker)
+	}
+	*now = now.Add(5 * time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	breaker.Next = &mock.MockHTTPClient{DoFunc: func(req *http.Request) (*http.Response, error) {
+		cancel()
+		return nil, req.Context().Err()
+	}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.weatherapi.com", nil)
+	require.NoError(t, err)
+	_, err = breaker.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, StateHalfOpen, breaker.State(), "Canceled probe must not reopen the breaker")
+
+	probe, err := breaker.allow()
+	require.NoError(t, err, "Canceled probe should free its slot")
+	assert.True(t, probe)
+}
+
+func TestBreakerConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultBreakerConfig().Validate())
+
+	config := DefaultBreakerConfig()
+	config.FailureRate = 1.5
+	assert.ErrorIs(t, config.Validate(), ErrInvalidBreakerConfig)
+}
+
+func TestBreakerStateString(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "half_open", StateHalfOpen.String())
+	assert.Equal(t, "open", StateOpen.String())
+}
@@ -0,0 +1,100 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var ErrInvalidBulkhead = errors.New("invalid bulkhead config")
+
+var _ contracts.HttpClient = (*Bulkhead)(nil)
+
+type Bulkhead struct {
+	Name    string
+	Next    contracts.HttpClient
+	MaxWait time.Duration
+	slots   chan struct{}
+}
+
+func NewBulkhead(name string, next contracts.HttpClient, maxConcurrent int, maxWait time.Duration) (*Bulkhead, error) {
+	if maxConcurrent < 1 || maxWait < 0 {
+		return nil, ErrInvalidBulkhead
+	}
+
+	return &Bulkhead{
+		Name:    name,
+		Next:    next,
+		MaxWait: maxWait,
+		slots:   make(chan struct{}, maxConcurrent),
+	}, nil
+}
+
+func (b *Bulkhead) InFlight() int {
+	return len(b.slots)
+}
+
+func (b *Bulkhead) Do(req *http.Request) (*http.Response, error) {
+	if err := b.acquire(req); err != nil {
+		return nil, err
+	}
+
+	res, err := b.Next.Do(req)
+	if err != nil || res == nil || res.Body == nil {
+		b.release()
+		return res, err
+	}
+
+	res.Body = &releasingBody{ReadCloser: res.Body, release: b.release}
+	return res, nil
+}
+
+func (b *Bulkhead) acquire(req *http.Request) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	if b.MaxWait == 0 {
+		return b.fullError()
+	}
+
+	timer := time.NewTimer(b.MaxWait)
+	defer timer.Stop()
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return b.fullError()
+	}
+}
+
+func (b *Bulkhead) release() {
+	<-b.slots
+}
+
+func (b *Bulkhead) fullError() error {
+	return fmt.Errorf("%w: bulkhead for %s is full", domain.ErrUpstreamUnavailable, b.Name)
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func okClient() *mock.MockHTTPClient {
+	return &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+		},
+	}
+}
+
+func TestNewBulkheadValidates(t *testing.T) {
+	_, err := NewBulkhead(testUpstream, okClient(), 0, time.Second)
+	assert.ErrorIs(t, err, ErrInvalidBulkhead)
+
+	_, err = NewBulkhead(testUpstream, okClient(), 1, -time.Second)
+	assert.ErrorIs(t, err, ErrInvalidBulkhead)
+}
+
+func TestBulkheadLimitsInFlightCalls(t *testing.T) {
+	bulkhead, err := NewBulkhead(testUpstream, okClient(), 2, 0)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	first, err := bulkhead.Do(req)
+	require.NoError(t, err)
+	second, err := bulkhead.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bulkhead.InFlight(), "Slots should be held until the body is closed")
+
+	_, err = bulkhead.Do(req)
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+
+	require.NoError(t, first.Body.Close())
+	require.NoError(t, first.Body.Close())
+	assert.Equal(t, 1, bulkhead.InFlight(), "Closing twice should release a single slot")
+
+	third, err := bulkhead.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, second.Body.Close())
+	require.NoError(t, third.Body.Close())
+	assert.Zero(t, bulkhead.InFlight())
+}
+
+func TestBulkheadWaitsForSlot(t *testing.T) {
+	bulkhead, err := NewBulkhead(testUpstream, okClient(), 1, time.Second)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	held, err := bulkhead.Do(req)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = held.Body.Close()
+	}()
+
+	res, err := bulkhead.Do(req)
+	require.NoError(t, err, "Calls should wait up to MaxWait for a free slot")
+	_ = res.Body.Close()
+
+	held, err = bulkhead.Do(req)
+	require.NoError(t, err)
+	defer held.Body.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = bulkhead.Do(req.WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	bulkhead.MaxWait = 10 * time.Millisecond
+	_, err = bulkhead.Do(req)
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable)
+}
+
+func TestBulkheadReleasesOnError(t *testing.T) {
+	bulkhead, err := NewBulkhead(testUpstream, &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, context.DeadlineExceeded
+		},
+	}, 1, 0)
+	require.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	_, err = bulkhead.Do(req)
+	assert.Error(t, err)
+	assert.Zero(t, bulkhead.InFlight())
+}
@@ -0,0 +1,237 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	HedgeFired           = "fired"
+	HedgeWon             = "won"
+	HedgeBudgetExhausted = "budget_exhausted"
+
+	hedgeMinSamples = 20
+	hedgeMaxSamples = 200
+	hedgeMaxTokens  = 10
+)
+
+var ErrInvalidHedgeConfig = errors.New("invalid hedge config")
+
+type HedgeConfig struct {
+	Percentile float64
+	MinDelay   time.Duration
+	MaxDelay   time.Duration
+	Budget     float64
+}
+
+func DefaultHedgeConfig() HedgeConfig {
+	return HedgeConfig{
+		Percentile: 0.95,
+		MinDelay:   50 * time.Millisecond,
+		MaxDelay:   time.Second,
+		Budget:     0.1,
+	}
+}
+
+func (c HedgeConfig) Validate() error {
+	if c.Percentile <= 0 || c.Percentile >= 1 || c.MinDelay <= 0 || c.MaxDelay < c.MinDelay || c.Budget <= 0 || c.Budget >= 1 {
+		return ErrInvalidHedgeConfig
+	}
+	return nil
+}
+
+var _ contracts.HttpClient = (*HedgingClient)(nil)
+
+type HedgingClient struct {
+	Name    string
+	Next    contracts.HttpClient
+	Config  HedgeConfig
+	OnHedge func(name, outcome string)
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	tokens  float64
+}
+
+func NewHedgingClient(name string, next contracts.HttpClient, config HedgeConfig) *HedgingClient {
+	return &HedgingClient{
+		Name:   name,
+		Next:   next,
+		Config: config,
+	}
+}
+
+type hedgeResult struct {
+	res     *http.Response
+	err     error
+	hedge   bool
+	elapsed time.Duration
+}
+
+func (h *HedgingClient) Do(req *http.Request) (*http.Response, error) {
+	if !isHedgeable(req) {
+		return h.Next.Do(req)
+	}
+
+	h.earnToken()
+	results := make(chan hedgeResult, 2)
+	cancels := map[bool]context.CancelFunc{false: h.launch(req, false, results)}
+
+	timer := time.NewTimer(h.Delay())
+	defer timer.Stop()
+
+	select {
+	case result := <-results:
+		return h.finish(result, cancels, results)
+	case <-timer.C:
+	}
+
+	if !h.spendToken() {
+		h.notify(HedgeBudgetExhausted)
+		return h.finish(<-results, cancels, results)
+	}
+
+	h.notify(HedgeFired)
+	cancels[true] = h.launch(req, true, results)
+
+	result := <-results
+	if result.err != nil {
+		cancels[result.hedge]()
+		discard(result)
+		delete(cancels, result.hedge)
+		result = <-results
+	}
+	return h.finish(result, cancels, results)
+}
+
+func (h *HedgingClient) finish(result hedgeResult, cancels map[bool]context.CancelFunc, results <-chan hedgeResult) (*http.Response, error) {
+	cancel := cancels[result.hedge]
+	delete(cancels, result.hedge)
+	for _, loser := range cancels {
+		loser()
+	}
+	if len(cancels) > 0 {
+		go func() {
+			discard(<-results)
+		}()
+	}
+
+	if result.err != nil {
+		cancel()
+		return result.res, result.err
+	}
+
+	h.record(result.elapsed)
+	if result.hedge {
+		h.notify(HedgeWon)
+	}
+	if result.res.Body == nil {
+		cancel()
+		return result.res, nil
+	}
+	result.res.Body = &cancelingBody{ReadCloser: result.res.Body, cancel: cancel}
+	return result.res, nil
+}
+
+func (h *HedgingClient) Delay() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < hedgeMinSamples {
+		return h.Config.MaxDelay
+	}
+
+	sorted := slices.Clone(h.samples)
+	slices.Sort(sorted)
+	index := int(math.Ceil(h.Config.Percentile*float64(len(sorted)))) - 1
+	return min(max(sorted[index], h.Config.MinDelay), h.Config.MaxDelay)
+}
+
+func (h *HedgingClient) launch(req *http.Request, hedge bool, results chan<- hedgeResult) context.CancelFunc {
+	ctx, cancel := context.WithCancel(req.Context())
+	attempt := req.Clone(ctx)
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			results <- hedgeResult{err: err, hedge: hedge}
+			return cancel
+		}
+		attempt.Body = body
+	}
+
+	go func() {
+		start := time.Now()
+		res, err := h.Next.Do(attempt)
+		results <- hedgeResult{res: res, err: err, hedge: hedge, elapsed: time.Since(start)}
+	}()
+
+	return cancel
+}
+
+func (h *HedgingClient) record(elapsed time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.samples) < hedgeMaxSamples {
+		h.samples = append(h.samples, elapsed)
+		return
+	}
+	h.samples[h.next] = elapsed
+	h.next = (h.next + 1) % hedgeMaxSamples
+}
+
+func (h *HedgingClient) earnToken() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.tokens = min(h.tokens+h.Config.Budget, hedgeMaxTokens)
+}
+
+func (h *HedgingClient) spendToken() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+func (h *HedgingClient) notify(outcome string) {
+	if h.OnHedge != nil {
+		h.OnHedge(h.Name, outcome)
+	}
+}
+
+func isHedgeable(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+func discard(result hedgeResult) {
+	if result.res != nil && result.res.Body != nil {
+		_ = result.res.Body.Close()
+	}
+}
+
+type cancelingBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,242 @@
+package httpclient
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type hedgeRecorder struct {
+	mu       sync.Mutex
+	outcomes []string
+}
+
+func (r *hedgeRecorder) record(name, outcome string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outcomes = append(r.outcomes, outcome)
+}
+
+func (r *hedgeRecorder) all() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.outcomes...)
+}
+
+func bodyClient(body string) *mock.MockHTTPClient {
+	return &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+		},
+	}
+}
+
+func newTestHedgingClient(primary, hedge *mock.MockHTTPClient, recorder *hedgeRecorder) *HedgingClient {
+	config := DefaultHedgeConfig()
+	config.MinDelay = time.Millisecond
+	config.MaxDelay = 10 * time.Millisecond
+	config.Budget = 0.5
+
+	var calls atomic.Int32
+	client := NewHedgingClient(testUpstream, &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if calls.Add(1) == 1 || hedge == nil {
+				return primary.Do(req)
+			}
+			return hedge.Do(req)
+		},
+	}, config)
+	client.OnHedge = recorder.record
+	client.tokens = 1
+	return client
+}
+
+func readBody(t *testing.T, res *http.Response) string {
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestHedgeConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultHedgeConfig().Validate())
+
+	config := DefaultHedgeConfig()
+	config.Percentile = 1
+	assert.ErrorIs(t, config.Validate(), ErrInvalidHedgeConfig)
+
+	config = DefaultHedgeConfig()
+	config.MaxDelay = config.MinDelay - 1
+	assert.ErrorIs(t, config.Validate(), ErrInvalidHedgeConfig)
+
+	config = DefaultHedgeConfig()
+	config.Budget = 1
+	assert.ErrorIs(t, config.Validate(), ErrInvalidHedgeConfig, "A full budget would double upstream load")
+}
+
+func TestHedgingClientFastPrimaryIsNotHedged(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	hedge := bodyClient("hedge")
+	hedge.DoFunc = func(req *http.Request) (*http.Response, error) {
+		t.Fatal("Hedge should not be fired")
+		return nil, nil
+	}
+	client := newTestHedgingClient(bodyClient("primary"), hedge, recorder)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", readBody(t, res))
+	assert.Empty(t, recorder.all())
+}
+
+func TestHedgingClientSlowPrimaryIsCancelled(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	cancelled := make(chan struct{})
+	primary := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			close(cancelled)
+			return nil, req.Context().Err()
+		},
+	}
+	client := newTestHedgingClient(primary, bodyClient("hedge"), recorder)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, "hedge", readBody(t, res))
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("The losing attempt should be cancelled")
+	}
+	assert.Equal(t, []string{HedgeFired, HedgeWon}, recorder.all())
+	assert.Never(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return len(client.samples) != 1
+	}, 50*time.Millisecond, time.Millisecond, "The cancelled loser's latency should not be sampled")
+}
+
+func TestHedgingClientHedgeTakesBulkheadSlot(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	calls := 0
+	bulkhead, err := NewBulkhead(testUpstream, &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			time.Sleep(30 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("primary"))}, nil
+		},
+	}, 1, 0)
+	require.NoError(t, err)
+	client := newTestHedgingClient(&mock.MockHTTPClient{DoFunc: bulkhead.Do}, nil, recorder)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", readBody(t, res))
+	assert.Equal(t, 1, calls, "A hedge without a free slot should not reach the upstream")
+	assert.Equal(t, []string{HedgeFired}, recorder.all())
+	assert.Zero(t, bulkhead.InFlight())
+}
+
+func TestHedgingClientFallsBackWhenOneAttemptFails(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	primary := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			time.Sleep(20 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("primary"))}, nil
+		},
+	}
+	hedge := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("mock error")
+		},
+	}
+	client := newTestHedgingClient(primary, hedge, recorder)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", readBody(t, res), "A failed hedge should not mask a pending success")
+	assert.Equal(t, []string{HedgeFired}, recorder.all())
+}
+
+func TestHedgingClientRespectsBudget(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	primary := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			time.Sleep(20 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("primary"))}, nil
+		},
+	}
+	client := newTestHedgingClient(primary, bodyClient("hedge"), recorder)
+	client.tokens = 0
+	client.Config.Budget = 0.1
+
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "primary", readBody(t, res))
+	assert.Equal(t, []string{HedgeBudgetExhausted}, recorder.all())
+}
+
+func TestHedgingClientSkipsUnsafeMethods(t *testing.T) {
+	recorder := &hedgeRecorder{}
+	calls := 0
+	primary := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			time.Sleep(20 * time.Millisecond)
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		},
+	}
+	client := newTestHedgingClient(primary, nil, recorder)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("payload"))
+	_, err := client.Do(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, recorder.all())
+}
+
+func TestHedgingClientDelay(t *testing.T) {
+	client := NewHedgingClient(testUpstream, okClient(), DefaultHedgeConfig())
+	assert.Equal(t, time.Second, client.Delay(), "Max delay should be used until enough samples are collected")
+
+	for i := 1; i <= 100; i++ {
+		client.record(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, 95*time.Millisecond, client.Delay())
+
+	client.Config.MaxDelay = 60 * time.Millisecond
+	assert.Equal(t, 60*time.Millisecond, client.Delay())
+
+	client.Config.MinDelay = 200 * time.Millisecond
+	client.Config.MaxDelay = time.Second
+	assert.Equal(t, 200*time.Millisecond, client.Delay())
+
+	for i := 0; i < hedgeMaxSamples; i++ {
+		client.record(time.Millisecond)
+	}
+	assert.Len(t, client.samples, hedgeMaxSamples, "Samples should be kept in a bounded window")
+}
@@ -0,0 +1,284 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	RetryOnTimeout = "timeout"
+	RetryOnReset   = "reset"
+	RetryOnRefused = "refused"
+	RetryOnEOF     = "eof"
+)
+
+var (
+	DefaultRetryStatuses = []int{
+		http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+	DefaultRetryErrors = []string{RetryOnTimeout, RetryOnReset, RetryOnRefused, RetryOnEOF}
+)
+
+var ErrInvalidRetryPolicy = errors.New("invalid retry policy")
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Statuses    []int
+	Errors      []string
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+		Statuses:    DefaultRetryStatuses,
+		Errors:      DefaultRetryErrors,
+	}
+}
+
+func NewRetryPolicy(maxAttempts int, baseBackoff, maxBackoff time.Duration, statuses, errorKinds string) (RetryPolicy, error) {
+	if maxAttempts < 1 || baseBackoff < 0 || maxBackoff < 0 {
+		return RetryPolicy{}, ErrInvalidRetryPolicy
+	}
+
+	retryStatuses, err := ParseStatusCodes(statuses)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+	retryErrors, err := ParseRetryErrors(errorKinds)
+	if err != nil {
+		return RetryPolicy{}, err
+	}
+
+	return RetryPolicy{
+		MaxAttempts: maxAttempts,
+		BaseBackoff: baseBackoff,
+		MaxBackoff:  maxBackoff,
+		Statuses:    retryStatuses,
+		Errors:      retryErrors,
+	}, nil
+}
+
+func (p RetryPolicy) retryStatus(status int) bool {
+	return slices.Contains(p.Statuses, status)
+}
+
+func (p RetryPolicy) retryError(ctx context.Context, err error) bool {
+	if (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) && ctx.Err() != nil {
+		return false
+	}
+
+	for _, kind := range p.Errors {
+		switch kind {
+		case RetryOnTimeout:
+			var netErr net.Error
+			if (errors.As(err, &netErr) && netErr.Timeout()) || errors.Is(err, context.DeadlineExceeded) {
+				return true
+			}
+		case RetryOnReset:
+			if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+				return true
+			}
+		case RetryOnRefused:
+			if errors.Is(err, syscall.ECONNREFUSED) {
+				return true
+			}
+		case RetryOnEOF:
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var _ contracts.HttpClient = (*RetryClient)(nil)
+
+type RetryClient struct {
+	Next       contracts.HttpClient
+	Policy     RetryPolicy
+	sleepFunc  func(ctx context.Context, d time.Duration) error
+	jitterFunc func(max time.Duration) time.Duration
+	nowFunc    func() time.Time
+}
+
+func NewRetryClient(next contracts.HttpClient, policy RetryPolicy) *RetryClient {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+
+	return &RetryClient{
+		Next:       next,
+		Policy:     policy,
+		sleepFunc:  sleepContext,
+		jitterFunc: fullJitter,
+		nowFunc:    time.Now,
+	}
+}
+
+func (c *RetryClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	maxAttempts := c.Policy.MaxAttempts
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		maxAttempts = 1
+	}
+
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		res, err := c.Next.Do(attemptReq)
+
+		var retryAfter time.Duration
+		retry := attempt < maxAttempts
+		if err != nil {
+			retry = retry && c.Policy.retryError(ctx, err)
+		} else {
+			retry = retry && c.Policy.retryStatus(res.StatusCode)
+			retryAfter = parseRetryAfter(res.Header.Get("Retry-After"), c.nowFunc())
+		}
+		if !retry {
+			return res, err
+		}
+
+		if c.Policy.MaxBackoff > 0 && retryAfter > c.Policy.MaxBackoff {
+			return res, err
+		}
+		wait := c.backoff(attempt, retryAfter)
+		if deadline, ok := ctx.Deadline(); ok && c.nowFunc().Add(wait).After(deadline) {
+			return res, err
+		}
+
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		if err := c.sleepFunc(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		if attemptReq, err = cloneRequest(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *RetryClient) backoff(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	ceiling := c.Policy.BaseBackoff
+	for i := 1; i < retry; i++ {
+		ceiling *= 2
+		if c.Policy.MaxBackoff > 0 && ceiling >= c.Policy.MaxBackoff {
+			break
+		}
+	}
+	if c.Policy.MaxBackoff > 0 && ceiling > c.Policy.MaxBackoff {
+		ceiling = c.Policy.MaxBackoff
+	}
+	return c.jitterFunc(ceiling)
+}
+
+func cloneRequest(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, nil
+}
+
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil && at.After(now) {
+		return at.Sub(now)
+	}
+	return 0
+}
+
+func fullJitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return rand.N(max + 1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func ParseStatusCodes(value string) ([]int, error) {
+	var statuses []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		status, err := strconv.Atoi(part)
+		if err != nil || status < 100 || status > 599 {
+			return nil, fmt.Errorf("%w: status code %q", ErrInvalidRetryPolicy, part)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func ParseRetryErrors(value string) ([]string, error) {
+	var kinds []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		if !slices.Contains(DefaultRetryErrors, part) {
+			return nil, fmt.Errorf("%w: error kind %q", ErrInvalidRetryPolicy, part)
+		}
+		kinds = append(kinds, part)
+	}
+	return kinds, nil
+}
@@ -0,0 +1,346 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func response(status int, headers ...string) *http.Response {
+	res := &http.Response{StatusCode: status, Header: make(http.Header), Body: io.NopCloser(strings.NewReader("body"))}
+	for i := 0; i+1 < len(headers); i += 2 {
+		res.Header.Set(headers[i], headers[i+1])
+	}
+	return res
+}
+
+func newTestRetryClient(next *mock.MockHTTPClient, policy RetryPolicy) (*RetryClient, *[]time.Duration) {
+	var waits []time.Duration
+	client := NewRetryClient(next, policy)
+	client.sleepFunc = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	client.jitterFunc = func(max time.Duration) time.Duration { return max }
+	return client, &waits
+}
+
+func TestRetryClient(t *testing.T) {
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: 100 * time.Millisecond,
+		MaxBackoff:  150 * time.Millisecond,
+		Statuses:    DefaultRetryStatuses,
+		Errors:      DefaultRetryErrors,
+	}
+
+	tests := []struct {
+		name           string
+		results        []func() (*http.Response, error)
+		expectedCalls  int
+		expectedStatus int
+		expectedErr    error
+		expectedWaits  []time.Duration
+	}{
+		{
+			name: "Retries Transient Status Until Success",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) { return response(http.StatusBadGateway), nil },
+				func() (*http.Response, error) { return response(http.StatusServiceUnavailable), nil },
+				func() (*http.Response, error) { return response(http.StatusOK), nil },
+			},
+			expectedCalls:  3,
+			expectedStatus: http.StatusOK,
+			expectedWaits:  []time.Duration{100 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name: "Retries Connection Reset",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) { return nil, syscall.ECONNRESET },
+				func() (*http.Response, error) { return response(http.StatusOK), nil },
+			},
+			expectedCalls:  2,
+			expectedStatus: http.StatusOK,
+			expectedWaits:  []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name: "Does Not Retry Definitive Bad Request",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) { return response(http.StatusBadRequest), nil },
+			},
+			expectedCalls:  1,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Does Not Retry Unknown Errors",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) { return nil, errors.New("tls: bad certificate") },
+			},
+			expectedCalls: 1,
+			expectedErr:   errors.New("tls: bad certificate"),
+		},
+		{
+			name: "Returns Last Response When Attempts Are Exhausted",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) { return response(http.StatusBadGateway), nil },
+				func() (*http.Response, error) { return response(http.StatusBadGateway), nil },
+				func() (*http.Response, error) { return response(http.StatusBadGateway), nil },
+			},
+			expectedCalls:  3,
+			expectedStatus: http.StatusBadGateway,
+			expectedWaits:  []time.Duration{100 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name: "Gives Up When Retry-After Exceeds Max Backoff",
+			results: []func() (*http.Response, error){
+				func() (*http.Response, error) {
+					return response(http.StatusTooManyRequests, "Retry-After", "86400"), nil
+				},
+			},
+			expectedCalls:  1,
+			expectedStatus: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client, waits := newTestRetryClient(&mock.MockHTTPClient{
+				DoFunc: func(req *http.Request) (*http.Response, error) {
+					result := tt.results[calls]
+					calls++
+					return result()
+				},
+			}, policy)
+
+			req, err := http.NewRequest(http.MethodGet, "https://viacep.com.br/ws/01001000/json/", nil)
+			require.NoError(t, err)
+			res, err := client.Do(req)
+
+			assert.Equal(t, tt.expectedCalls, calls)
+			assert.Equal(t, tt.expectedWaits, *waits)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, res.StatusCode)
+		})
+	}
+}
+
+func TestRetryClientRespectsRetryAfter(t *testing.T) {
+	calls := 0
+	client, waits := newTestRetryClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return response(http.StatusTooManyRequests, "Retry-After", "2"), nil
+			}
+			return response(http.StatusOK), nil
+		},
+	}, RetryPolicy{MaxAttempts: 3, BaseBackoff: 100 * time.Millisecond, MaxBackoff: 5 * time.Second, Statuses: DefaultRetryStatuses})
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.weatherapi.com/v1/current.json", nil)
+	require.NoError(t, err)
+	res, err := client.Do(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestRetryClientRespectsDeadline(t *testing.T) {
+	calls := 0
+	client, waits := newTestRetryClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return response(http.StatusServiceUnavailable, "Retry-After", "30"), nil
+		},
+	}, DefaultRetryPolicy())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.weatherapi.com/v1/current.json", nil)
+	require.NoError(t, err)
+
+	res, err := client.Do(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *waits)
+}
+
+func TestRetryClientStopsWhenContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client, _ := newTestRetryClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			cancel()
+			return nil, syscall.ECONNREFUSED
+		},
+	}, DefaultRetryPolicy())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://viacep.com.br", nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRetryClientRetriesUpstreamTimeouts(t *testing.T) {
+	tests := []struct {
+		name   string
+		client func() *http.Client
+	}{
+		{
+			name:   "Client Timeout",
+			client: func() *http.Client { return &http.Client{Timeout: 50 * time.Millisecond} },
+		},
+		{
+			name: "Response Header Timeout",
+			client: func() *http.Client {
+				return &http.Client{Transport: &http.Transport{ResponseHeaderTimeout: 50 * time.Millisecond}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) == 1 {
+					select {
+					case <-r.Context().Done():
+					case <-time.After(time.Second):
+					}
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			client := NewRetryClient(tt.client(), RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, Errors: []string{RetryOnTimeout}})
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+			assert.Equal(t, int32(2), calls.Load(), "A timed out attempt should be retried")
+		})
+	}
+}
+
+func TestRetryClientDoesNotRetryCallerDeadline(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := NewRetryClient(server.Client(), RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, Errors: []string{RetryOnTimeout}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(1), calls.Load(), "The caller deadline is terminal")
+}
+
+func TestRetryClientReplaysBody(t *testing.T) {
+	var bodies []string
+	client, _ := newTestRetryClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			bodies = append(bodies, string(body))
+			if len(bodies) == 1 {
+				return response(http.StatusBadGateway), nil
+			}
+			return response(http.StatusOK), nil
+		},
+	}, DefaultRetryPolicy())
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_, err = client.Do(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter("5", now))
+	assert.Equal(t, 10*time.Second, parseRetryAfter(now.Add(10*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("-1", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+}
+
+func TestFullJitter(t *testing.T) {
+	assert.Zero(t, fullJitter(0))
+	for i := 0; i < 100; i++ {
+		wait := fullJitter(time.Second)
+		assert.GreaterOrEqual(t, wait, time.Duration(0))
+		assert.LessOrEqual(t, wait, time.Second)
+	}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy, err := NewRetryPolicy(4, 50*time.Millisecond, time.Second, "502,503", "timeout")
+	require.NoError(t, err)
+	assert.Equal(t, RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 50 * time.Millisecond,
+		MaxBackoff:  time.Second,
+		Statuses:    []int{502, 503},
+		Errors:      []string{RetryOnTimeout},
+	}, policy)
+
+	_, err = NewRetryPolicy(0, 0, 0, "", "")
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+	_, err = NewRetryPolicy(3, 0, 0, "abc", "")
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+	_, err = NewRetryPolicy(3, 0, 0, "", "dns")
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+}
+
+func TestParseStatusCodes(t *testing.T) {
+	statuses, err := ParseStatusCodes("502, 503,,504")
+	require.NoError(t, err)
+	assert.Equal(t, []int{502, 503, 504}, statuses)
+
+	_, err = ParseStatusCodes("5xx")
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+}
+
+func TestParseRetryErrors(t *testing.T) {
+	kinds, err := ParseRetryErrors("timeout, RESET")
+	require.NoError(t, err)
+	assert.Equal(t, []string{RetryOnTimeout, RetryOnReset}, kinds)
+
+	_, err = ParseRetryErrors("dns")
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+}
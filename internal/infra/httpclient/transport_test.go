@@ -0,0 +1,120 @@
+package httpclient
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultTransportConfig().Validate())
+
+	cfg := DefaultTransportConfig()
+	cfg.DialTimeout = -time.Second
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidTransportConfig)
+
+	cfg = DefaultTransportConfig()
+	cfg.MaxIdleConnsPerHost = -1
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidTransportConfig)
+}
+
+func TestNewTransportAppliesConfig(t *testing.T) {
+	cfg := DefaultTransportConfig()
+	cfg.MaxConnsPerHost = 20
+	transport, err := NewTransport(cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, cfg.TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	assert.Equal(t, cfg.ResponseHeaderTimeout, transport.ResponseHeaderTimeout)
+	assert.Equal(t, 100, transport.MaxIdleConns)
+	assert.Equal(t, 10, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 20, transport.MaxConnsPerHost)
+	assert.True(t, transport.ForceAttemptHTTP2)
+	assert.Nil(t, transport.TLSNextProto)
+
+	cfg.HTTP2 = false
+	transport, err = NewTransport(cfg)
+	require.NoError(t, err)
+	assert.False(t, transport.ForceAttemptHTTP2)
+	assert.NotNil(t, transport.TLSNextProto, "An empty TLSNextProto map disables HTTP/2")
+}
+
+func TestNewTransportProxy(t *testing.T) {
+	cfg := DefaultTransportConfig()
+	cfg.ProxyURL = "http://proxy.internal:3128"
+	transport, err := NewTransport(cfg)
+	require.NoError(t, err)
+
+	proxyURL, err := transport.Proxy(httptest.NewRequest(http.MethodGet, "https://viacep.com.br/ws/01001000/json/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, &url.URL{Scheme: "http", Host: "proxy.internal:3128"}, proxyURL)
+
+	for _, invalid := range []string{"ftp://proxy.internal", "proxy.internal:3128", "://"} {
+		cfg.ProxyURL = invalid
+		_, err := NewTransport(cfg)
+		assert.ErrorIs(t, err, ErrInvalidProxyURL, invalid)
+	}
+}
+
+func TestNewTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600))
+
+	cfg := DefaultTransportConfig()
+	client, err := NewUpstreamClient(cfg)
+	require.NoError(t, err)
+	_, err = client.Get(server.URL)
+	assert.Error(t, err, "Unknown authorities should be rejected")
+
+	cfg.CABundle = bundle
+	client, err = NewUpstreamClient(cfg)
+	require.NoError(t, err)
+	res, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, []byte("not a certificate"), 0o600))
+	for _, invalid := range []string{empty, filepath.Join(t.TempDir(), "missing.pem")} {
+		cfg.CABundle = invalid
+		_, err := NewTransport(cfg)
+		assert.ErrorIs(t, err, ErrInvalidCABundle)
+	}
+}
+
+func TestNewUpstreamClientTimeouts(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	cfg := DefaultTransportConfig()
+	cfg.ResponseHeaderTimeout = 50 * time.Millisecond
+	client, err := NewUpstreamClient(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.Timeout, client.Timeout)
+
+	start := time.Now()
+	_, err = client.Get(server.URL)
+	assert.Error(t, err, "A hung upstream should time out")
+	assert.Less(t, time.Since(start), time.Second)
+}
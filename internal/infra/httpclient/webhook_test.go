@@ -0,0 +1,54 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookClientRejectsNonPublicDestinations(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	client := NewWebhookClient(time.Second)
+	res, err := client.Post(server.URL, "application/json", nil)
+	if res != nil {
+		res.Body.Close()
+	}
+
+	assert.ErrorIs(t, err, ErrForbiddenDestination)
+	assert.Zero(t, calls, "Loopback destinations should never be dialed")
+}
+
+func TestPublicDestinationOnly(t *testing.T) {
+	assert.NoError(t, publicDestinationOnly("tcp4", "93.184.216.34:443", nil))
+	for _, address := range []string{"127.0.0.1:80", "[::1]:80", "169.254.169.254:80", "10.1.2.3:443", "192.168.0.10:8080", "invalid"} {
+		assert.ErrorIs(t, publicDestinationOnly("tcp", address, nil), ErrForbiddenDestination, address)
+	}
+}
+
+func TestWebhookClientDoesNotFollowRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Redirects should not be followed")
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := NewWebhookClient(time.Second)
+	client.Transport = http.DefaultTransport
+	res, err := client.Post(server.URL, "application/json", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusTemporaryRedirect, res.StatusCode)
+}
@@ -0,0 +1,26 @@
+package keypool
+
+import (
+	"net/http"
+
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.HttpClient = (*Client)(nil)
+
+type Client struct {
+	Next  contracts.HttpClient
+	Pool  *Pool
+	Param string
+}
+
+func NewClient(next contracts.HttpClient, pool *Pool, param string) *Client {
+	return &Client{Next: next, Pool: pool, Param: param}
+}
+
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if key := req.URL.Query().Get(c.Param); key != "" {
+		c.Pool.Record(key)
+	}
+	return c.Next.Do(req)
+}
@@ -0,0 +1,62 @@
+package keypool
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/httpclient"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCountsEveryAttempt(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	pool, _ := newTestPool(t, []Key{{Value: "key-a-0001", Weight: 1, DailyBudget: 3}}, &now)
+
+	calls := 0
+	client := NewClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			status := http.StatusServiceUnavailable
+			if calls == 3 {
+				status = http.StatusOK
+			}
+			return &http.Response{StatusCode: status, Body: http.NoBody, Header: http.Header{}}, nil
+		},
+	}, pool, "key")
+	retry := httpclient.NewRetryClient(client, httpclient.RetryPolicy{MaxAttempts: 3, Statuses: httpclient.DefaultRetryStatuses})
+
+	key, err := pool.Acquire()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "https://api.weatherapi.com/v1/current.json?key="+key+"&q=Sao+Paulo", nil)
+	require.NoError(t, err)
+	res, err := retry.Do(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	assert.Equal(t, int64(3), pool.Stats()[0].DailyCalls, "Each retry should count against the key budget")
+	_, err = pool.Acquire()
+	assert.Error(t, err, "Key should be out of budget after the retries")
+}
+
+func TestClientIgnoresRequestsWithoutKnownKey(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	pool, _ := newTestPool(t, []Key{{Value: "key-a-0001", Weight: 1}}, &now)
+	client := NewClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		},
+	}, pool, "key")
+
+	for _, url := range []string{"https://api.weatherapi.com/", "https://api.weatherapi.com/?key=other"} {
+		req, err := http.NewRequest(http.MethodHead, url, nil)
+		require.NoError(t, err)
+		_, err = client.Do(req)
+		require.NoError(t, err)
+	}
+
+	assert.Zero(t, pool.Stats()[0].DailyCalls)
+}
@@ -0,0 +1,282 @@
+package keypool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.KeyPool = (*Pool)(nil)
+
+type Key struct {
+	Value         string
+	Weight        int
+	DailyBudget   int64
+	MonthlyBudget int64
+}
+
+type entry struct {
+	Key
+	usage    domain.UpstreamKeyUsage
+	disabled bool
+	dirty    bool
+	current  int
+}
+
+type Pool struct {
+	Name       string
+	repository contracts.UpstreamKeyUsageRepository
+	entries    []*entry
+	mu         sync.Mutex
+	flushMu    sync.Mutex
+	nowFunc    func() time.Time
+}
+
+func New(name string, keys []Key, repository contracts.UpstreamKeyUsageRepository) (*Pool, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: at least one key is required", domain.ErrInvalidUpstreamKeySpec)
+	}
+
+	stored, err := repository.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	usages := make(map[string]domain.UpstreamKeyUsage, len(stored))
+	for _, usage := range stored {
+		usages[usage.ID] = usage
+	}
+
+	pool := &Pool{Name: name, repository: repository, nowFunc: time.Now}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.Value == "" || key.Weight < 1 || key.DailyBudget < 0 || key.MonthlyBudget < 0 {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidUpstreamKeySpec, Mask(key.Value))
+		}
+		id := Fingerprint(key.Value)
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate key %s", domain.ErrInvalidUpstreamKeySpec, Mask(key.Value))
+		}
+		seen[id] = true
+		usage, ok := usages[id]
+		if !ok {
+			usage = domain.UpstreamKeyUsage{ID: id}
+		}
+		pool.entries = append(pool.entries, &entry{Key: key, usage: usage})
+	}
+
+	return pool, nil
+}
+
+func (p *Pool) Acquire() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.nowFunc()
+	total := 0
+	var selected *entry
+	for _, e := range p.entries {
+		e.usage.Roll(now)
+		if !e.available(now) {
+			continue
+		}
+		e.current += e.Weight
+		total += e.Weight
+		if selected == nil || e.current > selected.current {
+			selected = e
+		}
+	}
+	if selected == nil {
+		return "", fmt.Errorf("%w: no %s api key available", domain.ErrUpstreamUnavailable, p.Name)
+	}
+
+	selected.current -= total
+
+	return selected.Value, nil
+}
+
+func (p *Pool) Record(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.entries {
+		if e.Value != key {
+			continue
+		}
+		e.usage.Roll(p.nowFunc())
+		e.usage.DailyCalls++
+		e.usage.MonthlyCalls++
+		e.dirty = true
+		return
+	}
+}
+
+func (p *Pool) Report(key string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, e := range p.entries {
+		if e.Value != key {
+			continue
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrUpstreamQuotaExceeded):
+			until := nextMonth(p.nowFunc())
+			e.usage.ExhaustedUntil = &until
+			e.dirty = true
+		case errors.Is(err, domain.ErrUpstreamKeyRejected):
+			e.disabled = true
+		default:
+			return
+		}
+
+		slog.Warn("upstream api key removed from rotation", slog.String("upstream", p.Name), slog.String("key", Mask(key)), slog.String("error", domain.ErrorCode(err)))
+		return
+	}
+}
+
+func (p *Pool) Stats() []domain.UpstreamKeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.nowFunc()
+	stats := make([]domain.UpstreamKeyStats, 0, len(p.entries))
+	for _, e := range p.entries {
+		e.usage.Roll(now)
+		stat := domain.UpstreamKeyStats{
+			ID:             e.usage.ID,
+			Key:            Mask(e.Value),
+			Weight:         e.Weight,
+			Status:         e.status(now),
+			DailyCalls:     e.usage.DailyCalls,
+			DailyBudget:    e.DailyBudget,
+			MonthlyCalls:   e.usage.MonthlyCalls,
+			MonthlyBudget:  e.MonthlyBudget,
+			ExhaustedUntil: e.usage.ExhaustedUntil,
+		}
+		if e.DailyBudget > 0 {
+			remaining := max(e.DailyBudget-e.usage.DailyCalls, 0)
+			stat.DailyRemaining = &remaining
+		}
+		if e.MonthlyBudget > 0 {
+			remaining := max(e.MonthlyBudget-e.usage.MonthlyCalls, 0)
+			stat.MonthlyRemaining = &remaining
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
+
+func (p *Pool) Flush(ctx context.Context) {
+	p.flushMu.Lock()
+	defer p.flushMu.Unlock()
+
+	p.mu.Lock()
+	pending := make(map[*entry]domain.UpstreamKeyUsage)
+	for _, e := range p.entries {
+		if e.dirty {
+			pending[e] = e.usage
+			e.dirty = false
+		}
+	}
+	p.mu.Unlock()
+
+	for e, usage := range pending {
+		if err := p.repository.Save(usage); err != nil {
+			slog.Error("failed to persist upstream api key usage", slog.String("upstream", p.Name), slog.String("key", Mask(e.Value)), slog.String("error", err.Error()))
+			p.mu.Lock()
+			e.dirty = true
+			p.mu.Unlock()
+		}
+	}
+}
+
+func (e *entry) available(now time.Time) bool {
+	return e.status(now) == domain.UpstreamKeyActive
+}
+
+func (e *entry) status(now time.Time) string {
+	switch {
+	case e.disabled:
+		return domain.UpstreamKeyDisabled
+	case e.usage.IsExhausted(now),
+		e.DailyBudget > 0 && e.usage.DailyCalls >= e.DailyBudget,
+		e.MonthlyBudget > 0 && e.usage.MonthlyCalls >= e.MonthlyBudget:
+		return domain.UpstreamKeyExhausted
+	default:
+		return domain.UpstreamKeyActive
+	}
+}
+
+func nextMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func Fingerprint(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+func Mask(key string) string {
+	if len(key) <= 8 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+func ParseKeys(raw string) ([]Key, error) {
+	var keys []Key
+	for _, spec := range strings.Split(raw, ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		parts := strings.Split(spec, ":")
+		invalid := fmt.Errorf("%w: %s", domain.ErrInvalidUpstreamKeySpec, Mask(parts[0]))
+		if len(parts) > 4 || parts[0] == "" {
+			return nil, invalid
+		}
+		parts = append(parts, make([]string, 4-len(parts))...)
+
+		weight, err := parseField(parts[1], 1)
+		if err != nil || weight < 1 {
+			return nil, invalid
+		}
+		daily, err := parseField(parts[2], 0)
+		if err != nil {
+			return nil, invalid
+		}
+		monthly, err := parseField(parts[3], 0)
+		if err != nil {
+			return nil, invalid
+		}
+
+		keys = append(keys, Key{Value: parts[0], Weight: int(weight), DailyBudget: daily, MonthlyBudget: monthly})
+	}
+
+	return keys, nil
+}
+
+func parseField(value string, fallback int64) (int64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, domain.ErrInvalidUpstreamKeySpec
+	}
+	return parsed, nil
+}
@@ -0,0 +1,234 @@
+package keypool
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/repository"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPool(t *testing.T, keys []Key, now *time.Time) (*Pool, *repository.UpstreamKeyUsageRepository) {
+	repo := repository.NewUpstreamKeyUsageRepository(filepath.Join(t.TempDir(), "upstream_key_usage.json"))
+	pool, err := New("weatherapi", keys, repo)
+	require.NoError(t, err)
+	pool.nowFunc = func() time.Time { return *now }
+	return pool, repo
+}
+
+func acquireN(t *testing.T, pool *Pool, n int) []string {
+	var keys []string
+	for i := 0; i < n; i++ {
+		key, err := pool.Acquire()
+		require.NoError(t, err)
+		pool.Record(key)
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestPoolWeightedOrder(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	pool, _ := newTestPool(t, []Key{
+		{Value: "key-a-0001", Weight: 3},
+		{Value: "key-b-0002", Weight: 1},
+	}, &now)
+
+	assert.Equal(t, []string{"key-a-0001", "key-a-0001", "key-b-0002", "key-a-0001"}, acquireN(t, pool, 4))
+}
+
+func TestPoolBudgets(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	pool, _ := newTestPool(t, []Key{
+		{Value: "key-a-0001", Weight: 5, DailyBudget: 2},
+		{Value: "key-b-0002", Weight: 1, MonthlyBudget: 3},
+	}, &now)
+
+	assert.Equal(t, []string{"key-a-0001", "key-a-0001", "key-b-0002", "key-b-0002", "key-b-0002"}, acquireN(t, pool, 5))
+
+	_, err := pool.Acquire()
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable, "All keys are over budget")
+
+	now = now.Add(24 * time.Hour)
+	assert.Equal(t, []string{"key-a-0001", "key-a-0001"}, acquireN(t, pool, 2), "Daily budgets should reset on a new day")
+
+	stats := pool.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, domain.UpstreamKeyStats{
+		ID:             Fingerprint("key-a-0001"),
+		Key:            "****0001",
+		Weight:         5,
+		Status:         domain.UpstreamKeyExhausted,
+		DailyCalls:     2,
+		DailyBudget:    2,
+		DailyRemaining: ptr(int64(0)),
+		MonthlyCalls:   4,
+	}, stats[0])
+	assert.Equal(t, domain.UpstreamKeyStats{
+		ID:               Fingerprint("key-b-0002"),
+		Key:              "****0002",
+		Weight:           1,
+		Status:           domain.UpstreamKeyExhausted,
+		MonthlyCalls:     3,
+		MonthlyBudget:    3,
+		MonthlyRemaining: ptr(int64(0)),
+	}, stats[1])
+}
+
+func TestPoolRotatesOnQuotaAndAuthErrors(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	pool, _ := newTestPool(t, []Key{
+		{Value: "key-a-0001", Weight: 1},
+		{Value: "key-b-0002", Weight: 1},
+		{Value: "key-c-0003", Weight: 1},
+	}, &now)
+
+	pool.Report("key-a-0001", domain.ErrUpstreamQuotaExceeded)
+	pool.Report("key-b-0002", domain.ErrUpstreamKeyRejected)
+	pool.Report("key-c-0003", errors.New("connection refused"))
+	pool.Report("unknown", domain.ErrUpstreamKeyRejected)
+
+	assert.Equal(t, []string{"key-c-0003", "key-c-0003"}, acquireN(t, pool, 2))
+
+	stats := pool.Stats()
+	assert.Equal(t, domain.UpstreamKeyExhausted, stats[0].Status)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), *stats[0].ExhaustedUntil, "Quota errors should exhaust the key until next month")
+	assert.Equal(t, domain.UpstreamKeyDisabled, stats[1].Status)
+	assert.Equal(t, domain.UpstreamKeyActive, stats[2].Status)
+
+	now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Contains(t, acquireN(t, pool, 2), "key-a-0001", "Exhausted keys should return on the next month")
+}
+
+func TestPoolPersistsUsage(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	keys := []Key{{Value: "key-a-0001", Weight: 1, DailyBudget: 3}}
+	pool, repo := newTestPool(t, keys, &now)
+
+	acquireN(t, pool, 2)
+	pool.Report("key-a-0001", domain.ErrUpstreamQuotaExceeded)
+	pool.Flush(context.Background())
+
+	restored, err := New("weatherapi", keys, repo)
+	require.NoError(t, err)
+	restored.nowFunc = func() time.Time { return now }
+
+	stats := restored.Stats()
+	assert.Equal(t, int64(2), stats[0].DailyCalls, "Counters should survive a restart")
+	assert.Equal(t, domain.UpstreamKeyExhausted, stats[0].Status, "Quota exhaustion should survive a restart")
+
+	all, err := repo.FindAll()
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, Fingerprint("key-a-0001"), all[0].ID)
+	assert.NotContains(t, all[0].ID, "key-a", "Raw keys should never be persisted")
+}
+
+func TestPoolFlushesUsageInBatches(t *testing.T) {
+	var saved []domain.UpstreamKeyUsage
+	failing := true
+	pool, err := New("weatherapi", []Key{{Value: "key-a-0001", Weight: 1}, {Value: "key-b-0002", Weight: 1}}, &mock.MockUpstreamKeyUsageRepository{
+		FindAllFunc: func() ([]domain.UpstreamKeyUsage, error) {
+			return nil, nil
+		},
+		SaveFunc: func(usage domain.UpstreamKeyUsage) error {
+			if failing {
+				return errors.New("mock save error")
+			}
+			saved = append(saved, usage)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+
+	acquireN(t, pool, 4)
+	assert.Empty(t, saved, "Acquire should not write to the repository")
+
+	pool.Flush(context.Background())
+	assert.Empty(t, saved)
+
+	failing = false
+	pool.Flush(context.Background())
+	require.Len(t, saved, 2, "Failed flushes should be retried")
+	for _, usage := range saved {
+		assert.Equal(t, int64(2), usage.DailyCalls)
+	}
+
+	pool.Flush(context.Background())
+	assert.Len(t, saved, 2, "Only changed keys should be written")
+}
+
+func TestPoolRepositoryErrors(t *testing.T) {
+	_, err := New("weatherapi", []Key{{Value: "key-a-0001", Weight: 1}}, &mock.MockUpstreamKeyUsageRepository{
+		FindAllFunc: func() ([]domain.UpstreamKeyUsage, error) {
+			return nil, errors.New("mock load error")
+		},
+	})
+	assert.Error(t, err)
+
+	pool, err := New("weatherapi", []Key{{Value: "key-a-0001", Weight: 1}}, &mock.MockUpstreamKeyUsageRepository{
+		FindAllFunc: func() ([]domain.UpstreamKeyUsage, error) {
+			return nil, nil
+		},
+		SaveFunc: func(usage domain.UpstreamKeyUsage) error {
+			return errors.New("mock save error")
+		},
+	})
+	require.NoError(t, err)
+
+	key, err := pool.Acquire()
+	assert.NoError(t, err, "Persistence failures should not block calls")
+	assert.Equal(t, "key-a-0001", key)
+}
+
+func TestNewValidatesKeys(t *testing.T) {
+	repo := &mock.MockUpstreamKeyUsageRepository{
+		FindAllFunc: func() ([]domain.UpstreamKeyUsage, error) {
+			return nil, nil
+		},
+	}
+
+	_, err := New("weatherapi", nil, repo)
+	assert.ErrorIs(t, err, domain.ErrInvalidUpstreamKeySpec)
+
+	_, err = New("weatherapi", []Key{{Value: "key-a-0001"}}, repo)
+	assert.ErrorIs(t, err, domain.ErrInvalidUpstreamKeySpec, "Weight must be positive")
+
+	_, err = New("weatherapi", []Key{{Value: "key-a-0001", Weight: 1}, {Value: "key-a-0001", Weight: 2}}, repo)
+	assert.ErrorIs(t, err, domain.ErrInvalidUpstreamKeySpec, "Duplicate keys must be rejected")
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys(" key-a-0001:3:100:2000, key-b-0002 ,key-c-0003::50,")
+	require.NoError(t, err)
+	assert.Equal(t, []Key{
+		{Value: "key-a-0001", Weight: 3, DailyBudget: 100, MonthlyBudget: 2000},
+		{Value: "key-b-0002", Weight: 1},
+		{Value: "key-c-0003", Weight: 1, DailyBudget: 50},
+	}, keys)
+
+	keys, err = ParseKeys("")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	for _, invalid := range []string{":1", "key:0", "key:x", "key:1:-5", "key:1:1:1:1"} {
+		_, err := ParseKeys(invalid)
+		assert.ErrorIs(t, err, domain.ErrInvalidUpstreamKeySpec, invalid)
+	}
+}
+
+func TestMask(t *testing.T) {
+	assert.Equal(t, "****cdef", Mask("0123456789abcdef"))
+	assert.Equal(t, "****", Mask("short"))
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type requestIDKey struct{}
+
+type annotationsKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type UpstreamCall struct {
+	Host       string  `json:"host"`
+	Method     string  `json:"method"`
+	Status     int     `json:"status,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type Annotations struct {
+	mu       sync.Mutex
+	attrs    []slog.Attr
+	upstream []UpstreamCall
+}
+
+func WithAnnotations(ctx context.Context) (context.Context, *Annotations) {
+	annotations := &Annotations{}
+	return context.WithValue(ctx, annotationsKey{}, annotations), annotations
+}
+
+func AnnotationsFromContext(ctx context.Context) (*Annotations, bool) {
+	annotations, ok := ctx.Value(annotationsKey{}).(*Annotations)
+	return annotations, ok
+}
+
+func Annotate(ctx context.Context, key string, value any) {
+	if annotations, ok := AnnotationsFromContext(ctx); ok {
+		annotations.Set(key, value)
+	}
+}
+
+func RecordUpstream(ctx context.Context, call UpstreamCall) {
+	if annotations, ok := AnnotationsFromContext(ctx); ok {
+		annotations.mu.Lock()
+		defer annotations.mu.Unlock()
+		annotations.upstream = append(annotations.upstream, call)
+	}
+}
+
+func (a *Annotations) Set(key string, value any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, attr := range a.attrs {
+		if attr.Key == key {
+			a.attrs[i].Value = slog.AnyValue(value)
+			return
+		}
+	}
+	a.attrs = append(a.attrs, slog.Any(key, value))
+}
+
+func (a *Annotations) Value(key string) (slog.Value, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, attr := range a.attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+func (a *Annotations) Attrs() []slog.Attr {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	attrs := append([]slog.Attr(nil), a.attrs...)
+	if len(a.upstream) > 0 {
+		attrs = append(attrs, slog.Any("upstream", append([]UpstreamCall(nil), a.upstream...)))
+	}
+	return attrs
+}
+
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDContext(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	assert.Equal(t, "req-1", RequestIDFromContext(ctx))
+}
+
+func TestAnnotations(t *testing.T) {
+	t.Run("Without Annotations Is A No-Op", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			Annotate(context.Background(), "cep", "01001***")
+			RecordUpstream(context.Background(), UpstreamCall{Host: "example.com"})
+		})
+	})
+
+	t.Run("Collects Attributes And Upstream Calls", func(t *testing.T) {
+		ctx, annotations := WithAnnotations(context.Background())
+
+		Annotate(ctx, "cache_status", "miss")
+		Annotate(ctx, "cache_status", "not_modified")
+		RecordUpstream(ctx, UpstreamCall{Host: "viacep.com.br", Method: "GET", Status: 200, DurationMS: 12.5})
+
+		attrs := annotations.Attrs()
+		require.Len(t, attrs, 2)
+		assert.Equal(t, "cache_status", attrs[0].Key)
+		assert.Equal(t, "not_modified", attrs[0].Value.String())
+		assert.Equal(t, "upstream", attrs[1].Key)
+		assert.Equal(t, []UpstreamCall{{Host: "viacep.com.br", Method: "GET", Status: 200, DurationMS: 12.5}}, attrs[1].Value.Any())
+	})
+}
+
+func TestMilliseconds(t *testing.T) {
+	assert.Equal(t, 1.5, Milliseconds(1500*time.Microsecond))
+}
+
+func TestMaskCep(t *testing.T) {
+	assert.Equal(t, "01001***", MaskCep("01001000"))
+	assert.Equal(t, "***", MaskCep("0100"))
+}
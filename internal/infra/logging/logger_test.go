@@ -0,0 +1,65 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "debug", "json")
+		require.NoError(t, err)
+
+		logger.Debug("hello", slog.String("key", "value"))
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "hello", entry["msg"])
+		assert.Equal(t, "value", entry["key"])
+	})
+
+	t.Run("Text Honours Level", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger, err := New(&buf, "warn", "text")
+		require.NoError(t, err)
+
+		logger.Info("hidden")
+		logger.Warn("shown")
+
+		assert.NotContains(t, buf.String(), "hidden")
+		assert.True(t, strings.Contains(buf.String(), "msg=shown"))
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "info", "xml")
+		assert.ErrorIs(t, err, ErrInvalidFormat)
+	})
+
+	t.Run("Invalid Level", func(t *testing.T) {
+		_, err := New(&bytes.Buffer{}, "verbose", "json")
+		assert.ErrorIs(t, err, ErrInvalidLevel)
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"":        slog.LevelInfo,
+		"DEBUG":   slog.LevelDebug,
+		"info":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+	}
+
+	for input, expected := range tests {
+		level, err := ParseLevel(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, level, input)
+	}
+}
@@ -0,0 +1,8 @@
+package logging
+
+func MaskCep(cep string) string {
+	if len(cep) <= 5 {
+		return "***"
+	}
+	return cep[:5] + "***"
+}
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+type Transport struct {
+	Base    http.RoundTripper
+	nowFunc func() time.Time
+}
+
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base, nowFunc: time.Now}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := RequestIDFromContext(req.Context()); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	start := t.nowFunc()
+	res, err := t.Base.RoundTrip(req)
+
+	call := UpstreamCall{
+		Host:       req.URL.Host,
+		Method:     req.Method,
+		DurationMS: Milliseconds(t.nowFunc().Sub(start)),
+	}
+	if err != nil {
+		call.Error = err.Error()
+	} else {
+		call.Status = res.StatusCode
+	}
+	RecordUpstream(req.Context(), call)
+
+	return res, err
+}
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestTransportForwardsRequestIDAndRecordsTiming(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(RequestIDHeader)
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	ctx, annotations := WithAnnotations(WithRequestID(context.Background(), "req-1"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	res, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+
+	assert.Equal(t, "req-1", received)
+	assert.Empty(t, req.Header.Get(RequestIDHeader), "Original request must not be mutated")
+
+	attrs := annotations.Attrs()
+	require.Len(t, attrs, 1)
+	calls := attrs[0].Value.Any().([]UpstreamCall)
+	require.Len(t, calls, 1)
+	assert.Equal(t, http.StatusTeapot, calls[0].Status)
+	assert.Equal(t, http.MethodGet, calls[0].Method)
+}
+
+func TestTransportRecordsErrors(t *testing.T) {
+	transport := NewTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}))
+	now := time.Now()
+	calls := 0
+	transport.nowFunc = func() time.Time {
+		calls++
+		return now.Add(time.Duration(calls) * 250 * time.Millisecond)
+	}
+
+	ctx, annotations := WithAnnotations(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "http://upstream.local/path", nil).WithContext(ctx)
+
+	_, err := transport.RoundTrip(req)
+	assert.Error(t, err)
+
+	recorded := annotations.Attrs()[0].Value.Any().([]UpstreamCall)
+	assert.Equal(t, UpstreamCall{Host: "upstream.local", Method: http.MethodGet, DurationMS: 250, Error: "connection refused"}, recorded[0])
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type keyPoolCollector struct {
+	upstream  string
+	stats     func() []domain.UpstreamKeyStats
+	calls     *prometheus.Desc
+	remaining *prometheus.Desc
+	available *prometheus.Desc
+}
+
+func (m *Metrics) RegisterKeyPoolCollector(upstream string, stats func() []domain.UpstreamKeyStats) error {
+	return m.Registry.Register(newKeyPoolCollector(upstream, stats))
+}
+
+func newKeyPoolCollector(upstream string, stats func() []domain.UpstreamKeyStats) *keyPoolCollector {
+	labels := prometheus.Labels{"upstream": upstream}
+	return &keyPoolCollector{
+		upstream:  upstream,
+		stats:     stats,
+		calls:     prometheus.NewDesc(namespace+"_upstream_key_calls", "Calls made with each upstream API key in the current period.", []string{"key", "masked", "period"}, labels),
+		remaining: prometheus.NewDesc(namespace+"_upstream_key_remaining", "Remaining budget of each upstream API key in the current period.", []string{"key", "masked", "period"}, labels),
+		available: prometheus.NewDesc(namespace+"_upstream_key_available", "Whether each upstream API key is in rotation (1) or not (0).", []string{"key", "masked", "status"}, labels),
+	}
+}
+
+func (c *keyPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.calls
+	ch <- c.remaining
+	ch <- c.available
+}
+
+func (c *keyPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, stat := range c.stats() {
+		ch <- prometheus.MustNewConstMetric(c.calls, prometheus.GaugeValue, float64(stat.DailyCalls), stat.ID, stat.Key, "daily")
+		ch <- prometheus.MustNewConstMetric(c.calls, prometheus.GaugeValue, float64(stat.MonthlyCalls), stat.ID, stat.Key, "monthly")
+		if stat.DailyRemaining != nil {
+			ch <- prometheus.MustNewConstMetric(c.remaining, prometheus.GaugeValue, float64(*stat.DailyRemaining), stat.ID, stat.Key, "daily")
+		}
+		if stat.MonthlyRemaining != nil {
+			ch <- prometheus.MustNewConstMetric(c.remaining, prometheus.GaugeValue, float64(*stat.MonthlyRemaining), stat.ID, stat.Key, "monthly")
+		}
+
+		available := 0.0
+		if stat.Status == domain.UpstreamKeyActive {
+			available = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.available, prometheus.GaugeValue, available, stat.ID, stat.Key, stat.Status)
+	}
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPoolCollector(t *testing.T) {
+	remaining := int64(40)
+	m := New()
+	require.NoError(t, m.RegisterKeyPoolCollector(UpstreamWeatherAPI, func() []domain.UpstreamKeyStats {
+		return []domain.UpstreamKeyStats{
+			{ID: "aaaa", Key: "****0001", Status: domain.UpstreamKeyActive, DailyCalls: 60, DailyBudget: 100, DailyRemaining: &remaining, MonthlyCalls: 900},
+			{ID: "bbbb", Key: "****0001", Status: domain.UpstreamKeyExhausted, MonthlyCalls: 1000},
+		}
+	}))
+
+	expected := `
+# HELP weatherzip_upstream_key_available Whether each upstream API key is in rotation (1) or not (0).
+# TYPE weatherzip_upstream_key_available gauge
+weatherzip_upstream_key_available{key="aaaa",masked="****0001",status="active",upstream="weatherapi"} 1
+weatherzip_upstream_key_available{key="bbbb",masked="****0001",status="exhausted",upstream="weatherapi"} 0
+# HELP weatherzip_upstream_key_calls Calls made with each upstream API key in the current period.
+# TYPE weatherzip_upstream_key_calls gauge
+weatherzip_upstream_key_calls{key="aaaa",masked="****0001",period="daily",upstream="weatherapi"} 60
+weatherzip_upstream_key_calls{key="aaaa",masked="****0001",period="monthly",upstream="weatherapi"} 900
+weatherzip_upstream_key_calls{key="bbbb",masked="****0001",period="daily",upstream="weatherapi"} 0
+weatherzip_upstream_key_calls{key="bbbb",masked="****0001",period="monthly",upstream="weatherapi"} 1000
+# HELP weatherzip_upstream_key_remaining Remaining budget of each upstream API key in the current period.
+# TYPE weatherzip_upstream_key_remaining gauge
+weatherzip_upstream_key_remaining{key="aaaa",masked="****0001",period="daily",upstream="weatherapi"} 40
+`
+	require.NoError(t, testutil.GatherAndCompare(m.Registry, strings.NewReader(expected),
+		"weatherzip_upstream_key_available", "weatherzip_upstream_key_calls", "weatherzip_upstream_key_remaining"))
+}
@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "weatherzip"
+
+const (
+	UpstreamViaCep     = "viacep"
+	UpstreamWeatherAPI = "weatherapi"
+)
+
+var breakerStates = []string{"closed", "half_open", "open"}
+
+type Metrics struct {
+	Registry         *prometheus.Registry
+	httpRequests     *prometheus.CounterVec
+	httpDuration     *prometheus.HistogramVec
+	upstreamRequests *prometheus.CounterVec
+	upstreamDuration *prometheus.HistogramVec
+	upstreamErrors   *prometheus.CounterVec
+	cacheRequests    *prometheus.CounterVec
+	breakerState     *prometheus.GaugeVec
+	upstreamHedges   *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total HTTP requests by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by route, method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method", "status"}),
+		upstreamRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "upstream",
+			Name:      "requests_total",
+			Help:      "Total upstream calls by upstream and outcome.",
+		}, []string{"upstream", "outcome"}),
+		upstreamDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "upstream",
+			Name:      "request_duration_seconds",
+			Help:      "Upstream call latency by upstream and outcome.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"upstream", "outcome"}),
+		upstreamErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "upstream",
+			Name:      "errors_total",
+			Help:      "Total upstream errors by upstream and domain error.",
+		}, []string{"upstream", "error"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "cache",
+			Name:      "requests_total",
+			Help:      "Total cache lookups by route and result.",
+		}, []string{"route", "result"}),
+		breakerState: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "circuit_breaker",
+			Name:      "state",
+			Help:      "Circuit breaker state by upstream; the current state is 1, the others are 0.",
+		}, []string{"upstream", "state"}),
+		upstreamHedges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "upstream",
+			Name:      "hedges_total",
+			Help:      "Total hedged upstream requests by upstream and outcome.",
+		}, []string{"upstream", "outcome"}),
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.upstreamRequests,
+		m.upstreamDuration,
+		m.upstreamErrors,
+		m.cacheRequests,
+		m.breakerState,
+		m.upstreamHedges,
+	)
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+func (m *Metrics) ObserveUpstream(upstream string, duration time.Duration, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+		m.upstreamErrors.WithLabelValues(upstream, domain.ErrorCode(err)).Inc()
+	}
+
+	m.upstreamRequests.WithLabelValues(upstream, outcome).Inc()
+	m.upstreamDuration.WithLabelValues(upstream, outcome).Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveCache(route, result string) {
+	m.cacheRequests.WithLabelValues(route, result).Inc()
+}
+
+func (m *Metrics) SetBreakerState(upstream, state string) {
+	for _, known := range breakerStates {
+		value := 0.0
+		if known == state {
+			value = 1
+		}
+		m.breakerState.WithLabelValues(upstream, known).Set(value)
+	}
+}
+
+func (m *Metrics) ObserveHedge(upstream, outcome string) {
+	m.upstreamHedges.WithLabelValues(upstream, outcome).Inc()
+}
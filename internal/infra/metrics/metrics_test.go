@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveUpstream(t *testing.T) {
+	m := New()
+
+	m.ObserveUpstream(UpstreamViaCep, 10*time.Millisecond, nil)
+	m.ObserveUpstream(UpstreamViaCep, 20*time.Millisecond, domain.ErrZipcodeNotFound)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamRequests.WithLabelValues(UpstreamViaCep, "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamRequests.WithLabelValues(UpstreamViaCep, "error")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamErrors.WithLabelValues(UpstreamViaCep, "zipcode_not_found")))
+}
+
+func TestSetBreakerState(t *testing.T) {
+	m := New()
+
+	m.SetBreakerState(UpstreamWeatherAPI, "open")
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.breakerState.WithLabelValues(UpstreamWeatherAPI, "closed")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(m.breakerState.WithLabelValues(UpstreamWeatherAPI, "half_open")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.breakerState.WithLabelValues(UpstreamWeatherAPI, "open")))
+}
+
+func TestObserveHedge(t *testing.T) {
+	m := New()
+
+	m.ObserveHedge(UpstreamWeatherAPI, "fired")
+	m.ObserveHedge(UpstreamWeatherAPI, "fired")
+	m.ObserveHedge(UpstreamWeatherAPI, "won")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.upstreamHedges.WithLabelValues(UpstreamWeatherAPI, "fired")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamHedges.WithLabelValues(UpstreamWeatherAPI, "won")))
+}
+
+func TestHandlerExposesMetrics(t *testing.T) {
+	m := New()
+	m.ObserveCache("/weather/{cep}", "miss")
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.True(t, strings.Contains(body, `weatherzip_cache_requests_total{result="miss",route="/weather/{cep}"} 1`))
+	assert.Contains(t, body, "go_goroutines")
+}
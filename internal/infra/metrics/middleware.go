@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	unmatchedRoute = "unmatched"
+	otherMethod    = "other"
+)
+
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rr := middleware.NewResponseRecorder(w)
+		next.ServeHTTP(rr, r)
+
+		route := routePattern(r)
+		method := methodLabel(r.Method)
+		status := strconv.Itoa(rr.Status())
+		m.httpRequests.WithLabelValues(route, method, status).Inc()
+		m.httpDuration.WithLabelValues(route, method, status).Observe(time.Since(start).Seconds())
+
+		if annotations, ok := logging.AnnotationsFromContext(r.Context()); ok {
+			if result, ok := annotations.Value("cache_status"); ok {
+				m.ObserveCache(route, result.String())
+			}
+		}
+	})
+}
+
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return otherMethod
+	}
+}
+
+func routePattern(r *http.Request) string {
+	if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+		if pattern := routeContext.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return unmatchedRoute
+}
@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := New()
+
+	router := chi.NewRouter()
+	router.Use(m.Middleware)
+	router.Get("/weather/{cep}", func(w http.ResponseWriter, r *http.Request) {
+		logging.Annotate(r.Context(), "cache_status", "miss")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ctx, _ := logging.WithAnnotations(context.Background())
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/weather/01001000", nil).WithContext(ctx))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/01001000", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/unknown/01001000", nil))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.httpRequests.WithLabelValues("/weather/{cep}", http.MethodGet, "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.httpRequests.WithLabelValues(unmatchedRoute, http.MethodGet, "404")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.httpRequests.WithLabelValues(unmatchedRoute, otherMethod, "405")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.cacheRequests.WithLabelValues("/weather/{cep}", "miss")))
+	assert.Equal(t, 3, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestMiddlewareForwardsRecorderHooks(t *testing.T) {
+	m := New()
+	rr := &middleware.ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+
+	m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middleware.WriteError(w, "Zipcode not found")
+		middleware.SetTenant(w, "acme")
+	})).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "Zipcode not found", rr.ReadError())
+	assert.Equal(t, "acme", rr.Tenant())
+}
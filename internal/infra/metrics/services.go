@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var (
+	_ contracts.CepService     = (*CepService)(nil)
+	_ contracts.WeatherService = (*WeatherService)(nil)
+)
+
+type CepService struct {
+	Next    contracts.CepService
+	Metrics *Metrics
+}
+
+func InstrumentCepService(next contracts.CepService, m *Metrics) *CepService {
+	return &CepService{Next: next, Metrics: m}
+}
+
+func (s *CepService) GetLocation(ctx context.Context, cep string) (domain.CepResponse, error) {
+	start := time.Now()
+	response, err := s.Next.GetLocation(ctx, cep)
+	s.Metrics.ObserveUpstream(UpstreamViaCep, time.Since(start), err)
+	return response, err
+}
+
+type WeatherService struct {
+	Next    contracts.WeatherService
+	Metrics *Metrics
+}
+
+func InstrumentWeatherService(next contracts.WeatherService, m *Metrics) *WeatherService {
+	return &WeatherService{Next: next, Metrics: m}
+}
+
+func (s *WeatherService) GetWeather(ctx context.Context, location string) (domain.WeatherResponse, error) {
+	start := time.Now()
+	response, err := s.Next.GetWeather(ctx, location)
+	s.Metrics.ObserveUpstream(UpstreamWeatherAPI, time.Since(start), err)
+	return response, err
+}
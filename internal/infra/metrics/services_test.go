@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstrumentedServices(t *testing.T) {
+	m := New()
+
+	cepService := InstrumentCepService(&mock.MockCepService{
+		GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
+			return domain.CepResponse{Localidade: "São Paulo"}, nil
+		},
+	}, m)
+	weatherService := InstrumentWeatherService(&mock.MockWeatherService{
+		GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
+			return domain.WeatherResponse{}, domain.ErrLocationNotFound
+		},
+	}, m)
+
+	location, err := cepService.GetLocation(context.Background(), "01001000")
+	assert.NoError(t, err)
+	assert.Equal(t, "São Paulo", location.Localidade)
+
+	_, err = weatherService.GetWeather(context.Background(), location.Localidade)
+	assert.ErrorIs(t, err, domain.ErrLocationNotFound)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamRequests.WithLabelValues(UpstreamViaCep, "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.upstreamErrors.WithLabelValues(UpstreamWeatherAPI, "location_not_found")))
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/vs0uz4/weatherzip/internal/service"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type systemCollector struct {
+	cpu           service.CPUService
+	memory        service.MemoryService
+	cpuCores      *prometheus.Desc
+	cpuPercent    *prometheus.Desc
+	memoryBytes   *prometheus.Desc
+	memoryPercent *prometheus.Desc
+}
+
+func (m *Metrics) RegisterSystemCollector(cpu service.CPUService, memory service.MemoryService) error {
+	return m.Registry.Register(newSystemCollector(cpu, memory))
+}
+
+func newSystemCollector(cpu service.CPUService, memory service.MemoryService) *systemCollector {
+	return &systemCollector{
+		cpu:           cpu,
+		memory:        memory,
+		cpuCores:      prometheus.NewDesc(namespace+"_cpu_cores", "Number of logical CPU cores.", nil, nil),
+		cpuPercent:    prometheus.NewDesc(namespace+"_cpu_usage_percent", "CPU usage percentage per core.", []string{"core"}, nil),
+		memoryBytes:   prometheus.NewDesc(namespace+"_memory_bytes", "System memory in bytes by state.", []string{"state"}, nil),
+		memoryPercent: prometheus.NewDesc(namespace+"_memory_usage_percent", "System memory usage percentage.", nil, nil),
+	}
+}
+
+func (c *systemCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.cpuCores
+	ch <- c.cpuPercent
+	ch <- c.memoryBytes
+	ch <- c.memoryPercent
+}
+
+func (c *systemCollector) Collect(ch chan<- prometheus.Metric) {
+	if cores, percentUsed, err := c.cpu.GetCPUStats(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.cpuCores, prometheus.GaugeValue, float64(cores))
+		for i, percent := range percentUsed {
+			ch <- prometheus.MustNewConstMetric(c.cpuPercent, prometheus.GaugeValue, percent, strconv.Itoa(i))
+		}
+	}
+
+	if total, used, free, available, percentUsed, err := c.memory.GetMemoryStats(); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.memoryBytes, prometheus.GaugeValue, float64(total), "total")
+		ch <- prometheus.MustNewConstMetric(c.memoryBytes, prometheus.GaugeValue, float64(used), "used")
+		ch <- prometheus.MustNewConstMetric(c.memoryBytes, prometheus.GaugeValue, float64(free), "free")
+		ch <- prometheus.MustNewConstMetric(c.memoryBytes, prometheus.GaugeValue, float64(available), "available")
+		ch <- prometheus.MustNewConstMetric(c.memoryPercent, prometheus.GaugeValue, percentUsed)
+	}
+}
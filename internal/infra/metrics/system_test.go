@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemCollector(t *testing.T) {
+	collector := newSystemCollector(
+		&mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+			return 2, []float64{12.5, 30}, nil
+		}},
+		&mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+			return 1000, 600, 100, 400, 60, nil
+		}},
+	)
+
+	expected := `
+# HELP weatherzip_cpu_cores Number of logical CPU cores.
+# TYPE weatherzip_cpu_cores gauge
+weatherzip_cpu_cores 2
+# HELP weatherzip_cpu_usage_percent CPU usage percentage per core.
+# TYPE weatherzip_cpu_usage_percent gauge
+weatherzip_cpu_usage_percent{core="0"} 12.5
+weatherzip_cpu_usage_percent{core="1"} 30
+# HELP weatherzip_memory_bytes System memory in bytes by state.
+# TYPE weatherzip_memory_bytes gauge
+weatherzip_memory_bytes{state="available"} 400
+weatherzip_memory_bytes{state="free"} 100
+weatherzip_memory_bytes{state="total"} 1000
+weatherzip_memory_bytes{state="used"} 600
+# HELP weatherzip_memory_usage_percent System memory usage percentage.
+# TYPE weatherzip_memory_usage_percent gauge
+weatherzip_memory_usage_percent 60
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func TestSystemCollectorSkipsFailures(t *testing.T) {
+	m := New()
+	require.NoError(t, m.RegisterSystemCollector(
+		&mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+			return 0, nil, errors.New("mock error")
+		}},
+		&mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+			return 0, 0, 0, 0, 0, errors.New("mock error")
+		}},
+	))
+
+	count, err := testutil.GatherAndCount(m.Registry, "weatherzip_cpu_cores", "weatherzip_memory_bytes")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
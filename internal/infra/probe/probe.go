@@ -0,0 +1,164 @@
+package probe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	StatusPass    = "pass"
+	StatusFail    = "fail"
+	StatusUnknown = "unknown"
+
+	cacheProbeKey = "health:probe"
+)
+
+type Check func(ctx context.Context) error
+
+type Prober struct {
+	timeout  time.Duration
+	mu       sync.RWMutex
+	checks   map[string]Check
+	critical map[string]bool
+	results  map[string]health.ProbeResult
+	nowFunc  func() time.Time
+}
+
+func NewProber(timeout time.Duration) *Prober {
+	return &Prober{
+		timeout:  timeout,
+		checks:   make(map[string]Check),
+		critical: make(map[string]bool),
+		results:  make(map[string]health.ProbeResult),
+		nowFunc:  time.Now,
+	}
+}
+
+func (p *Prober) Register(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks[name] = check
+	p.results[name] = health.ProbeResult{Status: StatusUnknown}
+}
+
+func (p *Prober) RegisterCritical(name string, check Check) {
+	p.Register(name, check)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.critical[name] = true
+}
+
+func (p *Prober) Refresh(ctx context.Context) {
+	p.mu.RLock()
+	checks := make(map[string]Check, len(p.checks))
+	for name, check := range p.checks {
+		checks[name] = check
+	}
+	p.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.store(name, p.run(ctx, check))
+		}()
+	}
+	wg.Wait()
+}
+
+func (p *Prober) Results() (map[string]health.ProbeResult, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ok := true
+	results := make(map[string]health.ProbeResult, len(p.results))
+	for name, result := range p.results {
+		results[name] = result
+		ok = ok && (!p.critical[name] || result.Status == StatusPass)
+	}
+	return results, ok
+}
+
+func (p *Prober) Result(name string) (health.ProbeResult, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	result, ok := p.results[name]
+	return result, ok
+}
+
+func (p *Prober) run(ctx context.Context, check Check) health.ProbeResult {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := p.nowFunc()
+	result := health.ProbeResult{Status: StatusPass}
+	if err := check(ctx); err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	result.Latency = p.nowFunc().Sub(start)
+	result.Duration = result.Latency.String()
+	result.CheckedAt = start.Format(time.RFC3339)
+	return result
+}
+
+func (p *Prober) store(name string, result health.ProbeResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.results[name] = result
+}
+
+func HTTPCheck(client contracts.HttpClient, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return err
+		}
+
+		res, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status code %d", res.StatusCode)
+		}
+		return nil
+	}
+}
+
+func CacheCheck(cache contracts.Cache) Check {
+	return func(ctx context.Context) error {
+		if err := cache.Set(ctx, cacheProbeKey, []byte(StatusPass), time.Minute); err != nil {
+			return err
+		}
+		if _, _, err := cache.Get(ctx, cacheProbeKey); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+func Origin(rawURL string) string {
+	scheme, rest, ok := strings.Cut(rawURL, "://")
+	if !ok {
+		return rawURL
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	host, _, _ = strings.Cut(host, "?")
+	return scheme + "://" + host + "/"
+}
@@ -0,0 +1,127 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/cache"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("mock get error")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("mock set error")
+}
+
+func (failingCache) Delete(ctx context.Context, key string) error {
+	return nil
+}
+
+func TestProberCachesResults(t *testing.T) {
+	prober := NewProber(time.Second)
+	calls := 0
+	prober.Register("viacep", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+	prober.Register("weatherapi", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	prober.RegisterCritical("cache", func(ctx context.Context) error {
+		return nil
+	})
+
+	results, ok := prober.Results()
+	assert.False(t, ok, "Critical checks that never ran should not be ready")
+	assert.Equal(t, StatusUnknown, results["viacep"].Status)
+
+	prober.Refresh(context.Background())
+	results, ok = prober.Results()
+	assert.True(t, ok, "Failing non critical checks should not gate readiness")
+	assert.Equal(t, StatusPass, results["viacep"].Status)
+	assert.NotEmpty(t, results["viacep"].CheckedAt)
+	assert.Equal(t, StatusFail, results["weatherapi"].Status)
+	assert.Equal(t, "connection refused", results["weatherapi"].Error)
+
+	prober.Results()
+	prober.Results()
+	assert.Equal(t, 1, calls, "Reading results should not run checks")
+}
+
+func TestProberGatesOnCriticalChecks(t *testing.T) {
+	prober := NewProber(time.Second)
+	prober.Register("viacep", func(ctx context.Context) error {
+		return nil
+	})
+	prober.RegisterCritical("cache", func(ctx context.Context) error {
+		return errors.New("cache unavailable")
+	})
+
+	prober.Refresh(context.Background())
+	results, ok := prober.Results()
+	assert.False(t, ok)
+	assert.Equal(t, StatusFail, results["cache"].Status)
+	assert.Equal(t, StatusPass, results["viacep"].Status)
+}
+
+func TestProberAppliesTimeout(t *testing.T) {
+	prober := NewProber(10 * time.Millisecond)
+	prober.RegisterCritical("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	prober.Refresh(context.Background())
+
+	results, ok := prober.Results()
+	assert.False(t, ok)
+	assert.Equal(t, context.DeadlineExceeded.Error(), results["slow"].Error)
+}
+
+func TestHTTPCheck(t *testing.T) {
+	var method string
+	status := http.StatusUnauthorized
+	client := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			method = req.Method
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		},
+	}
+	check := HTTPCheck(client, "https://api.weatherapi.com/")
+
+	assert.NoError(t, check(context.Background()), "Any non-5xx answer proves the upstream is reachable")
+	assert.Equal(t, http.MethodHead, method)
+
+	status = http.StatusBadGateway
+	assert.Error(t, check(context.Background()))
+
+	client.DoFunc = func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}
+	assert.Error(t, check(context.Background()))
+}
+
+func TestCacheCheck(t *testing.T) {
+	memory := cache.NewMemoryCache()
+	require.NoError(t, CacheCheck(memory)(context.Background()))
+
+	assert.Error(t, CacheCheck(failingCache{})(context.Background()))
+}
+
+func TestOrigin(t *testing.T) {
+	assert.Equal(t, "https://viacep.com.br/", Origin("https://viacep.com.br/ws/%s/json/"))
+	assert.Equal(t, "https://api.weatherapi.com/", Origin("https://api.weatherapi.com/v1/current.json?key=%s&q=%s"))
+	assert.Equal(t, "http://localhost:8080/", Origin("http://localhost:8080?q=%s"))
+	assert.Equal(t, "invalid", Origin("invalid"))
+}
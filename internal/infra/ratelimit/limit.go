@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+type Limit struct {
+	Requests int
+	Window   time.Duration
+	Burst    int
+}
+
+type Decision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	Reset      time.Duration
+}
+
+type bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type RouteLimit struct {
+	Prefix string
+	IP     Limit
+	APIKey Limit
+}
+
+func ParseLimit(value string) (Limit, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+	}
+
+	count, err := strconv.Atoi(requests)
+	if err != nil || count <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+	}
+
+	if window != "" && !strings.ContainsAny(window[:1], "0123456789") {
+		window = "1" + window
+	}
+	duration, err := time.ParseDuration(window)
+	if err != nil || duration <= 0 {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+	}
+
+	return Limit{Requests: count, Window: duration}, nil
+}
+
+func ParseRouteLimits(value string) ([]RouteLimit, error) {
+	var limits []RouteLimit
+
+	for _, spec := range strings.Split(value, ";") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		prefix, rest, ok := strings.Cut(spec, "=")
+		if !ok || !strings.HasPrefix(prefix, "/") {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLimit, spec)
+		}
+
+		ipSpec, keySpec, hasKey := strings.Cut(rest, ",")
+		ipLimit, err := ParseLimit(ipSpec)
+		if err != nil {
+			return nil, err
+		}
+
+		keyLimit := ipLimit
+		if hasKey {
+			if keyLimit, err = ParseLimit(keySpec); err != nil {
+				return nil, err
+			}
+		}
+
+		limits = append(limits, RouteLimit{Prefix: strings.TrimSpace(prefix), IP: ipLimit, APIKey: keyLimit})
+	}
+
+	return limits, nil
+}
+
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+func (l Limit) capacity() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return float64(l.Requests)
+}
+
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d;w=%d", int(l.capacity()), int(math.Ceil(l.Window.Seconds())))
+}
+
+func (l Limit) take(state bucket, found bool, now time.Time) (bucket, Decision) {
+	capacity := l.capacity()
+	rate := l.rate()
+
+	tokens := capacity
+	if found {
+		elapsed := now.Sub(state.UpdatedAt).Seconds()
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		tokens = math.Min(capacity, state.Tokens+elapsed*rate)
+	}
+
+	decision := Decision{Limit: int(capacity)}
+	if tokens >= 1 {
+		tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+
+	decision.Remaining = int(math.Floor(tokens))
+	decision.Reset = secondsToDuration((capacity - tokens) / rate)
+
+	return bucket{Tokens: tokens, UpdatedAt: now}, decision
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Limit
+		wantErr  bool
+	}{
+		{value: "60/1m", expected: Limit{Requests: 60, Window: time.Minute}},
+		{value: "10/s", expected: Limit{Requests: 10, Window: time.Second}},
+		{value: " 5/30s ", expected: Limit{Requests: 5, Window: 30 * time.Second}},
+		{value: "60", wantErr: true},
+		{value: "0/1m", wantErr: true},
+		{value: "abc/1m", wantErr: true},
+		{value: "10/forever", wantErr: true},
+		{value: "10/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			limit, err := ParseLimit(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, limit)
+		})
+	}
+}
+
+func TestParseRouteLimits(t *testing.T) {
+	limits, err := ParseRouteLimits("/weather=30/1m,300/1m; /subscriptions=10/1m")
+	require.NoError(t, err)
+
+	assert.Equal(t, []RouteLimit{
+		{Prefix: "/weather", IP: Limit{Requests: 30, Window: time.Minute}, APIKey: Limit{Requests: 300, Window: time.Minute}},
+		{Prefix: "/subscriptions", IP: Limit{Requests: 10, Window: time.Minute}, APIKey: Limit{Requests: 10, Window: time.Minute}},
+	}, limits)
+
+	limits, err = ParseRouteLimits("")
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	_, err = ParseRouteLimits("weather=30/1m")
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+
+	_, err = ParseRouteLimits("/weather=30/1m,bad")
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestLimitTake(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Requests: 2, Window: 2 * time.Second}
+
+	state, decision := limit.take(bucket{}, false, now)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Limit)
+	assert.Equal(t, 1, decision.Remaining)
+
+	state, decision = limit.take(state, true, now)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 0, decision.Remaining)
+	assert.Equal(t, 2*time.Second, decision.Reset)
+
+	state, decision = limit.take(state, true, now)
+	assert.False(t, decision.Allowed, "Empty bucket should deny")
+	assert.Equal(t, time.Second, decision.RetryAfter)
+
+	_, decision = limit.take(state, true, now.Add(time.Second))
+	assert.True(t, decision.Allowed, "Bucket should refill over time")
+}
+
+func TestLimitBurstAndPolicy(t *testing.T) {
+	limit := Limit{Requests: 60, Window: time.Minute, Burst: 10}
+
+	assert.Equal(t, "10;w=60", limit.String())
+	assert.False(t, limit.IsZero())
+	assert.True(t, Limit{}.IsZero())
+}
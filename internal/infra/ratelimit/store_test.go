@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreAllow(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.nowFunc = func() time.Time { return now }
+	limit := Limit{Requests: 1, Window: time.Minute}
+	ctx := context.Background()
+
+	decision, err := store.Allow(ctx, "a", limit)
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, _ = store.Allow(ctx, "a", limit)
+	assert.False(t, decision.Allowed)
+
+	decision, _ = store.Allow(ctx, "b", limit)
+	assert.True(t, decision.Allowed, "Keys should have independent buckets")
+
+	now = now.Add(2 * time.Minute)
+	decision, _ = store.Allow(ctx, "c", limit)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 1, store.Len(), "Idle buckets should be swept")
+}
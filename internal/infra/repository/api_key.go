@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/storage"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.APIKeyRepository = (*APIKeyRepository)(nil)
+
+type APIKeyRepository struct {
+	file  *storage.JSONFile[[]domain.APIKey]
+	mu    sync.RWMutex
+	index *apiKeyIndex
+}
+
+type apiKeyIndex struct {
+	keys   []domain.APIKey
+	byID   map[string]domain.APIKey
+	byHash map[string]domain.APIKey
+}
+
+func NewAPIKeyRepository(path string) *APIKeyRepository {
+	return &APIKeyRepository{
+		file: storage.NewJSONFile[[]domain.APIKey](path),
+	}
+}
+
+func (r *APIKeyRepository) Save(key domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.index = nil
+	return r.file.Update(func(keys *[]domain.APIKey) error {
+		for i := range *keys {
+			if (*keys)[i].ID == key.ID {
+				(*keys)[i] = key
+				return nil
+			}
+		}
+		*keys = append(*keys, key)
+		return nil
+	})
+}
+
+func (r *APIKeyRepository) Update(key domain.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.index = nil
+	return r.file.Update(func(keys *[]domain.APIKey) error {
+		for i := range *keys {
+			if (*keys)[i].ID == key.ID {
+				(*keys)[i] = key
+				return nil
+			}
+		}
+		return domain.ErrAPIKeyNotFound
+	})
+}
+
+func (r *APIKeyRepository) FindAll() ([]domain.APIKey, error) {
+	index, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(index.keys), nil
+}
+
+func (r *APIKeyRepository) FindByID(id string) (domain.APIKey, error) {
+	index, err := r.load()
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	key, ok := index.byID[id]
+	if !ok {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) FindByHash(hash string) (domain.APIKey, error) {
+	index, err := r.load()
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+
+	key, ok := index.byHash[hash]
+	if !ok {
+		return domain.APIKey{}, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) load() (*apiKeyIndex, error) {
+	r.mu.RLock()
+	index := r.index
+	r.mu.RUnlock()
+	if index != nil {
+		return index, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index != nil {
+		return r.index, nil
+	}
+
+	keys, err := r.file.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	index = &apiKeyIndex{
+		keys:   append([]domain.APIKey{}, keys...),
+		byID:   make(map[string]domain.APIKey, len(keys)),
+		byHash: make(map[string]domain.APIKey, len(keys)),
+	}
+	for _, key := range keys {
+		index.byID[key.ID] = key
+		index.byHash[key.Hash] = key
+	}
+	r.index = index
+	return index, nil
+}
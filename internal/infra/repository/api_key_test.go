@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPIKey(id, hash string) domain.APIKey {
+	return domain.APIKey{
+		ID:        id,
+		Tenant:    "partner",
+		Scopes:    []string{domain.ScopeWeatherRead},
+		Quota:     100,
+		Prefix:    "wz_1234",
+		Hash:      hash,
+		CreatedAt: time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestAPIKeyRepositorySaveAndFind(t *testing.T) {
+	repo := NewAPIKeyRepository(filepath.Join(t.TempDir(), "api_keys.json"))
+
+	require.NoError(t, repo.Save(newTestAPIKey("key-1", "hash-1")))
+	require.NoError(t, repo.Save(newTestAPIKey("key-2", "hash-2")))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	found, err := repo.FindByID("key-2")
+	assert.NoError(t, err)
+	assert.Equal(t, newTestAPIKey("key-2", "hash-2"), found)
+
+	found, err = repo.FindByHash("hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", found.ID)
+
+	_, err = repo.FindByHash("missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	_, err = repo.FindByID("missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepositoryUpdate(t *testing.T) {
+	repo := NewAPIKeyRepository(filepath.Join(t.TempDir(), "api_keys.json"))
+	key := newTestAPIKey("key-1", "hash-1")
+	require.NoError(t, repo.Save(key))
+
+	key.Hash = "hash-2"
+	require.NoError(t, repo.Save(key), "Save should replace an existing key")
+	require.NoError(t, repo.Update(key))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, "hash-2", all[0].Hash)
+
+	err = repo.Update(newTestAPIKey("missing", "hash"))
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepositoryEmptyAndInvalidFile(t *testing.T) {
+	repo := NewAPIKeyRepository(filepath.Join(t.TempDir(), "api_keys.json"))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+	repo = NewAPIKeyRepository(path)
+
+	_, err = repo.FindAll()
+	assert.Error(t, err)
+	_, err = repo.FindByID("key-1")
+	assert.Error(t, err)
+	_, err = repo.FindByHash("hash")
+	assert.Error(t, err)
+}
+
+func TestAPIKeyRepositoryCachesKeysUntilWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_keys.json")
+	repo := NewAPIKeyRepository(path)
+	require.NoError(t, repo.Save(newTestAPIKey("key-1", "hash-1")))
+
+	_, err := repo.FindByHash("hash-1")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+
+	found, err := repo.FindByHash("hash-1")
+	assert.NoError(t, err, "Lookups should be served from memory")
+	assert.Equal(t, "key-1", found.ID)
+
+	require.NoError(t, os.Remove(path))
+	revokedAt := time.Date(2024, 12, 11, 16, 0, 0, 0, time.UTC)
+	revoked := newTestAPIKey("key-1", "hash-1")
+	revoked.RevokedAt = &revokedAt
+	require.NoError(t, repo.Save(revoked))
+
+	found, err = repo.FindByHash("hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, revoked, found, "Writes should invalidate the cached index")
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/storage"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.DeadLetterRepository = (*DeadLetterRepository)(nil)
+
+type DeadLetterRepository struct {
+	Limit int
+	file  *storage.JSONFile[[]domain.DeadLetter]
+}
+
+func NewDeadLetterRepository(path string) *DeadLetterRepository {
+	return &DeadLetterRepository{
+		file: storage.NewJSONFile[[]domain.DeadLetter](path),
+	}
+}
+
+func (r *DeadLetterRepository) Add(letter domain.DeadLetter) error {
+	return r.file.Update(func(letters *[]domain.DeadLetter) error {
+		*letters = append(*letters, letter)
+		if r.Limit <= 0 {
+			return nil
+		}
+
+		excess := -r.Limit
+		for _, existing := range *letters {
+			if existing.Tenant == letter.Tenant {
+				excess++
+			}
+		}
+
+		kept := (*letters)[:0]
+		for _, existing := range *letters {
+			if existing.Tenant == letter.Tenant && excess > 0 {
+				excess--
+				continue
+			}
+			kept = append(kept, existing)
+		}
+		*letters = kept
+		return nil
+	})
+}
+
+func (r *DeadLetterRepository) FindAll() ([]domain.DeadLetter, error) {
+	letters, err := r.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	if letters == nil {
+		letters = []domain.DeadLetter{}
+	}
+	return letters, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepositoryAddAndFindAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letters.json")
+	repo := NewDeadLetterRepository(path)
+
+	letter := domain.DeadLetter{
+		ID:             "dl-1",
+		SubscriptionID: "sub-1",
+		CallbackURL:    "http://example.com/hook",
+		Event:          domain.WebhookEvent{ID: "evt-1", Type: domain.EventConditionStarted},
+		Attempts:       3,
+		LastError:      "webhook delivery failed with status code: 500",
+		FailedAt:       time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.Add(letter))
+
+	letters, err := NewDeadLetterRepository(path).FindAll()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.DeadLetter{letter}, letters)
+}
+
+func TestDeadLetterRepositoryLimitPerTenant(t *testing.T) {
+	repo := NewDeadLetterRepository(filepath.Join(t.TempDir(), "dead_letters.json"))
+	repo.Limit = 2
+
+	require.NoError(t, repo.Add(domain.DeadLetter{ID: "other-1", Tenant: "globex"}))
+	for _, id := range []string{"dl-1", "dl-2", "dl-3"} {
+		require.NoError(t, repo.Add(domain.DeadLetter{ID: id, Tenant: "acme"}))
+	}
+
+	letters, err := repo.FindAll()
+	assert.NoError(t, err)
+
+	ids := make([]string, 0, len(letters))
+	for _, letter := range letters {
+		ids = append(ids, letter.ID)
+	}
+	assert.Equal(t, []string{"other-1", "dl-2", "dl-3"}, ids, "Oldest letters of the same tenant should be dropped first")
+}
+
+func TestDeadLetterRepositoryFindAllEmpty(t *testing.T) {
+	repo := NewDeadLetterRepository(filepath.Join(t.TempDir(), "dead_letters.json"))
+
+	letters, err := repo.FindAll()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, letters)
+	assert.Empty(t, letters)
+}
+
+func TestDeadLetterRepositoryLoadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dead_letters.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+
+	_, err := NewDeadLetterRepository(path).FindAll()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/storage"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.SubscriptionRepository = (*SubscriptionRepository)(nil)
+
+type SubscriptionRepository struct {
+	file *storage.JSONFile[[]domain.Subscription]
+}
+
+func NewSubscriptionRepository(path string) *SubscriptionRepository {
+	return &SubscriptionRepository{
+		file: storage.NewJSONFile[[]domain.Subscription](path),
+	}
+}
+
+func (r *SubscriptionRepository) Save(subscription domain.Subscription) error {
+	return r.file.Update(func(subscriptions *[]domain.Subscription) error {
+		for i := range *subscriptions {
+			if (*subscriptions)[i].ID == subscription.ID {
+				(*subscriptions)[i] = subscription
+				return nil
+			}
+		}
+		*subscriptions = append(*subscriptions, subscription)
+		return nil
+	})
+}
+
+func (r *SubscriptionRepository) UpdateAll(updated []domain.Subscription) error {
+	byID := make(map[string]domain.Subscription, len(updated))
+	for _, subscription := range updated {
+		byID[subscription.ID] = subscription
+	}
+
+	return r.file.Update(func(subscriptions *[]domain.Subscription) error {
+		for i := range *subscriptions {
+			if subscription, ok := byID[(*subscriptions)[i].ID]; ok {
+				(*subscriptions)[i] = subscription
+			}
+		}
+		return nil
+	})
+}
+
+func (r *SubscriptionRepository) FindAll() ([]domain.Subscription, error) {
+	subscriptions, err := r.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	if subscriptions == nil {
+		subscriptions = []domain.Subscription{}
+	}
+	return subscriptions, nil
+}
+
+func (r *SubscriptionRepository) FindByID(id string) (domain.Subscription, error) {
+	subscriptions, err := r.file.Load()
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.ID == id {
+			return subscription, nil
+		}
+	}
+
+	return domain.Subscription{}, domain.ErrSubscriptionNotFound
+}
+
+func (r *SubscriptionRepository) Delete(id string) error {
+	return r.file.Update(func(subscriptions *[]domain.Subscription) error {
+		for i, subscription := range *subscriptions {
+			if subscription.ID == id {
+				*subscriptions = append((*subscriptions)[:i], (*subscriptions)[i+1:]...)
+				return nil
+			}
+		}
+		return domain.ErrSubscriptionNotFound
+	})
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSubscription(id string) domain.Subscription {
+	return domain.Subscription{
+		ID:          id,
+		Cep:         "01001000",
+		Condition:   domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+		Expression:  "temp_C > 35",
+		CallbackURL: "http://example.com/hook",
+		Secret:      "secret",
+		CreatedAt:   time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSubscriptionRepositorySaveAndFind(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+
+	require.NoError(t, repo.Save(newTestSubscription("sub-1")))
+	require.NoError(t, repo.Save(newTestSubscription("sub-2")))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	found, err := repo.FindByID("sub-2")
+	assert.NoError(t, err)
+	assert.Equal(t, newTestSubscription("sub-2"), found)
+}
+
+func TestSubscriptionRepositorySaveUpdatesExisting(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+	subscription := newTestSubscription("sub-1")
+	require.NoError(t, repo.Save(subscription))
+
+	subscription.Matching = true
+	require.NoError(t, repo.Save(subscription))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.True(t, all[0].Matching, "Saved subscription should be updated in place")
+}
+
+func TestSubscriptionRepositoryUpdateAll(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+	first, second := newTestSubscription("sub-1"), newTestSubscription("sub-2")
+	require.NoError(t, repo.Save(first))
+	require.NoError(t, repo.Save(second))
+
+	first.LastObserved = "36.5"
+	second.LastObserved = "12"
+	require.NoError(t, repo.UpdateAll([]domain.Subscription{first, second, newTestSubscription("deleted")}))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	require.Len(t, all, 2, "UpdateAll should not create missing subscriptions")
+	assert.Equal(t, "36.5", all[0].LastObserved)
+	assert.Equal(t, "12", all[1].LastObserved)
+}
+
+func TestSubscriptionRepositoryPersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+	require.NoError(t, NewSubscriptionRepository(path).Save(newTestSubscription("sub-1")))
+
+	found, err := NewSubscriptionRepository(path).FindByID("sub-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sub-1", found.ID)
+}
+
+func TestSubscriptionRepositoryNotFound(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+
+	_, err := repo.FindByID("missing")
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+
+	err = repo.Delete("missing")
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound)
+}
+
+func TestSubscriptionRepositoryDelete(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+	require.NoError(t, repo.Save(newTestSubscription("sub-1")))
+	require.NoError(t, repo.Save(newTestSubscription("sub-2")))
+
+	require.NoError(t, repo.Delete("sub-1"))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, "sub-2", all[0].ID)
+}
+
+func TestSubscriptionRepositoryFindAllEmpty(t *testing.T) {
+	repo := NewSubscriptionRepository(filepath.Join(t.TempDir(), "subscriptions.json"))
+
+	all, err := repo.FindAll()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, all, "FindAll should return an empty slice instead of nil")
+	assert.Empty(t, all)
+}
+
+func TestSubscriptionRepositoryLoadErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+	repo := NewSubscriptionRepository(path)
+
+	_, err := repo.FindAll()
+	assert.Error(t, err)
+
+	_, err = repo.FindByID("sub-1")
+	assert.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/storage"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+var _ contracts.UpstreamKeyUsageRepository = (*UpstreamKeyUsageRepository)(nil)
+
+type UpstreamKeyUsageRepository struct {
+	file *storage.JSONFile[[]domain.UpstreamKeyUsage]
+}
+
+func NewUpstreamKeyUsageRepository(path string) *UpstreamKeyUsageRepository {
+	return &UpstreamKeyUsageRepository{
+		file: storage.NewJSONFile[[]domain.UpstreamKeyUsage](path),
+	}
+}
+
+func (r *UpstreamKeyUsageRepository) Save(usage domain.UpstreamKeyUsage) error {
+	return r.file.Update(func(all *[]domain.UpstreamKeyUsage) error {
+		for i := range *all {
+			if (*all)[i].ID == usage.ID {
+				(*all)[i] = usage
+				return nil
+			}
+		}
+		*all = append(*all, usage)
+		return nil
+	})
+}
+
+func (r *UpstreamKeyUsageRepository) FindAll() ([]domain.UpstreamKeyUsage, error) {
+	all, err := r.file.Load()
+	if err != nil {
+		return nil, err
+	}
+	if all == nil {
+		all = []domain.UpstreamKeyUsage{}
+	}
+	return all, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamKeyUsageRepositorySaveAndFind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upstream_key_usage.json")
+	repo := NewUpstreamKeyUsageRepository(path)
+
+	require.NoError(t, repo.Save(domain.UpstreamKeyUsage{ID: "key-1", Day: "2024-12-10", DailyCalls: 1}))
+	require.NoError(t, repo.Save(domain.UpstreamKeyUsage{ID: "key-2", Day: "2024-12-10", DailyCalls: 2}))
+	require.NoError(t, repo.Save(domain.UpstreamKeyUsage{ID: "key-1", Day: "2024-12-10", DailyCalls: 3}), "Save should replace existing usage")
+
+	all, err := NewUpstreamKeyUsageRepository(path).FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.UpstreamKeyUsage{
+		{ID: "key-1", Day: "2024-12-10", DailyCalls: 3},
+		{ID: "key-2", Day: "2024-12-10", DailyCalls: 2},
+	}, all, "Usage should survive a new repository instance")
+}
+
+func TestUpstreamKeyUsageRepositoryEmptyAndInvalidFile(t *testing.T) {
+	repo := NewUpstreamKeyUsageRepository(filepath.Join(t.TempDir(), "upstream_key_usage.json"))
+
+	all, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+
+	_, err = NewUpstreamKeyUsageRepository(path).FindAll()
+	assert.Error(t, err)
+}
@@ -0,0 +1,222 @@
+package sampler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/cgroup"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service"
+)
+
+var (
+	ErrNoSamples     = errors.New("no resource samples collected yet")
+	ErrInvalidWindow = errors.New("sample interval must be positive and retention must not be shorter than the interval")
+)
+
+var _ service.CPUService = (*Sampler)(nil)
+
+type Sampler struct {
+	Cgroup       *cgroup.Reader
+	cpu          service.CPUService
+	memory       service.MemoryService
+	interval     time.Duration
+	mu           sync.RWMutex
+	samples      []sample
+	next         int
+	cores        int
+	percentUsed  []float64
+	cpuUsage     time.Duration
+	numGC        uint32
+	pauseTotalNs uint64
+	nowFunc      func() time.Time
+	readMemStats func(*runtime.MemStats)
+	numGoroutine func() int
+}
+
+type sample struct {
+	health.ResourceSample
+	at time.Time
+}
+
+func NewSampler(cpu service.CPUService, memory service.MemoryService, interval, retention time.Duration) (*Sampler, error) {
+	if interval <= 0 || retention < interval {
+		return nil, ErrInvalidWindow
+	}
+
+	return &Sampler{
+		cpu:          cpu,
+		memory:       memory,
+		interval:     interval,
+		samples:      make([]sample, 0, int(retention/interval)),
+		nowFunc:      time.Now,
+		readMemStats: runtime.ReadMemStats,
+		numGoroutine: runtime.NumGoroutine,
+	}, nil
+}
+
+func (s *Sampler) Collect(ctx context.Context) {
+	cores, percentUsed, cpuErr := s.cpu.GetCPUStats()
+	_, memoryUsed, _, _, memoryPercent, memoryErr := s.memory.GetMemoryStats()
+	var memStats runtime.MemStats
+	s.readMemStats(&memStats)
+	var container cgroup.Stats
+	containerErr := cgroup.ErrUnavailable
+	if s.Cgroup != nil {
+		container, containerErr = s.Cgroup.Read()
+	}
+	now := s.nowFunc()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, hasPrevious := s.latest()
+	current := sample{
+		at: now,
+		ResourceSample: health.ResourceSample{
+			Time:          now.Format(time.RFC3339),
+			CPUPercent:    previous.CPUPercent,
+			MemoryPercent: previous.MemoryPercent,
+			MemoryUsed:    previous.MemoryUsed,
+			Goroutines:    s.numGoroutine(),
+			HeapAlloc:     memStats.HeapAlloc,
+		},
+	}
+	if hasPrevious {
+		current.GCCycles = memStats.NumGC - s.numGC
+		current.GCPauseMs = round(float64(memStats.PauseTotalNs-s.pauseTotalNs) / float64(time.Millisecond))
+	}
+	s.numGC, s.pauseTotalNs = memStats.NumGC, memStats.PauseTotalNs
+
+	if cpuErr == nil {
+		s.cores, s.percentUsed = cores, percentUsed
+		current.CPUPercent = round(mean(percentUsed))
+	}
+	if memoryErr == nil {
+		current.MemoryPercent, current.MemoryUsed = memoryPercent, memoryUsed
+	}
+	if containerErr == nil {
+		elapsed := now.Sub(previous.at)
+		if limit := container.CPULimit(); limit > 0 && hasPrevious && elapsed > 0 && container.CPUUsage >= s.cpuUsage {
+			current.CPUPercent = round(float64(container.CPUUsage-s.cpuUsage) / float64(elapsed) / limit * 100)
+		}
+		s.cpuUsage = container.CPUUsage
+		if container.MemoryLimit > 0 {
+			current.MemoryPercent, current.MemoryUsed = round(container.MemoryPercentUsed()), container.MemoryUsage
+		}
+	}
+
+	s.push(current)
+}
+
+func (s *Sampler) GetCPUStats() (int, []float64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.percentUsed == nil {
+		return 0, nil, ErrNoSamples
+	}
+	return s.cores, slices.Clone(s.percentUsed), nil
+}
+
+func (s *Sampler) History(window time.Duration) health.ResourceHistory {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := health.ResourceHistory{
+		Window:   window.String(),
+		Interval: s.interval.String(),
+		Samples:  []health.ResourceSample{},
+	}
+	since := s.nowFunc().Add(-window)
+	for _, sample := range s.ordered() {
+		if !sample.at.Before(since) {
+			history.Samples = append(history.Samples, sample.ResourceSample)
+		}
+	}
+	return history
+}
+
+func (s *Sampler) Stats() *health.ResourceStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	samples := s.ordered()
+	if len(samples) == 0 {
+		return nil
+	}
+
+	return &health.ResourceStats{
+		Samples:       len(samples),
+		Interval:      s.interval.String(),
+		CPUPercent:    aggregate(samples, func(s sample) float64 { return s.CPUPercent }),
+		MemoryPercent: aggregate(samples, func(s sample) float64 { return s.MemoryPercent }),
+		Goroutines:    aggregate(samples, func(s sample) float64 { return float64(s.Goroutines) }),
+		HeapAllocMB:   aggregate(samples, func(s sample) float64 { return float64(s.HeapAlloc) / (1 << 20) }),
+		GCPauseMs:     aggregate(samples, func(s sample) float64 { return s.GCPauseMs }),
+	}
+}
+
+func (s *Sampler) push(current sample) {
+	if len(s.samples) < cap(s.samples) {
+		s.samples = append(s.samples, current)
+		return
+	}
+	s.samples[s.next] = current
+	s.next = (s.next + 1) % len(s.samples)
+}
+
+func (s *Sampler) latest() (sample, bool) {
+	if len(s.samples) == 0 {
+		return sample{}, false
+	}
+	return s.samples[(s.next+len(s.samples)-1)%len(s.samples)], true
+}
+
+func (s *Sampler) ordered() []sample {
+	return append(slices.Clone(s.samples[s.next:]), s.samples[:s.next]...)
+}
+
+func aggregate(samples []sample, value func(sample) float64) health.ResourceAggregate {
+	last := samples[len(samples)-1]
+	var lastMinute, lastFiveMinutes []float64
+	for _, sample := range samples {
+		age := last.at.Sub(sample.at)
+		if age < time.Minute {
+			lastMinute = append(lastMinute, value(sample))
+		}
+		if age < 5*time.Minute {
+			lastFiveMinutes = append(lastFiveMinutes, value(sample))
+		}
+	}
+
+	slices.Sort(lastFiveMinutes)
+	index := int(math.Ceil(0.95*float64(len(lastFiveMinutes)))) - 1
+
+	return health.ResourceAggregate{
+		Last:  round(value(last)),
+		Avg1m: round(mean(lastMinute)),
+		Avg5m: round(mean(lastFiveMinutes)),
+		P95:   round(lastFiveMinutes[index]),
+	}
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10) / 10
+}
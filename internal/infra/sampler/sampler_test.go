@@ -0,0 +1,148 @@
+package sampler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/cgroup"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSampler(t *testing.T, cpuPercents []float64, retention time.Duration) (*Sampler, *time.Time) {
+	calls := 0
+	cpu := &mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+		percent := cpuPercents[min(calls, len(cpuPercents)-1)]
+		calls++
+		return 2, []float64{percent, percent}, nil
+	}}
+	memory := &mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 1000, 400, 600, 600, 40, nil
+	}}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	gcs := uint32(0)
+	sampler, err := NewSampler(cpu, memory, 10*time.Second, retention)
+	require.NoError(t, err)
+	sampler.nowFunc = func() time.Time { return now }
+	sampler.numGoroutine = func() int { return 10 }
+	sampler.readMemStats = func(stats *runtime.MemStats) {
+		gcs++
+		stats.HeapAlloc = 4 << 20
+		stats.NumGC = gcs
+		stats.PauseTotalNs = uint64(gcs) * uint64(time.Millisecond)
+	}
+	return sampler, &now
+}
+
+func TestSamplerStats(t *testing.T) {
+	percents := []float64{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 90, 30, 30, 30, 30, 30}
+	sampler, now := newTestSampler(t, percents, time.Hour)
+	assert.Nil(t, sampler.Stats())
+
+	for range percents {
+		sampler.Collect(context.Background())
+		*now = now.Add(10 * time.Second)
+	}
+
+	stats := sampler.Stats()
+	require.NotNil(t, stats)
+	assert.Equal(t, 30, stats.Samples)
+	assert.Equal(t, "10s", stats.Interval)
+	assert.Equal(t, health.ResourceAggregate{Last: 30, Avg1m: 40, Avg5m: 16, P95: 30}, stats.CPUPercent)
+	assert.Equal(t, health.ResourceAggregate{Last: 40, Avg1m: 40, Avg5m: 40, P95: 40}, stats.MemoryPercent)
+	assert.Equal(t, health.ResourceAggregate{Last: 10, Avg1m: 10, Avg5m: 10, P95: 10}, stats.Goroutines)
+	assert.Equal(t, health.ResourceAggregate{Last: 4, Avg1m: 4, Avg5m: 4, P95: 4}, stats.HeapAllocMB)
+	assert.Equal(t, 1.0, stats.GCPauseMs.Last)
+
+	cores, percentUsed, err := sampler.GetCPUStats()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cores)
+	assert.Equal(t, []float64{30, 30}, percentUsed)
+}
+
+func TestSamplerRingBuffer(t *testing.T) {
+	sampler, now := newTestSampler(t, []float64{1, 2, 3, 4, 5}, 30*time.Second)
+
+	for range 5 {
+		sampler.Collect(context.Background())
+		*now = now.Add(10 * time.Second)
+	}
+
+	history := sampler.History(time.Hour)
+	assert.Equal(t, "1h0m0s", history.Window)
+	assert.Equal(t, "10s", history.Interval)
+	require.Len(t, history.Samples, 3, "Ring buffer should keep only the retention window")
+	assert.Equal(t, []float64{3, 4, 5}, []float64{history.Samples[0].CPUPercent, history.Samples[1].CPUPercent, history.Samples[2].CPUPercent})
+	assert.Equal(t, "2024-01-01T12:00:20Z", history.Samples[0].Time)
+	assert.Equal(t, uint32(1), history.Samples[2].GCCycles)
+
+	recent := sampler.History(25 * time.Second)
+	assert.Len(t, recent.Samples, 2)
+	assert.Empty(t, sampler.History(time.Second).Samples)
+}
+
+func TestSamplerKeepsPreviousValuesOnError(t *testing.T) {
+	sampler, _ := newTestSampler(t, []float64{50}, time.Minute)
+	_, _, err := sampler.GetCPUStats()
+	assert.ErrorIs(t, err, ErrNoSamples)
+
+	sampler.Collect(context.Background())
+	sampler.cpu = &mock.MockCPUService{GetCPUStatsFunc: func() (int, []float64, error) {
+		return 0, nil, errors.New("cpu error")
+	}}
+	sampler.memory = &mock.MockMemoryService{GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+		return 0, 0, 0, 0, 0, errors.New("memory error")
+	}}
+	sampler.Collect(context.Background())
+
+	stats := sampler.Stats()
+	assert.Equal(t, 50.0, stats.CPUPercent.Last)
+	assert.Equal(t, 40.0, stats.MemoryPercent.Last)
+}
+
+func TestSamplerUsesContainerLimits(t *testing.T) {
+	root := t.TempDir()
+	writeCgroup := func(usage time.Duration) {
+		files := map[string]string{
+			"cgroup.controllers": "cpu memory\n",
+			"cpu.max":            "200000 100000\n",
+			"cpu.stat":           fmt.Sprintf("usage_usec %d\n", usage.Microseconds()),
+			"memory.max":         fmt.Sprintf("%d\n", 1<<30),
+			"memory.current":     fmt.Sprintf("%d\n", 1<<28),
+		}
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+		}
+	}
+
+	sampler, now := newTestSampler(t, []float64{5}, time.Minute)
+	sampler.Cgroup = cgroup.NewReader(root)
+
+	writeCgroup(10 * time.Second)
+	sampler.Collect(context.Background())
+	*now = now.Add(10 * time.Second)
+	writeCgroup(20 * time.Second)
+	sampler.Collect(context.Background())
+
+	stats := sampler.Stats()
+	assert.Equal(t, 50.0, stats.CPUPercent.Last, "1s of cpu per second on a 2 cpu quota is 50%")
+	assert.Equal(t, 25.0, stats.MemoryPercent.Last)
+}
+
+func TestNewSamplerValidatesWindow(t *testing.T) {
+	_, err := NewSampler(&mock.MockCPUService{}, &mock.MockMemoryService{}, 0, time.Minute)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+
+	_, err = NewSampler(&mock.MockCPUService{}, &mock.MockMemoryService{}, time.Minute, 30*time.Second)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunsTaskOnInterval(t *testing.T) {
+	var runs int32
+	s := NewScheduler(10*time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+	})
+
+	s.Start()
+	time.Sleep(55 * time.Millisecond)
+	s.Stop()
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&runs), int32(2), "Task should run on every tick")
+}
+
+func TestSchedulerStopWaitsAndCancelsTask(t *testing.T) {
+	var runs, canceled int32
+	s := NewScheduler(time.Millisecond, func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+		<-ctx.Done()
+		atomic.AddInt32(&canceled, 1)
+	})
+
+	s.Start()
+	time.Sleep(10 * time.Millisecond)
+	s.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs), "Task should not run again after Stop")
+	assert.Equal(t, int32(1), atomic.LoadInt32(&canceled), "Stop should cancel the running task and wait for it")
+}
+
+func TestSchedulerStartStopIdempotent(t *testing.T) {
+	var runs int32
+	s := NewScheduler(time.Hour, func(ctx context.Context) {
+		atomic.AddInt32(&runs, 1)
+	})
+
+	assert.NotPanics(t, func() {
+		s.Stop()
+		s.Start()
+		s.Start()
+		s.Stop()
+		s.Stop()
+	})
+	assert.Zero(t, atomic.LoadInt32(&runs))
+}
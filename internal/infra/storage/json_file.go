@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+type JSONFile[T any] struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewJSONFile[T any](path string) *JSONFile[T] {
+	return &JSONFile[T]{path: path}
+}
+
+func (f *JSONFile[T]) Path() string {
+	return f.path
+}
+
+func (f *JSONFile[T]) Load() (T, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.load()
+}
+
+func (f *JSONFile[T]) Save(value T) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.save(value)
+}
+
+func (f *JSONFile[T]) Update(fn func(*T) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	value, err := f.load()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&value); err != nil {
+		return err
+	}
+
+	return f.save(value)
+}
+
+func (f *JSONFile[T]) load() (T, error) {
+	var value T
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return value, nil
+	}
+	if err != nil {
+		return value, err
+	}
+
+	if len(data) == 0 {
+		return value, nil
+	}
+
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+func (f *JSONFile[T]) save(value T) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
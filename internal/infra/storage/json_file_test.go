@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestJSONFileLoadMissingFile(t *testing.T) {
+	file := NewJSONFile[[]testRecord](filepath.Join(t.TempDir(), "missing.json"))
+
+	records, err := file.Load()
+
+	assert.NoError(t, err, "Loading a missing file should not fail")
+	assert.Empty(t, records, "Missing file should load the zero value")
+}
+
+func TestJSONFileSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "records.json")
+	file := NewJSONFile[[]testRecord](path)
+
+	err := file.Save([]testRecord{{Name: "a", Count: 1}, {Name: "b", Count: 2}})
+	require.NoError(t, err, "Save should not fail")
+
+	reloaded := NewJSONFile[[]testRecord](path)
+	records, err := reloaded.Load()
+
+	assert.NoError(t, err, "Load should not fail")
+	assert.Equal(t, []testRecord{{Name: "a", Count: 1}, {Name: "b", Count: 2}}, records)
+	assert.Equal(t, path, reloaded.Path())
+}
+
+func TestJSONFileUpdate(t *testing.T) {
+	file := NewJSONFile[map[string]int](filepath.Join(t.TempDir(), "counters.json"))
+
+	for i := 0; i < 3; i++ {
+		err := file.Update(func(counters *map[string]int) error {
+			if *counters == nil {
+				*counters = map[string]int{}
+			}
+			(*counters)["calls"]++
+			return nil
+		})
+		require.NoError(t, err, "Update should not fail")
+	}
+
+	counters, err := file.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, counters["calls"])
+}
+
+func TestJSONFileUpdateError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.json")
+	file := NewJSONFile[[]testRecord](path)
+
+	err := file.Update(func(records *[]testRecord) error {
+		return errors.New("mock update error")
+	})
+
+	assert.EqualError(t, err, "mock update error")
+	_, statErr := os.Stat(path)
+	assert.True(t, os.IsNotExist(statErr), "Failed update should not write the file")
+}
+
+func TestJSONFileLoadInvalidContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte("invalid_json"), 0o644))
+
+	_, err := NewJSONFile[[]testRecord](path).Load()
+
+	assert.Error(t, err, "Invalid JSON should produce an error")
+}
+
+func TestJSONFileLoadEmptyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(path, nil, 0o644))
+
+	records, err := NewJSONFile[[]testRecord](path).Load()
+
+	assert.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestHttpClientInjectsTraceparent(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	var traceparent string
+	client := NewHttpClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			traceparent = req.Header.Get("traceparent")
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		},
+	})
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://viacep.com.br/ws/01001000/json/", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("server.address", "viacep.com.br"))
+	assert.Contains(t, traceparent, spans[0].SpanContext().SpanID().String())
+	assert.Empty(t, req.Header.Get("traceparent"), "Original request must not be mutated")
+}
+
+func TestHttpClientRecordsErrors(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	client := NewHttpClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.weatherapi.com/v1/current.json", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	assert.Error(t, err)
+
+	assert.Equal(t, codes.Error, recorder.Ended()[0].Status().Code)
+}
+
+func TestHttpClientRedactsQueryFromErrors(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	refused := errors.New("connection refused")
+	client := NewHttpClient(&mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, &url.Error{Op: "Get", URL: req.URL.String(), Err: refused}
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.weatherapi.com/v1/current.json?key=secret-api-key&q=-23.5,-46.6", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+
+	assert.ErrorIs(t, err, refused)
+	assert.NotContains(t, err.Error(), "secret-api-key", "Returned errors end up in logs")
+	assert.Contains(t, err.Error(), "https://api.weatherapi.com/v1/current.json")
+
+	span := recorder.Ended()[0]
+	assert.NotContains(t, span.Status().Description, "secret-api-key")
+	require.NotEmpty(t, span.Events())
+	for _, event := range span.Events() {
+		for _, attr := range event.Attributes {
+			assert.False(t, strings.Contains(attr.Value.Emit(), "secret-api-key"), "Span event %s leaks the api key", event.Name)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := Tracer().Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attribute.String("http.request.method", r.Method)),
+		)
+		defer span.End()
+
+		if span.SpanContext().IsValid() {
+			logging.Annotate(ctx, "trace_id", span.SpanContext().TraceID().String())
+		}
+
+		rr := middleware.NewResponseRecorder(w)
+		next.ServeHTTP(rr, r.WithContext(ctx))
+
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+			if pattern := routeContext.RoutePattern(); pattern != "" {
+				span.SetName(r.Method + " " + pattern)
+				span.SetAttributes(attribute.String("http.route", pattern))
+			}
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", rr.Status()))
+		if annotations, ok := logging.AnnotationsFromContext(ctx); ok {
+			if status, ok := annotations.Value("cache_status"); ok {
+				span.SetAttributes(AttrCacheStatus.String(status.String()))
+			}
+		}
+		if rr.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rr.Status()))
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+	usecasecontracts "github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ProviderViaCep     = "viacep"
+	ProviderWeatherAPI = "weatherapi"
+)
+
+var (
+	_ contracts.CepService                 = (*CepService)(nil)
+	_ contracts.WeatherService             = (*WeatherService)(nil)
+	_ usecasecontracts.WeatherByCepUsecase = (*WeatherByCepUsecase)(nil)
+)
+
+type CepService struct {
+	Next contracts.CepService
+}
+
+func TraceCepService(next contracts.CepService) *CepService {
+	return &CepService{Next: next}
+}
+
+func (s *CepService) GetLocation(ctx context.Context, cep string) (domain.CepResponse, error) {
+	ctx, span := startSpan(ctx, "CepService.GetLocation", ProviderViaCep)
+	defer span.End()
+
+	response, err := s.Next.GetLocation(ctx, cep)
+	recordError(span, err)
+	return response, err
+}
+
+type WeatherService struct {
+	Next contracts.WeatherService
+}
+
+func TraceWeatherService(next contracts.WeatherService) *WeatherService {
+	return &WeatherService{Next: next}
+}
+
+func (s *WeatherService) GetWeather(ctx context.Context, location string) (domain.WeatherResponse, error) {
+	ctx, span := startSpan(ctx, "WeatherService.GetWeather", ProviderWeatherAPI)
+	defer span.End()
+
+	response, err := s.Next.GetWeather(ctx, location)
+	recordError(span, err)
+	return response, err
+}
+
+type WeatherByCepUsecase struct {
+	Next usecasecontracts.WeatherByCepUsecase
+}
+
+func TraceWeatherByCepUsecase(next usecasecontracts.WeatherByCepUsecase) *WeatherByCepUsecase {
+	return &WeatherByCepUsecase{Next: next}
+}
+
+func (uc *WeatherByCepUsecase) GetWeatherByCep(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+	ctx, span := startSpan(ctx, "WeatherByCepUsecase.GetWeatherByCep", "")
+	defer span.End()
+
+	response, err := uc.Next.GetWeatherByCep(ctx, cep)
+	recordError(span, err)
+	return response, err
+}
+
+func startSpan(ctx context.Context, name, provider string) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal))
+	if provider != "" {
+		span.SetAttributes(AttrProvider.String(provider))
+	}
+	return ctx, span
+}
+
+func recordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.SetAttributes(AttrError.String(domain.ErrorCode(err)))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
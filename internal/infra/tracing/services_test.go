@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+	"github.com/vs0uz4/weatherzip/internal/usecase"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracedWeatherByCep(t *testing.T) {
+	recorder := setupRecorder(t)
+
+	cepService := TraceCepService(&mock.MockCepService{
+		GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
+			return domain.CepResponse{Localidade: "São Paulo"}, nil
+		},
+	})
+	weatherService := TraceWeatherService(&mock.MockWeatherService{
+		GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
+			return domain.WeatherResponse{}, domain.ErrLocationNotFound
+		},
+	})
+	uc := TraceWeatherByCepUsecase(usecase.NewWeatherByCepUsecase(cepService, weatherService))
+
+	_, err := uc.GetWeatherByCep(context.Background(), "01001000")
+	assert.ErrorIs(t, err, domain.ErrLocationNotFound)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	require.Len(t, spans, 3)
+
+	root := spans["WeatherByCepUsecase.GetWeatherByCep"]
+	cep := spans["CepService.GetLocation"]
+	weather := spans["WeatherService.GetWeather"]
+
+	assert.Equal(t, root.SpanContext().SpanID(), cep.Parent().SpanID())
+	assert.Equal(t, root.SpanContext().SpanID(), weather.Parent().SpanID())
+	assert.Contains(t, cep.Attributes(), AttrProvider.String(ProviderViaCep))
+	assert.Equal(t, codes.Unset, cep.Status().Code)
+	assert.Contains(t, weather.Attributes(), AttrProvider.String(ProviderWeatherAPI))
+	assert.Contains(t, weather.Attributes(), AttrError.String("location_not_found"))
+	assert.Equal(t, codes.Error, weather.Status().Code)
+	assert.Contains(t, root.Attributes(), AttrError.String("location_not_found"))
+}
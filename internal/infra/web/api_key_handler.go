@@ -0,0 +1,90 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type APIKeyHandler struct {
+	Usecase contracts.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(uc contracts.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{Usecase: uc}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var request domain.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeHandlerError(w, http.StatusRequestEntityTooLarge, "request body too large", "Request body too large")
+			return
+		}
+		writeHandlerError(w, http.StatusBadRequest, "invalid request body", "Invalid request body")
+		return
+	}
+
+	issued, err := h.Usecase.CreateAPIKey(request)
+	if err != nil {
+		h.writeAPIKeyError(w, err)
+		return
+	}
+
+	issued.Hash = ""
+	writeJSON(w, http.StatusCreated, issued)
+}
+
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.Usecase.ListAPIKeys()
+	if err != nil {
+		h.writeAPIKeyError(w, err)
+		return
+	}
+
+	for i := range keys {
+		keys[i].Hash = ""
+	}
+
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func (h *APIKeyHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	issued, err := h.Usecase.RotateAPIKey(chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeAPIKeyError(w, err)
+		return
+	}
+
+	issued.Hash = ""
+	writeJSON(w, http.StatusOK, issued)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.Usecase.RevokeAPIKey(chi.URLParam(r, "id")); err != nil {
+		h.writeAPIKeyError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) writeAPIKeyError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		writeHandlerError(w, http.StatusNotFound, err.Error(), "API key not found")
+	case errors.Is(err, domain.ErrAPIKeyRevoked):
+		writeHandlerError(w, http.StatusConflict, err.Error(), "API key revoked")
+	case errors.Is(err, domain.ErrInvalidTenant), errors.Is(err, domain.ErrInvalidScope),
+		errors.Is(err, domain.ErrInvalidQuota), errors.Is(err, domain.ErrAPIKeyExpired):
+		writeHandlerError(w, http.StatusUnprocessableEntity, err.Error(), "Invalid API key request")
+	default:
+		writeHandlerError(w, http.StatusInternalServerError, "internal server error", "Internal server error")
+	}
+}
@@ -0,0 +1,127 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/usecase/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyHandlerCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createErr      error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{name: "Created", body: `{"tenant":"partner","scopes":["weather:read"],"quota":60}`, expectedStatus: http.StatusCreated},
+		{name: "Invalid Body", body: `invalid_json`, expectedStatus: http.StatusBadRequest, expectedBody: "invalid request body"},
+		{name: "Invalid Scope", body: `{"tenant":"partner","scopes":["root"]}`, createErr: domain.ErrInvalidScope, expectedStatus: http.StatusUnprocessableEntity, expectedBody: "invalid scope"},
+		{name: "Repository Failure", body: `{"tenant":"partner","scopes":["weather:read"]}`, createErr: errors.New("disk full"), expectedStatus: http.StatusInternalServerError, expectedBody: "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewAPIKeyHandler(&mock.MockAPIKeyUsecase{
+				CreateAPIKeyFunc: func(request domain.APIKeyRequest) (domain.IssuedAPIKey, error) {
+					if tt.createErr != nil {
+						return domain.IssuedAPIKey{}, tt.createErr
+					}
+					return domain.IssuedAPIKey{APIKey: domain.APIKey{ID: "key-1", Tenant: request.Tenant, Hash: "hash"}, Key: "wz_plaintext"}, nil
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.CreateAPIKey(w, httptest.NewRequest(http.MethodPost, "/admin/api-keys", strings.NewReader(tt.body)))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				assert.Equal(t, tt.expectedBody, problemDetail(t, w))
+				return
+			}
+
+			var issued domain.IssuedAPIKey
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &issued))
+			assert.Equal(t, "wz_plaintext", issued.Key, "Plaintext key should be returned once on creation")
+			assert.Empty(t, issued.Hash)
+		})
+	}
+}
+
+func TestAPIKeyHandlerListAPIKeysHidesHashes(t *testing.T) {
+	handler := NewAPIKeyHandler(&mock.MockAPIKeyUsecase{
+		ListAPIKeysFunc: func() ([]domain.APIKey, error) {
+			return []domain.APIKey{{ID: "key-1", Hash: "hash"}}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ListAPIKeys(w, httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "hash")
+
+	handler.Usecase.(*mock.MockAPIKeyUsecase).ListAPIKeysFunc = func() ([]domain.APIKey, error) {
+		return nil, errors.New("mock error")
+	}
+	w = httptest.NewRecorder()
+	handler.ListAPIKeys(w, httptest.NewRequest(http.MethodGet, "/admin/api-keys", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAPIKeyHandlerRotateAPIKey(t *testing.T) {
+	handler := NewAPIKeyHandler(&mock.MockAPIKeyUsecase{
+		RotateAPIKeyFunc: func(id string) (domain.IssuedAPIKey, error) {
+			switch id {
+			case "key-1":
+				return domain.IssuedAPIKey{APIKey: domain.APIKey{ID: id, Hash: "hash"}, Key: "wz_rotated"}, nil
+			case "revoked":
+				return domain.IssuedAPIKey{}, domain.ErrAPIKeyRevoked
+			default:
+				return domain.IssuedAPIKey{}, domain.ErrAPIKeyNotFound
+			}
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.RotateAPIKey(w, withURLParam(httptest.NewRequest(http.MethodPost, "/admin/api-keys/key-1/rotate", nil), "id", "key-1"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "wz_rotated")
+	assert.NotContains(t, w.Body.String(), `"hash"`)
+
+	w = httptest.NewRecorder()
+	handler.RotateAPIKey(w, withURLParam(httptest.NewRequest(http.MethodPost, "/admin/api-keys/revoked/rotate", nil), "id", "revoked"))
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.RotateAPIKey(w, withURLParam(httptest.NewRequest(http.MethodPost, "/admin/api-keys/missing/rotate", nil), "id", "missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAPIKeyHandlerRevokeAPIKey(t *testing.T) {
+	handler := NewAPIKeyHandler(&mock.MockAPIKeyUsecase{
+		RevokeAPIKeyFunc: func(id string) error {
+			if id != "key-1" {
+				return domain.ErrAPIKeyNotFound
+			}
+			return nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.RevokeAPIKey(w, withURLParam(httptest.NewRequest(http.MethodDelete, "/admin/api-keys/key-1", nil), "id", "key-1"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.RevokeAPIKey(w, withURLParam(httptest.NewRequest(http.MethodDelete, "/admin/api-keys/missing", nil), "id", "missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
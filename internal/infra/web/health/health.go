@@ -1,5 +1,13 @@
 package health
 
+import "time"
+
+const (
+	StatusPass = "pass"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
 type CPUStats struct {
 	Cores       int       `json:"cores"`
 	PercentUsed []float64 `json:"percent_used"`
@@ -13,12 +21,96 @@ type MemoryStats struct {
 	PercentUsed float64 `json:"percent_used"`
 }
 
+type ContainerStats struct {
+	CgroupVersion       int     `json:"cgroup_version"`
+	CPULimit            float64 `json:"cpu_limit"`
+	CPUQuota            int64   `json:"cpu_quota"`
+	CPUPeriod           uint64  `json:"cpu_period"`
+	CPUPeriods          uint64  `json:"cpu_periods"`
+	CPUThrottledPeriods uint64  `json:"cpu_throttled_periods"`
+	CPUThrottledTime    string  `json:"cpu_throttled_time"`
+	MemoryLimit         uint64  `json:"memory_limit"`
+	MemoryUsage         uint64  `json:"memory_usage"`
+	MemoryPercentUsed   float64 `json:"memory_percent_used"`
+	OOMEvents           uint64  `json:"oom_events"`
+	OOMKills            uint64  `json:"oom_kills"`
+}
+
+type ResourceSample struct {
+	Time          string  `json:"time"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryPercent float64 `json:"memory_percent"`
+	MemoryUsed    uint64  `json:"memory_used"`
+	Goroutines    int     `json:"goroutines"`
+	HeapAlloc     uint64  `json:"heap_alloc"`
+	GCCycles      uint32  `json:"gc_cycles"`
+	GCPauseMs     float64 `json:"gc_pause_ms"`
+}
+
+type ResourceAggregate struct {
+	Last  float64 `json:"last"`
+	Avg1m float64 `json:"avg_1m"`
+	Avg5m float64 `json:"avg_5m"`
+	P95   float64 `json:"p95"`
+}
+
+type ResourceStats struct {
+	Samples       int               `json:"samples"`
+	Interval      string            `json:"interval"`
+	CPUPercent    ResourceAggregate `json:"cpu_percent"`
+	MemoryPercent ResourceAggregate `json:"memory_percent"`
+	Goroutines    ResourceAggregate `json:"goroutines"`
+	HeapAllocMB   ResourceAggregate `json:"heap_alloc_mb"`
+	GCPauseMs     ResourceAggregate `json:"gc_pause_ms"`
+}
+
+type ResourceHistory struct {
+	Window   string           `json:"window"`
+	Interval string           `json:"interval"`
+	Samples  []ResourceSample `json:"samples"`
+}
+
 type HealthStats struct {
-	CPU      CPUStats    `json:"cpu"`
-	Memory   MemoryStats `json:"memory"`
-	Uptime   string      `json:"uptime"`
-	Duration string      `json:"duration"`
-	Status   string      `json:"status"`
-	Message  string      `json:"message"`
-	Time     string      `json:"time"`
+	CPU       CPUStats               `json:"cpu"`
+	Memory    MemoryStats            `json:"memory"`
+	Container *ContainerStats        `json:"container,omitempty"`
+	Resources *ResourceStats         `json:"resources,omitempty"`
+	Upstreams map[string]string      `json:"upstreams,omitempty"`
+	Checks    map[string]CheckResult `json:"checks,omitempty"`
+	Uptime    string                 `json:"uptime"`
+	Duration  string                 `json:"duration"`
+	Status    string                 `json:"status"`
+	Message   string                 `json:"message"`
+	Time      string                 `json:"time"`
+}
+
+type CheckResult struct {
+	ComponentType string `json:"component_type"`
+	ObservedValue any    `json:"observed_value,omitempty"`
+	ObservedUnit  string `json:"observed_unit,omitempty"`
+	Status        string `json:"status"`
+	Output        string `json:"output,omitempty"`
+	Time          string `json:"time"`
+	Details       any    `json:"-"`
+}
+
+type HealthSummary struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Time    string `json:"time"`
+}
+
+type ProbeResult struct {
+	Status    string        `json:"status"`
+	Error     string        `json:"error,omitempty"`
+	Duration  string        `json:"duration,omitempty"`
+	CheckedAt string        `json:"checked_at,omitempty"`
+	Latency   time.Duration `json:"-"`
+}
+
+type ProbeStats struct {
+	Status  string                 `json:"status"`
+	Message string                 `json:"message"`
+	Checks  map[string]ProbeResult `json:"checks,omitempty"`
+	Time    string                 `json:"time"`
 }
@@ -0,0 +1,58 @@
+package health
+
+const ContentTypeHealthJSON = "application/health+json"
+
+type ServiceInfo struct {
+	Version     string
+	ReleaseID   string
+	ServiceID   string
+	Description string
+}
+
+type HealthJSON struct {
+	Status      string                       `json:"status"`
+	Version     string                       `json:"version,omitempty"`
+	ReleaseID   string                       `json:"releaseId,omitempty"`
+	ServiceID   string                       `json:"serviceId,omitempty"`
+	Description string                       `json:"description,omitempty"`
+	Output      string                       `json:"output,omitempty"`
+	Checks      map[string][]HealthJSONCheck `json:"checks,omitempty"`
+}
+
+type HealthJSONCheck struct {
+	ComponentType string `json:"componentType,omitempty"`
+	ObservedValue any    `json:"observedValue,omitempty"`
+	ObservedUnit  string `json:"observedUnit,omitempty"`
+	Status        string `json:"status"`
+	Output        string `json:"output,omitempty"`
+	Time          string `json:"time,omitempty"`
+}
+
+func NewHealthJSON(stats HealthStats, info ServiceInfo, detailed bool) HealthJSON {
+	healthJSON := HealthJSON{
+		Status:      stats.Status,
+		Version:     info.Version,
+		ReleaseID:   info.ReleaseID,
+		ServiceID:   info.ServiceID,
+		Description: info.Description,
+	}
+	if stats.Status != StatusPass {
+		healthJSON.Output = stats.Message
+	}
+	if !detailed || len(stats.Checks) == 0 {
+		return healthJSON
+	}
+
+	healthJSON.Checks = make(map[string][]HealthJSONCheck, len(stats.Checks))
+	for name, result := range stats.Checks {
+		healthJSON.Checks[name] = []HealthJSONCheck{{
+			ComponentType: result.ComponentType,
+			ObservedValue: result.ObservedValue,
+			ObservedUnit:  result.ObservedUnit,
+			Status:        result.Status,
+			Output:        result.Output,
+			Time:          result.Time,
+		}}
+	}
+	return healthJSON
+}
@@ -0,0 +1,52 @@
+package health
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHealthJSON(t *testing.T) {
+	stats := HealthStats{
+		Status:  StatusWarn,
+		Message: "Alive and kicking!",
+		Checks: map[string]CheckResult{
+			"cpu:utilization": {
+				ComponentType: "system",
+				ObservedValue: 12.5,
+				ObservedUnit:  "%",
+				Status:        StatusPass,
+				Time:          "2024-12-10T16:00:00Z",
+				Details:       CPUStats{Cores: 2},
+			},
+		},
+	}
+	info := ServiceInfo{Version: "1", ReleaseID: "1.2.0", ServiceID: "weatherzip", Description: "Weather by zipcode"}
+
+	payload, err := json.Marshal(NewHealthJSON(stats, info, true))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": "warn",
+		"version": "1",
+		"releaseId": "1.2.0",
+		"serviceId": "weatherzip",
+		"description": "Weather by zipcode",
+		"output": "Alive and kicking!",
+		"checks": {
+			"cpu:utilization": [{
+				"componentType": "system",
+				"observedValue": 12.5,
+				"observedUnit": "%",
+				"status": "pass",
+				"time": "2024-12-10T16:00:00Z"
+			}]
+		}
+	}`, string(payload))
+
+	stats.Status = StatusPass
+	summary := NewHealthJSON(stats, info, false)
+	assert.Empty(t, summary.Output, "Output should be omitted when passing")
+	assert.Nil(t, summary.Checks, "Summaries should not expose checks")
+}
@@ -2,29 +2,182 @@ package web
 
 import (
 	"encoding/json"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
 	"github.com/vs0uz4/weatherzip/internal/usecase"
 )
 
 type HealthHandler struct {
-	useCase usecase.HealthCheckUseCase
+	useCase          usecase.HealthCheckUseCase
+	DrainingFunc     func() bool
+	UpstreamsFunc    func() map[string]string
+	DetailAuthorizer func(r *http.Request) bool
+	ReadinessFunc    func() (map[string]health.ProbeResult, bool)
+	ResourcesFunc    func() *health.ResourceStats
+	HistoryFunc      func(window time.Duration) health.ResourceHistory
+	ServiceInfo      health.ServiceInfo
+	started          atomic.Bool
 }
 
 func NewHealthHandler(u usecase.HealthCheckUseCase) *HealthHandler {
-	return &HealthHandler{u}
+	return &HealthHandler{useCase: u}
 }
 
 func (h *HealthHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
-	health, err := h.useCase.GetHealth()
+	stats, err := h.useCase.GetHealth(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, problem.New(http.StatusInternalServerError, err.Error()))
 		return
 	}
 
-	err = json.NewEncoder(w).Encode(health)
+	if h.UpstreamsFunc != nil {
+		stats.Upstreams = h.UpstreamsFunc()
+	}
+
+	if h.ResourcesFunc != nil {
+		stats.Resources = h.ResourcesFunc()
+	}
+
+	if h.DrainingFunc != nil && h.DrainingFunc() {
+		stats.Status = "fail"
+		stats.Message = "Shutting down, not accepting new work"
+	}
+
+	detailed := h.DetailAuthorizer == nil || h.DetailAuthorizer(r)
+	var payload interface{} = stats
+	switch {
+	case acceptsHealthJSON(r):
+		w.Header().Set("Content-Type", health.ContentTypeHealthJSON)
+		w.Header().Set("Cache-Control", "no-store")
+		payload = health.NewHealthJSON(stats, h.ServiceInfo, detailed)
+	case !detailed:
+		payload = healthSummary(stats)
+	}
+
+	if stats.Status == "fail" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	err = json.NewEncoder(w).Encode(payload)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		problem.Write(w, problem.New(http.StatusInternalServerError, err.Error()))
 		return
 	}
 }
+
+func (h *HealthHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	if h.HistoryFunc == nil {
+		problem.Write(w, problem.New(http.StatusNotFound, "resource history is not enabled"))
+		return
+	}
+	if h.DetailAuthorizer != nil && !h.DetailAuthorizer(r) {
+		problem.Write(w, problem.New(http.StatusForbidden, "forbidden"))
+		return
+	}
+
+	window := 5 * time.Minute
+	if value := r.URL.Query().Get("window"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			problem.Write(w, problem.New(http.StatusBadRequest, "invalid window"))
+			return
+		}
+		window = parsed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(h.HistoryFunc(window)); err != nil {
+		problem.Write(w, problem.New(http.StatusInternalServerError, err.Error()))
+	}
+}
+
+func acceptsHealthJSON(r *http.Request) bool {
+	for _, accept := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != health.ContentTypeHealthJSON {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if value, err := strconv.ParseFloat(q, 64); err == nil && value == 0 {
+					continue
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func healthSummary(stats health.HealthStats) health.HealthSummary {
+	return health.HealthSummary{
+		Status:  stats.Status,
+		Message: stats.Message,
+		Time:    stats.Time,
+	}
+}
+
+func (h *HealthHandler) MarkStarted() {
+	h.started.Store(true)
+}
+
+func (h *HealthHandler) GetLiveness(w http.ResponseWriter, r *http.Request) {
+	writeProbe(w, health.ProbeStats{Status: "pass", Message: "Process is running"})
+}
+
+func (h *HealthHandler) GetStartup(w http.ResponseWriter, r *http.Request) {
+	if !h.started.Load() {
+		writeProbe(w, health.ProbeStats{Status: "fail", Message: "Still starting up"})
+		return
+	}
+	writeProbe(w, health.ProbeStats{Status: "pass", Message: "Startup completed"})
+}
+
+func (h *HealthHandler) GetReadiness(w http.ResponseWriter, r *http.Request) {
+	stats := health.ProbeStats{Status: "pass", Message: "Ready to accept traffic"}
+
+	ready := true
+	if h.ReadinessFunc != nil {
+		stats.Checks, ready = h.ReadinessFunc()
+	}
+
+	switch {
+	case !h.started.Load():
+		stats.Status = "fail"
+		stats.Message = "Still starting up"
+	case h.DrainingFunc != nil && h.DrainingFunc():
+		stats.Status = "fail"
+		stats.Message = "Shutting down, not accepting new work"
+	case !ready:
+		stats.Status = "fail"
+		stats.Message = "Dependencies unavailable"
+	}
+
+	if h.DetailAuthorizer != nil && !h.DetailAuthorizer(r) {
+		stats.Checks = nil
+	}
+
+	writeProbe(w, stats)
+}
+
+func writeProbe(w http.ResponseWriter, stats health.ProbeStats) {
+	stats.Time = time.Now().Format(time.RFC3339)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if stats.Status == "fail" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		problem.Write(w, problem.New(http.StatusInternalServerError, err.Error()))
+	}
+}
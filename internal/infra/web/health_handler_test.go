@@ -1,17 +1,21 @@
 package web
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/vs0uz4/weatherzip/internal/infra/checker"
 	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
 	"github.com/vs0uz4/weatherzip/internal/service"
 	"github.com/vs0uz4/weatherzip/internal/usecase"
 	"github.com/vs0uz4/weatherzip/internal/usecase/mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type ErrorResponseWriter struct{}
@@ -30,7 +34,10 @@ func TestHealthHandlerGetHealth(t *testing.T) {
 	cpuService := service.NewCPUService()
 	memoryService := service.NewMemoryService()
 	uptimeService := service.NewUptimeService()
-	healthCheck := usecase.NewHealthCheckUseCase(cpuService, memoryService, uptimeService)
+	registry := checker.NewRegistry(time.Second)
+	require.NoError(t, registry.Register(checker.NewCPUChecker(cpuService)))
+	require.NoError(t, registry.Register(checker.NewMemoryChecker(memoryService)))
+	healthCheck := usecase.NewHealthCheckUseCase(registry, uptimeService)
 	handler := NewHealthHandler(healthCheck)
 
 	req := httptest.NewRequest("GET", "/health", nil)
@@ -44,7 +51,7 @@ func TestHealthHandlerGetHealth(t *testing.T) {
 
 func TestHealthHandlerGetHealthErrorFromUseCase(t *testing.T) {
 	mockUseCase := &mock.MockHealthCheckUseCase{
-		GetHealthFunc: func() (health.HealthStats, error) {
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
 			return health.HealthStats{}, errors.New("mock use case error")
 		},
 	}
@@ -61,7 +68,7 @@ func TestHealthHandlerGetHealthErrorFromUseCase(t *testing.T) {
 
 func TestHealthHandlerGetHealthErrorEncodingResponse(t *testing.T) {
 	mockUseCase := &mock.MockHealthCheckUseCase{
-		GetHealthFunc: func() (health.HealthStats, error) {
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
 			return health.HealthStats{Status: "pass"}, nil
 		},
 	}
@@ -74,3 +81,230 @@ func TestHealthHandlerGetHealthErrorEncodingResponse(t *testing.T) {
 
 	assert.True(t, true, "Encoding error should be handled gracefully")
 }
+
+func TestHealthHandlerGetHealthWhileDraining(t *testing.T) {
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{Status: "pass", Message: "Alive and kicking!"}, nil
+		},
+	}
+
+	handler := NewHealthHandler(mockUseCase)
+	handler.DrainingFunc = func() bool { return true }
+
+	req := httptest.NewRequest("GET", "/health", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetHealth(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "Draining servers should fail health checks")
+	assert.Contains(t, w.Body.String(), `"status":"fail"`)
+}
+
+func TestHealthHandlerGetHealthReportsUpstreams(t *testing.T) {
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{Status: "pass"}, nil
+		},
+	}
+
+	handler := NewHealthHandler(mockUseCase)
+	handler.UpstreamsFunc = func() map[string]string {
+		return map[string]string{"viacep": "closed", "weatherapi": "open"}
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetHealth(w, httptest.NewRequest("GET", "/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"upstreams":{"viacep":"closed","weatherapi":"open"}`)
+}
+
+func TestHealthHandlerGetHealthDetailAuthorizer(t *testing.T) {
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{Status: "pass", Uptime: "1h", CPU: health.CPUStats{Cores: 4}}, nil
+		},
+	}
+
+	handler := NewHealthHandler(mockUseCase)
+	handler.DetailAuthorizer = func(r *http.Request) bool {
+		return r.Header.Get("X-Detail") == "yes"
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetHealth(w, httptest.NewRequest("GET", "/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pass"`)
+	assert.NotContains(t, w.Body.String(), "cpu", "Summary should hide detailed stats")
+
+	req := httptest.NewRequest("GET", "/health", nil)
+	req.Header.Set("X-Detail", "yes")
+	w = httptest.NewRecorder()
+	handler.GetHealth(w, req)
+
+	assert.Contains(t, w.Body.String(), `"cores":4`, "Authorized callers should receive detailed stats")
+}
+
+func TestHealthHandlerGetHealthFailReturnsUnavailable(t *testing.T) {
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{Status: "fail", Message: "Still alive, but not kicking!"}, nil
+		},
+	}
+
+	w := httptest.NewRecorder()
+	NewHealthHandler(mockUseCase).GetHealth(w, httptest.NewRequest("GET", "/health", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestHealthHandlerProbes(t *testing.T) {
+	handler := NewHealthHandler(&mock.MockHealthCheckUseCase{})
+	checks := map[string]health.ProbeResult{"viacep": {Status: "pass"}, "weatherapi": {Status: "pass"}}
+	ready, draining := true, false
+	handler.ReadinessFunc = func() (map[string]health.ProbeResult, bool) {
+		return checks, ready
+	}
+	handler.DrainingFunc = func() bool { return draining }
+
+	probe := func(h http.HandlerFunc, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, probe(handler.GetLiveness, "/health/live").Code)
+	assert.Equal(t, http.StatusServiceUnavailable, probe(handler.GetStartup, "/health/startup").Code)
+	w := probe(handler.GetReadiness, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "Not ready until startup completes")
+	assert.Contains(t, w.Body.String(), "Still starting up")
+
+	handler.MarkStarted()
+	assert.Equal(t, http.StatusOK, probe(handler.GetStartup, "/health/startup").Code)
+	w = probe(handler.GetReadiness, "/health/ready")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"viacep":{"status":"pass"}`)
+
+	ready = false
+	checks["weatherapi"] = health.ProbeResult{Status: "fail", Error: "connection refused"}
+	w = probe(handler.GetReadiness, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Dependencies unavailable")
+
+	ready, draining = true, true
+	w = probe(handler.GetReadiness, "/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Shutting down")
+	assert.Equal(t, http.StatusOK, probe(handler.GetLiveness, "/health/live").Code, "Draining should not fail liveness")
+
+	handler.DetailAuthorizer = func(r *http.Request) bool { return false }
+	assert.NotContains(t, probe(handler.GetReadiness, "/health/ready").Body.String(), "checks", "Summary should hide probe details")
+}
+
+func TestHealthHandlerGetHealthJSONFormat(t *testing.T) {
+	status := "warn"
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{
+				Status:  status,
+				Message: "Still alive, but not kicking!",
+				Checks: map[string]health.CheckResult{
+					"memory:utilization": {ComponentType: "system", ObservedValue: 91.5, ObservedUnit: "%", Status: status},
+				},
+			}, nil
+		},
+	}
+
+	handler := NewHealthHandler(mockUseCase)
+	handler.ServiceInfo = health.ServiceInfo{Version: "1", ReleaseID: "1.2.0", ServiceID: "weatherzip"}
+	get := func(accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/health", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		handler.GetHealth(w, req)
+		return w
+	}
+
+	w := get("application/json;q=0.5, application/health+json")
+	assert.Equal(t, http.StatusOK, w.Code, "Warnings should still return 200")
+	assert.Equal(t, health.ContentTypeHealthJSON, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"releaseId":"1.2.0"`)
+	assert.Contains(t, w.Body.String(), `"memory:utilization":[{"componentType":"system","observedValue":91.5,"observedUnit":"%","status":"warn"}]`)
+
+	status = "fail"
+	w = get("application/health+json")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `"output":"Still alive, but not kicking!"`)
+
+	for _, accept := range []string{"", "application/json", "application/health+json;q=0"} {
+		w = get(accept)
+		assert.NotEqual(t, health.ContentTypeHealthJSON, w.Header().Get("Content-Type"), accept)
+		assert.Contains(t, w.Body.String(), `"observed_value":91.5`, "The current format should remain the default")
+	}
+
+	handler.DetailAuthorizer = func(r *http.Request) bool { return false }
+	w = get("application/health+json")
+	assert.NotContains(t, w.Body.String(), "checks", "Summary should hide checks")
+	assert.Contains(t, w.Body.String(), `"serviceId":"weatherzip"`)
+}
+
+func TestHealthHandlerGetHealthReportsResources(t *testing.T) {
+	mockUseCase := &mock.MockHealthCheckUseCase{
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
+			return health.HealthStats{Status: "pass"}, nil
+		},
+	}
+
+	handler := NewHealthHandler(mockUseCase)
+	handler.ResourcesFunc = func() *health.ResourceStats {
+		return &health.ResourceStats{Samples: 3, CPUPercent: health.ResourceAggregate{Last: 12.5, Avg1m: 10, Avg5m: 8, P95: 12.5}}
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetHealth(w, httptest.NewRequest("GET", "/health", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"cpu_percent":{"last":12.5,"avg_1m":10,"avg_5m":8,"p95":12.5}`)
+}
+
+func TestHealthHandlerGetHistory(t *testing.T) {
+	handler := NewHealthHandler(&mock.MockHealthCheckUseCase{})
+
+	w := httptest.NewRecorder()
+	handler.GetHistory(w, httptest.NewRequest("GET", "/health/history", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code, "History should be unavailable without a sampler")
+
+	var windows []time.Duration
+	handler.HistoryFunc = func(window time.Duration) health.ResourceHistory {
+		windows = append(windows, window)
+		return health.ResourceHistory{Window: window.String(), Samples: []health.ResourceSample{{Time: "2024-01-01T12:00:00Z", CPUPercent: 20}}}
+	}
+
+	w = httptest.NewRecorder()
+	handler.GetHistory(w, httptest.NewRequest("GET", "/health/history", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), `"window":"5m0s"`)
+	assert.Contains(t, w.Body.String(), `"cpu_percent":20`)
+
+	w = httptest.NewRecorder()
+	handler.GetHistory(w, httptest.NewRequest("GET", "/health/history?window=1h", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []time.Duration{5 * time.Minute, time.Hour}, windows)
+
+	for _, window := range []string{"abc", "-1m", "0s"} {
+		w = httptest.NewRecorder()
+		handler.GetHistory(w, httptest.NewRequest("GET", "/health/history?window="+window, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, window)
+	}
+
+	handler.DetailAuthorizer = func(r *http.Request) bool { return false }
+	w = httptest.NewRecorder()
+	handler.GetHistory(w, httptest.NewRequest("GET", "/health/history", nil))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,98 @@
+package httpcache
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+}
+
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified.IsZero()
+}
+
+func SetValidators(w http.ResponseWriter, v Validators) {
+	if v.ETag != "" {
+		w.Header().Set("ETag", v.ETag)
+	}
+	if !v.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", v.LastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+func SetMaxAge(w http.ResponseWriter, maxAge time.Duration, private bool) {
+	visibility := "public"
+	if private {
+		visibility = "private"
+	}
+
+	seconds := int64(maxAge / time.Second)
+	if seconds <= 0 {
+		w.Header().Set("Cache-Control", visibility+", max-age=0, must-revalidate")
+		return
+	}
+	w.Header().Set("Cache-Control", visibility+", max-age="+strconv.FormatInt(seconds, 10))
+}
+
+func SetNoStore(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+func MaxAgeUntil(next, now time.Time) time.Duration {
+	if !next.After(now) {
+		return 0
+	}
+	return next.Sub(now).Truncate(time.Second)
+}
+
+func NotModified(r *http.Request, v Validators) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return v.ETag != "" && matchesETag(inm, v.ETag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || v.LastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	return !v.LastModified.Truncate(time.Second).After(since)
+}
+
+func WriteNotModified(w http.ResponseWriter) {
+	w.Header().Del("Content-Type")
+	w.Header().Del("Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+}
+
+func StrongETag(value string) string {
+	return fmt.Sprintf("%q", value)
+}
+
+func matchesETag(header, etag string) bool {
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
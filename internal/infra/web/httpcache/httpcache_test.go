@@ -0,0 +1,124 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var lastModified = time.Date(2024, 12, 10, 16, 45, 0, 0, time.UTC)
+
+func TestSetValidators(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	SetValidators(w, Validators{ETag: `"abc"`, LastModified: lastModified.In(time.FixedZone("BRT", -3*3600))})
+
+	assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+	assert.Equal(t, "Tue, 10 Dec 2024 16:45:00 GMT", w.Header().Get("Last-Modified"))
+}
+
+func TestSetValidatorsZero(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	SetValidators(w, Validators{})
+
+	assert.True(t, Validators{}.IsZero())
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+}
+
+func TestSetMaxAge(t *testing.T) {
+	tests := []struct {
+		maxAge   time.Duration
+		private  bool
+		expected string
+	}{
+		{10 * time.Minute, false, "public, max-age=600"},
+		{1500 * time.Millisecond, false, "public, max-age=1"},
+		{0, false, "public, max-age=0, must-revalidate"},
+		{-time.Minute, false, "public, max-age=0, must-revalidate"},
+		{10 * time.Minute, true, "private, max-age=600"},
+		{0, true, "private, max-age=0, must-revalidate"},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		SetMaxAge(w, tt.maxAge, tt.private)
+		assert.Equal(t, tt.expected, w.Header().Get("Cache-Control"))
+	}
+}
+
+func TestSetNoStore(t *testing.T) {
+	w := httptest.NewRecorder()
+	SetNoStore(w)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
+
+func TestMaxAgeUntil(t *testing.T) {
+	now := lastModified
+
+	assert.Equal(t, 5*time.Minute, MaxAgeUntil(now.Add(5*time.Minute+300*time.Millisecond), now))
+	assert.Equal(t, time.Duration(0), MaxAgeUntil(now, now))
+	assert.Equal(t, time.Duration(0), MaxAgeUntil(now.Add(-time.Minute), now))
+}
+
+func TestNotModified(t *testing.T) {
+	validators := Validators{ETag: `"abc"`, LastModified: lastModified}
+
+	tests := []struct {
+		name     string
+		method   string
+		headers  map[string]string
+		expected bool
+	}{
+		{"No Conditional Headers", http.MethodGet, nil, false},
+		{"Matching ETag", http.MethodGet, map[string]string{"If-None-Match": `"abc"`}, true},
+		{"Matching Weak ETag", http.MethodGet, map[string]string{"If-None-Match": `W/"abc"`}, true},
+		{"Matching ETag In List", http.MethodGet, map[string]string{"If-None-Match": `"xyz", "abc"`}, true},
+		{"Wildcard ETag", http.MethodHead, map[string]string{"If-None-Match": `*`}, true},
+		{"Different ETag", http.MethodGet, map[string]string{"If-None-Match": `"xyz"`}, false},
+		{"ETag Takes Precedence", http.MethodGet, map[string]string{"If-None-Match": `"xyz"`, "If-Modified-Since": "Tue, 10 Dec 2024 17:00:00 GMT"}, false},
+		{"Not Modified Since", http.MethodGet, map[string]string{"If-Modified-Since": "Tue, 10 Dec 2024 16:45:00 GMT"}, true},
+		{"Modified Since", http.MethodGet, map[string]string{"If-Modified-Since": "Tue, 10 Dec 2024 16:30:00 GMT"}, false},
+		{"Invalid Date", http.MethodGet, map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{"Unsafe Method", http.MethodPost, map[string]string{"If-None-Match": `"abc"`}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/weather/01001000", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, tt.expected, NotModified(req, validators))
+		})
+	}
+}
+
+func TestNotModifiedWithoutValidators(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/weather/01001000", nil)
+	req.Header.Set("If-None-Match", `"abc"`)
+	assert.False(t, NotModified(req, Validators{}))
+
+	req = httptest.NewRequest(http.MethodGet, "/weather/01001000", nil)
+	req.Header.Set("If-Modified-Since", "Tue, 10 Dec 2024 16:45:00 GMT")
+	assert.False(t, NotModified(req, Validators{}))
+}
+
+func TestWriteNotModified(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Type", "application/json")
+
+	WriteNotModified(w)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Type"))
+}
+
+func TestStrongETag(t *testing.T) {
+	assert.Equal(t, `"abc"`, StrongETag("abc"))
+}
@@ -0,0 +1,38 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	p := New(http.StatusServiceUnavailable, "request timed out")
+
+	assert.Equal(t, Problem{
+		Type:   "about:blank",
+		Title:  "Service Unavailable",
+		Status: http.StatusServiceUnavailable,
+		Detail: "request timed out",
+	}, p)
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	p := New(http.StatusTooManyRequests, "slow down")
+	p.Instance = "/weather/01001000"
+
+	Write(w, p)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+
+	var decoded Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &decoded))
+	assert.Equal(t, p, decoded)
+}
@@ -0,0 +1,122 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+	"github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SubscriptionHandler struct {
+	Usecase contracts.SubscriptionUsecase
+}
+
+func NewSubscriptionHandler(uc contracts.SubscriptionUsecase) *SubscriptionHandler {
+	return &SubscriptionHandler{Usecase: uc}
+}
+
+func (h *SubscriptionHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var request domain.SubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeHandlerError(w, http.StatusRequestEntityTooLarge, "request body too large", "Request body too large")
+			return
+		}
+		writeHandlerError(w, http.StatusBadRequest, "invalid request body", "Invalid request body")
+		return
+	}
+
+	subscription, err := h.Usecase.CreateSubscription(requestTenant(r), request)
+	if err != nil {
+		h.writeSubscriptionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, subscription)
+}
+
+func (h *SubscriptionHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.Usecase.ListSubscriptions(requestTenant(r))
+	if err != nil {
+		h.writeSubscriptionError(w, err)
+		return
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	writeJSON(w, http.StatusOK, subscriptions)
+}
+
+func (h *SubscriptionHandler) GetSubscription(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.Usecase.GetSubscription(requestTenant(r), chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeSubscriptionError(w, err)
+		return
+	}
+
+	subscription.Secret = ""
+	writeJSON(w, http.StatusOK, subscription)
+}
+
+func (h *SubscriptionHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	if err := h.Usecase.DeleteSubscription(requestTenant(r), chi.URLParam(r, "id")); err != nil {
+		h.writeSubscriptionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *SubscriptionHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	letters, err := h.Usecase.ListDeadLetters(requestTenant(r))
+	if err != nil {
+		h.writeSubscriptionError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, letters)
+}
+
+func (h *SubscriptionHandler) writeSubscriptionError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		writeHandlerError(w, http.StatusNotFound, err.Error(), "Subscription not found")
+	case errors.Is(err, domain.ErrInvalidZipcode):
+		writeHandlerError(w, http.StatusUnprocessableEntity, err.Error(), "Invalid zipcode")
+	case errors.Is(err, domain.ErrInvalidCondition), errors.Is(err, domain.ErrInvalidCallbackURL):
+		writeHandlerError(w, http.StatusUnprocessableEntity, err.Error(), "Invalid subscription")
+	default:
+		writeHandlerError(w, http.StatusInternalServerError, "internal server error", "Internal server error")
+	}
+}
+
+func requestTenant(r *http.Request) string {
+	principal, _ := middleware.PrincipalFromContext(r.Context())
+	return principal.Tenant
+}
+
+func writeHandlerError(w http.ResponseWriter, status int, body, logMessage string) {
+	middleware.WriteError(w, logMessage)
+	problem.Write(w, problem.New(status, body))
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		writeHandlerError(w, http.StatusInternalServerError, "internal server error", "Internal server error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
@@ -0,0 +1,266 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+	"github.com/vs0uz4/weatherzip/internal/usecase/mock"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withURLParam(r *http.Request, key, value string) *http.Request {
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, routeContext))
+}
+
+func problemDetail(t *testing.T, w *httptest.ResponseRecorder) string {
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var body problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body.Detail
+}
+
+func TestSubscriptionHandlerCreateSubscription(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		createErr      error
+		expectedStatus int
+		expectedBody   string
+		expectedError  string
+	}{
+		{
+			name:           "Created",
+			body:           `{"cep":"01001000","condition":"temp_C > 35","callback_url":"https://example.com/hook"}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid Body",
+			body:           `invalid_json`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid request body",
+			expectedError:  "Invalid request body",
+		},
+		{
+			name:           "Invalid Zipcode",
+			body:           `{"cep":"0100"}`,
+			createErr:      domain.ErrInvalidZipcode,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "invalid zipcode",
+			expectedError:  "Invalid zipcode",
+		},
+		{
+			name:           "Invalid Condition",
+			body:           `{"cep":"01001000","condition":"hot"}`,
+			createErr:      domain.ErrInvalidCondition,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   "invalid subscription condition",
+			expectedError:  "Invalid subscription",
+		},
+		{
+			name:           "Repository Failure",
+			body:           `{"cep":"01001000","condition":"temp_C > 35","callback_url":"https://example.com/hook"}`,
+			createErr:      errors.New("disk full"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "internal server error",
+			expectedError:  "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+				CreateSubscriptionFunc: func(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error) {
+					if tt.createErr != nil {
+						return domain.Subscription{}, tt.createErr
+					}
+					return domain.Subscription{ID: "sub-1", Cep: request.Cep, Secret: "secret"}, nil
+				},
+			})
+
+			rr := &middleware.ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(tt.body))
+			handler.CreateSubscription(rr, req)
+
+			recorder := rr.ResponseWriter.(*httptest.ResponseRecorder)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedError, rr.ReadError())
+
+			if tt.expectedStatus == http.StatusCreated {
+				var subscription domain.Subscription
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &subscription))
+				assert.Equal(t, "sub-1", subscription.ID)
+				assert.Equal(t, "secret", subscription.Secret, "Secret should be returned once on creation")
+				assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+				return
+			}
+
+			assert.Equal(t, tt.expectedBody, problemDetail(t, recorder))
+		})
+	}
+}
+
+func TestSubscriptionHandlerCreateSubscriptionBodyTooLarge(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(`{"cep":"01001000","condition":"temp_C > 35"}`))
+	req.Body = http.MaxBytesReader(w, req.Body, 8)
+	handler.CreateSubscription(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestSubscriptionHandlerListSubscriptionsHidesSecrets(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		ListSubscriptionsFunc: func(tenant string) ([]domain.Subscription, error) {
+			return []domain.Subscription{{ID: "sub-1", Secret: "secret"}}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ListSubscriptions(w, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	var subscriptions []domain.Subscription
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &subscriptions))
+	assert.Len(t, subscriptions, 1)
+}
+
+func TestSubscriptionHandlerListSubscriptionsError(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		ListSubscriptionsFunc: func(tenant string) ([]domain.Subscription, error) {
+			return nil, errors.New("mock error")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ListSubscriptions(w, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestSubscriptionHandlerGetSubscription(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		GetSubscriptionFunc: func(tenant, id string) (domain.Subscription, error) {
+			if id != "sub-1" {
+				return domain.Subscription{}, domain.ErrSubscriptionNotFound
+			}
+			return domain.Subscription{ID: id, Secret: "secret"}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.GetSubscription(w, withURLParam(httptest.NewRequest(http.MethodGet, "/subscriptions/sub-1", nil), "id", "sub-1"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+
+	w = httptest.NewRecorder()
+	handler.GetSubscription(w, withURLParam(httptest.NewRequest(http.MethodGet, "/subscriptions/missing", nil), "id", "missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "subscription not found", problemDetail(t, w))
+}
+
+func TestSubscriptionHandlerDeleteSubscription(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		DeleteSubscriptionFunc: func(tenant, id string) error {
+			if id != "sub-1" {
+				return domain.ErrSubscriptionNotFound
+			}
+			return nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.DeleteSubscription(w, withURLParam(httptest.NewRequest(http.MethodDelete, "/subscriptions/sub-1", nil), "id", "sub-1"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.DeleteSubscription(w, withURLParam(httptest.NewRequest(http.MethodDelete, "/subscriptions/missing", nil), "id", "missing"))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSubscriptionHandlerListDeadLetters(t *testing.T) {
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		ListDeadLettersFunc: func(tenant string) ([]domain.DeadLetter, error) {
+			return []domain.DeadLetter{{ID: "dl-1", Attempts: 3}}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ListDeadLetters(w, httptest.NewRequest(http.MethodGet, "/subscriptions/dead-letters", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var letters []domain.DeadLetter
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &letters))
+	assert.Equal(t, "dl-1", letters[0].ID)
+
+	handler.Usecase.(*mock.MockSubscriptionUsecase).ListDeadLettersFunc = func(tenant string) ([]domain.DeadLetter, error) {
+		return nil, errors.New("mock error")
+	}
+	w = httptest.NewRecorder()
+	handler.ListDeadLetters(w, httptest.NewRequest(http.MethodGet, "/subscriptions/dead-letters", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestSubscriptionHandlerScopesByPrincipalTenant(t *testing.T) {
+	var tenants []string
+	handler := NewSubscriptionHandler(&mock.MockSubscriptionUsecase{
+		CreateSubscriptionFunc: func(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error) {
+			tenants = append(tenants, tenant)
+			return domain.Subscription{ID: "sub-1", Tenant: tenant}, nil
+		},
+		ListSubscriptionsFunc: func(tenant string) ([]domain.Subscription, error) {
+			tenants = append(tenants, tenant)
+			return nil, nil
+		},
+		GetSubscriptionFunc: func(tenant, id string) (domain.Subscription, error) {
+			tenants = append(tenants, tenant)
+			return domain.Subscription{}, domain.ErrSubscriptionNotFound
+		},
+		DeleteSubscriptionFunc: func(tenant, id string) error {
+			tenants = append(tenants, tenant)
+			return nil
+		},
+		ListDeadLettersFunc: func(tenant string) ([]domain.DeadLetter, error) {
+			tenants = append(tenants, tenant)
+			return nil, nil
+		},
+	})
+
+	withPrincipal := func(r *http.Request) *http.Request {
+		return r.WithContext(middleware.WithPrincipal(r.Context(), domain.Principal{ID: "key-1", Tenant: "acme"}))
+	}
+
+	handler.CreateSubscription(httptest.NewRecorder(), withPrincipal(httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(`{"cep":"01001000"}`))))
+	handler.ListSubscriptions(httptest.NewRecorder(), withPrincipal(httptest.NewRequest(http.MethodGet, "/subscriptions", nil)))
+	handler.GetSubscription(httptest.NewRecorder(), withPrincipal(withURLParam(httptest.NewRequest(http.MethodGet, "/subscriptions/sub-1", nil), "id", "sub-1")))
+	handler.DeleteSubscription(httptest.NewRecorder(), withPrincipal(withURLParam(httptest.NewRequest(http.MethodDelete, "/subscriptions/sub-1", nil), "id", "sub-1")))
+	handler.ListDeadLetters(httptest.NewRecorder(), withPrincipal(httptest.NewRequest(http.MethodGet, "/subscriptions/dead-letters", nil)))
+	handler.ListSubscriptions(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+
+	assert.Equal(t, []string{"acme", "acme", "acme", "acme", "acme", ""}, tenants)
+}
+
+func TestWriteJSONEncodingError(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"invalid": make(chan int)})
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,24 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
+
+type UpstreamKeyHandler struct {
+	Pools map[string]func() []domain.UpstreamKeyStats
+}
+
+func NewUpstreamKeyHandler(pools map[string]func() []domain.UpstreamKeyStats) *UpstreamKeyHandler {
+	return &UpstreamKeyHandler{Pools: pools}
+}
+
+func (h *UpstreamKeyHandler) ListUpstreamKeys(w http.ResponseWriter, r *http.Request) {
+	payload := make(map[string][]domain.UpstreamKeyStats, len(h.Pools))
+	for upstream, stats := range h.Pools {
+		payload[upstream] = stats()
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
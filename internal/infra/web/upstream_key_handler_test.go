@@ -0,0 +1,29 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamKeyHandlerListUpstreamKeys(t *testing.T) {
+	remaining := int64(40)
+	handler := NewUpstreamKeyHandler(map[string]func() []domain.UpstreamKeyStats{
+		"weatherapi": func() []domain.UpstreamKeyStats {
+			return []domain.UpstreamKeyStats{
+				{ID: "3f2a9c1d5e7b8a46", Key: "****0001", Weight: 2, Status: domain.UpstreamKeyActive, DailyCalls: 60, DailyBudget: 100, DailyRemaining: &remaining, MonthlyCalls: 900},
+			}
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.ListUpstreamKeys(w, httptest.NewRequest(http.MethodGet, "/admin/upstream-keys", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"weatherapi":[{"id":"3f2a9c1d5e7b8a46","key":"****0001","weight":2,"status":"active","daily_calls":60,"daily_budget":100,"daily_remaining":40,"monthly_calls":900}]}`, w.Body.String())
+}
@@ -1,61 +1,180 @@
 package web
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/httpcache"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
 	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
 	"github.com/vs0uz4/weatherzip/internal/usecase/contracts"
 
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	DefaultWeatherRefreshInterval = 15 * time.Minute
+	DefaultCepCacheMaxAge         = 24 * time.Hour
+)
+
 type WeatherHandler struct {
-	Usecase contracts.WeatherByCepUsecase
+	Usecase         contracts.WeatherByCepUsecase
+	RefreshInterval time.Duration
+	CepCacheMaxAge  time.Duration
+	DegradedMode    bool
+	nowFunc         func() time.Time
 }
 
 func NewWeatherHandler(uc contracts.WeatherByCepUsecase) *WeatherHandler {
-	return &WeatherHandler{Usecase: uc}
+	return &WeatherHandler{
+		Usecase:         uc,
+		RefreshInterval: DefaultWeatherRefreshInterval,
+		CepCacheMaxAge:  DefaultCepCacheMaxAge,
+		nowFunc:         time.Now,
+	}
 }
 
 func (h *WeatherHandler) GetWeatherByCep(w http.ResponseWriter, r *http.Request) {
 	cep := chi.URLParam(r, "cep")
+	logging.Annotate(r.Context(), "cep", logging.MaskCep(cep))
 
-	weather, err := h.Usecase.GetWeatherByCep(cep)
+	weather, err := h.Usecase.GetWeatherByCep(r.Context(), cep)
 	if err != nil {
+		var stepErr *domain.WeatherStepError
+		if h.degradedRequested(r) && errors.As(err, &stepErr) && domain.IsTransientWeatherError(err) {
+			h.writeDegraded(w, r, stepErr)
+			return
+		}
+
 		if errors.Is(err, domain.ErrZipcodeNotFound) {
-			if rr, ok := w.(*middleware.ResponseRecorder); ok {
-				rr.WriteError("Zipcode not found")
-			}
-			http.Error(w, "can not find zipcode", http.StatusNotFound)
+			middleware.WriteError(w, "Zipcode not found")
+			httpcache.SetMaxAge(w, h.CepCacheMaxAge, hasCredentials(r))
+			problem.Write(w, problem.New(http.StatusNotFound, "can not find zipcode"))
 			return
 		}
 
 		if err.Error() == "invalid zipcode" {
-			if rr, ok := w.(*middleware.ResponseRecorder); ok {
-				rr.WriteError("Invalid zipcode")
-			}
-			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			middleware.WriteError(w, "Invalid zipcode")
+			httpcache.SetMaxAge(w, h.CepCacheMaxAge, hasCredentials(r))
+			problem.Write(w, problem.New(http.StatusUnprocessableEntity, err.Error()))
 			return
 		}
 
-		if rr, ok := w.(*middleware.ResponseRecorder); ok {
-			rr.WriteError("Internal server error")
+		if errors.Is(err, domain.ErrUpstreamUnavailable) {
+			middleware.WriteError(w, "Upstream unavailable")
+			httpcache.SetNoStore(w)
+			problem.Write(w, problem.New(http.StatusServiceUnavailable, "service temporarily unavailable"))
+			return
 		}
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+
+		middleware.WriteError(w, "Internal server error")
+		httpcache.SetNoStore(w)
+		problem.Write(w, problem.New(http.StatusInternalServerError, "internal server error"))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(map[string]interface{}{
+	cacheStatus := weather.Cache.Status
+	if cacheStatus == "" {
+		cacheStatus = domain.CacheStatusMiss
+	}
+	logging.Annotate(r.Context(), "cache_status", cacheStatus)
+	if weather.Cache.IsStale() {
+		w.Header().Set("Warning", staleWarning(weather.Cache))
+		w.Header().Set("X-Data-Stale", "true")
+	}
+
+	validators := h.weatherValidators(cep, weather)
+	if weather.Cache.IsStale() {
+		httpcache.SetMaxAge(w, 0, hasCredentials(r))
+	} else if !validators.IsZero() {
+		httpcache.SetValidators(w, validators)
+		httpcache.SetMaxAge(w, httpcache.MaxAgeUntil(validators.LastModified.Add(h.RefreshInterval), h.nowFunc()), hasCredentials(r))
+
+		if httpcache.NotModified(r, validators) {
+			httpcache.WriteNotModified(w)
+			return
+		}
+	}
+
+	payload := map[string]interface{}{
 		"temp_C": weather.Current.TempC,
 		"temp_F": weather.Current.TempF,
 		"temp_K": weather.Current.TempK,
-	}); err != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
+	}
+	if weather.Cache.IsStale() {
+		payload["age_seconds"] = int64(weather.Cache.Age.Seconds())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		w.Header().Del("ETag")
+		w.Header().Del("Last-Modified")
+		httpcache.SetNoStore(w)
+		problem.Write(w, problem.New(http.StatusInternalServerError, "internal server error"))
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+func hasCredentials(r *http.Request) bool {
+	if _, ok := middleware.PrincipalFromContext(r.Context()); ok {
+		return true
+	}
+	return r.Header.Get("Authorization") != "" || r.Header.Get(middleware.APIKeyHeader) != ""
+}
+
+func (h *WeatherHandler) degradedRequested(r *http.Request) bool {
+	if enabled, err := strconv.ParseBool(r.URL.Query().Get("degraded")); err == nil {
+		return enabled
+	}
+	return h.DegradedMode
+}
+
+func (h *WeatherHandler) writeDegraded(w http.ResponseWriter, r *http.Request, stepErr *domain.WeatherStepError) {
+	logging.Annotate(r.Context(), "degraded", true)
+	logging.Annotate(r.Context(), "degraded_reason", domain.ErrorCode(stepErr.Err))
+	httpcache.SetNoStore(w)
+
+	payload := map[string]interface{}{
+		"degraded": true,
+		"address":  stepErr.Location.Address(),
+		"weather":  nil,
+		"errors": []map[string]string{
+			{"source": "weather", "code": domain.ErrorCode(stepErr.Err), "message": "weather data unavailable"},
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Degraded", "true")
+	if err := json.NewEncoder(w).Encode(payload); err != nil {
+		problem.Write(w, problem.New(http.StatusInternalServerError, "internal server error"))
+	}
+}
+
+func staleWarning(info domain.CacheInfo) string {
+	if info.Status == domain.CacheStatusStaleIfError {
+		return `111 - "Revalidation Failed"`
+	}
+	return `110 - "Response is Stale"`
+}
+
+func (h *WeatherHandler) weatherValidators(cep string, weather domain.WeatherResponse) httpcache.Validators {
+	lastUpdated, ok := weather.Current.LastUpdatedAt(weather.Location.Timezone)
+	if !ok {
+		return httpcache.Validators{}
+	}
+
+	sum := sha256.Sum256([]byte(cep + "|" + strconv.FormatInt(lastUpdated.Unix(), 10)))
+
+	return httpcache.Validators{
+		ETag:         httpcache.StrongETag(hex.EncodeToString(sum[:8])),
+		LastModified: lastUpdated,
+	}
+}
@@ -1,15 +1,21 @@
 package web
 
 import (
+	"context"
 	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
 	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
 	"github.com/vs0uz4/weatherzip/internal/usecase/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWeatherHandler(t *testing.T) {
@@ -26,7 +32,7 @@ func TestWeatherHandler(t *testing.T) {
 			inputCEP: "123",
 			mockUsecase: func() *mock.MockWeatherByCepUsecase {
 				return &mock.MockWeatherByCepUsecase{
-					GetWeatherByCepFunc: func(cep string) (domain.WeatherResponse, error) {
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{}, domain.ErrInvalidZipcode
 					},
 				}
@@ -40,7 +46,7 @@ func TestWeatherHandler(t *testing.T) {
 			inputCEP: "99999999",
 			mockUsecase: func() *mock.MockWeatherByCepUsecase {
 				return &mock.MockWeatherByCepUsecase{
-					GetWeatherByCepFunc: func(cep string) (domain.WeatherResponse, error) {
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{}, domain.ErrZipcodeNotFound
 					},
 				}
@@ -54,7 +60,7 @@ func TestWeatherHandler(t *testing.T) {
 			inputCEP: "12345678",
 			mockUsecase: func() *mock.MockWeatherByCepUsecase {
 				return &mock.MockWeatherByCepUsecase{
-					GetWeatherByCepFunc: func(cep string) (domain.WeatherResponse, error) {
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{}, domain.ErrWeatherService
 					},
 				}
@@ -63,12 +69,26 @@ func TestWeatherHandler(t *testing.T) {
 			expectedBody:   "internal server error",
 			expectedError:  "Internal server error",
 		},
+		{
+			name:     "Provedor Indisponível",
+			inputCEP: "12345678",
+			mockUsecase: func() *mock.MockWeatherByCepUsecase {
+				return &mock.MockWeatherByCepUsecase{
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+						return domain.WeatherResponse{}, domain.NewFailedToMakeRequestError(domain.ErrUpstreamUnavailable)
+					},
+				}
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "service temporarily unavailable",
+			expectedError:  "Upstream unavailable",
+		},
 		{
 			name:     "Sucesso",
 			inputCEP: "12345678",
 			mockUsecase: func() *mock.MockWeatherByCepUsecase {
 				return &mock.MockWeatherByCepUsecase{
-					GetWeatherByCepFunc: func(cep string) (domain.WeatherResponse, error) {
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{
 							Current: domain.CurrentWeather{
 								TempC: 25.0,
@@ -87,7 +107,7 @@ func TestWeatherHandler(t *testing.T) {
 			inputCEP: "12345678",
 			mockUsecase: func() *mock.MockWeatherByCepUsecase {
 				return &mock.MockWeatherByCepUsecase{
-					GetWeatherByCepFunc: func(cep string) (domain.WeatherResponse, error) {
+					GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{
 							Current: domain.CurrentWeather{
 								TempC: math.NaN(),
@@ -112,13 +132,15 @@ func TestWeatherHandler(t *testing.T) {
 			handler.GetWeatherByCep(rr, req)
 
 			resp := rr.ResponseWriter.(*httptest.ResponseRecorder).Result()
-			body := strings.TrimSpace(rr.ResponseWriter.(*httptest.ResponseRecorder).Body.String())
-
 			if resp.StatusCode != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
 			}
 
-			if body != tt.expectedBody {
+			if tt.expectedStatus != http.StatusOK {
+				if body := problemDetail(t, rr.ResponseWriter.(*httptest.ResponseRecorder)); body != tt.expectedBody {
+					t.Errorf("Expected body %q, got %q", tt.expectedBody, body)
+				}
+			} else if body := strings.TrimSpace(rr.ResponseWriter.(*httptest.ResponseRecorder).Body.String()); body != tt.expectedBody {
 				t.Errorf("Expected body %q, got %q", tt.expectedBody, body)
 			}
 
@@ -137,3 +159,306 @@ func TestNewWeatherHandlerInitialization(t *testing.T) {
 		t.Errorf("Expected usecase %v, got %v", mockUsecase, handler.Usecase)
 	}
 }
+
+func newCachingWeatherHandler() *WeatherHandler {
+	handler := NewWeatherHandler(&mock.MockWeatherByCepUsecase{
+		GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+			return domain.WeatherResponse{
+				Location: domain.LocationData{Timezone: "America/Sao_Paulo"},
+				Current: domain.CurrentWeather{
+					TempC:            25.0,
+					TempF:            77.0,
+					TempK:            298.15,
+					LastUpdated:      "2024-12-10 13:45",
+					LastUpdatedEpoch: 1733849100,
+				},
+			}, nil
+		},
+	})
+	handler.nowFunc = func() time.Time {
+		return time.Date(2024, 12, 10, 16, 50, 0, 0, time.UTC)
+	}
+	return handler
+}
+
+func TestWeatherHandlerCachingHeaders(t *testing.T) {
+	handler := newCachingWeatherHandler()
+
+	w := httptest.NewRecorder()
+	req := withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000")
+	handler.GetWeatherByCep(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Equal(t, "Tue, 10 Dec 2024 16:45:00 GMT", w.Header().Get("Last-Modified"))
+	assert.Equal(t, "public, max-age=600", w.Header().Get("Cache-Control"), "max-age should last until the next provider refresh")
+}
+
+func TestWeatherHandlerCachingHeadersWithCredentials(t *testing.T) {
+	handler := newCachingWeatherHandler()
+
+	requests := map[string]*http.Request{
+		"API Key":   httptest.NewRequest(http.MethodGet, "/weather/01001000", nil),
+		"Bearer":    httptest.NewRequest(http.MethodGet, "/weather/01001000", nil),
+		"Principal": httptest.NewRequest(http.MethodGet, "/weather/01001000", nil),
+	}
+	requests["API Key"].Header.Set(middleware.APIKeyHeader, "wz_key")
+	requests["Bearer"].Header.Set("Authorization", "Bearer token")
+	requests["Principal"] = requests["Principal"].WithContext(middleware.WithPrincipal(context.Background(), domain.Principal{ID: "key-1"}))
+
+	for name, req := range requests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.GetWeatherByCep(w, withURLParam(req, "cep", "01001000"))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "private, max-age=600", w.Header().Get("Cache-Control"), "Shared caches must not store authenticated responses")
+		})
+	}
+}
+
+func TestWeatherHandlerAnnotatesAccessLog(t *testing.T) {
+	handler := newCachingWeatherHandler()
+
+	ctx, annotations := logging.WithAnnotations(context.Background())
+	req := withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil).WithContext(ctx), "cep", "01001000")
+	handler.GetWeatherByCep(httptest.NewRecorder(), req)
+
+	values := map[string]string{}
+	for _, attr := range annotations.Attrs() {
+		values[attr.Key] = attr.Value.String()
+	}
+	assert.Equal(t, map[string]string{"cep": "01001***", "cache_status": "miss"}, values)
+}
+
+func TestWeatherHandlerMarksStaleResponses(t *testing.T) {
+	tests := []struct {
+		status          string
+		expectedWarning string
+	}{
+		{domain.CacheStatusStale, `110 - "Response is Stale"`},
+		{domain.CacheStatusStaleIfError, `111 - "Revalidation Failed"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			handler := NewWeatherHandler(&mock.MockWeatherByCepUsecase{
+				GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+					return domain.WeatherResponse{
+						Current: domain.CurrentWeather{TempC: 25.0, TempF: 77.0, TempK: 298.15},
+						Cache:   domain.CacheInfo{Status: tt.status, Age: 20 * time.Minute},
+					}, nil
+				},
+			})
+
+			ctx, annotations := logging.WithAnnotations(context.Background())
+			w := httptest.NewRecorder()
+			handler.GetWeatherByCep(w, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil).WithContext(ctx), "cep", "01001000"))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedWarning, w.Header().Get("Warning"))
+			assert.Equal(t, "true", w.Header().Get("X-Data-Stale"))
+			assert.JSONEq(t, `{"temp_C":25,"temp_F":77,"temp_K":298.15,"age_seconds":1200}`, w.Body.String())
+
+			cacheStatus, ok := annotations.Value("cache_status")
+			require.True(t, ok)
+			assert.Equal(t, tt.status, cacheStatus.String())
+		})
+	}
+}
+
+func TestWeatherHandlerStaleResponsesSkipValidators(t *testing.T) {
+	handler := newCachingWeatherHandler()
+	fresh := httptest.NewRecorder()
+	handler.GetWeatherByCep(fresh, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000"))
+	etag := fresh.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	usecase := handler.Usecase
+	handler.Usecase = &mock.MockWeatherByCepUsecase{
+		GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+			weather, err := usecase.GetWeatherByCep(ctx, cep)
+			weather.Cache = domain.CacheInfo{Status: domain.CacheStatusStale, Age: 20 * time.Minute}
+			return weather, err
+		},
+	}
+
+	for _, header := range []string{"If-None-Match", "If-Modified-Since"} {
+		t.Run(header, func(t *testing.T) {
+			value := etag
+			if header == "If-Modified-Since" {
+				value = "Tue, 10 Dec 2024 16:45:00 GMT"
+			}
+			req := withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000")
+			req.Header.Set(header, value)
+			w := httptest.NewRecorder()
+			handler.GetWeatherByCep(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code, "Stale responses must never be confirmed with 304")
+			assert.Empty(t, w.Header().Get("ETag"))
+			assert.Empty(t, w.Header().Get("Last-Modified"))
+			assert.Equal(t, "public, max-age=0, must-revalidate", w.Header().Get("Cache-Control"))
+			assert.Equal(t, "true", w.Header().Get("X-Data-Stale"))
+		})
+	}
+}
+
+func TestWeatherHandlerETagDependsOnCep(t *testing.T) {
+	handler := newCachingWeatherHandler()
+
+	first := httptest.NewRecorder()
+	handler.GetWeatherByCep(first, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000"))
+	second := httptest.NewRecorder()
+	handler.GetWeatherByCep(second, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/98807172", nil), "cep", "98807172"))
+
+	assert.NotEqual(t, first.Header().Get("ETag"), second.Header().Get("ETag"))
+}
+
+func TestWeatherHandlerExpiredRefresh(t *testing.T) {
+	handler := newCachingWeatherHandler()
+	handler.nowFunc = func() time.Time {
+		return time.Date(2024, 12, 10, 17, 30, 0, 0, time.UTC)
+	}
+
+	w := httptest.NewRecorder()
+	handler.GetWeatherByCep(w, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000"))
+
+	assert.Equal(t, "public, max-age=0, must-revalidate", w.Header().Get("Cache-Control"))
+}
+
+func TestWeatherHandlerConditionalRequests(t *testing.T) {
+	handler := newCachingWeatherHandler()
+
+	w := httptest.NewRecorder()
+	handler.GetWeatherByCep(w, withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000"))
+	etag := w.Header().Get("ETag")
+
+	tests := []struct {
+		name           string
+		header         string
+		value          string
+		expectedStatus int
+	}{
+		{"If-None-Match Matches", "If-None-Match", etag, http.StatusNotModified},
+		{"If-None-Match Differs", "If-None-Match", `"other"`, http.StatusOK},
+		{"If-Modified-Since Not Modified", "If-Modified-Since", "Tue, 10 Dec 2024 16:45:00 GMT", http.StatusNotModified},
+		{"If-Modified-Since Modified", "If-Modified-Since", "Tue, 10 Dec 2024 16:00:00 GMT", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := &middleware.ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+			req := withURLParam(httptest.NewRequest(http.MethodGet, "/weather/01001000", nil), "cep", "01001000")
+			req.Header.Set(tt.header, tt.value)
+
+			handler.GetWeatherByCep(rr, req)
+
+			recorder := rr.ResponseWriter.(*httptest.ResponseRecorder)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, etag, recorder.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, recorder.Body.String(), "304 responses must not carry a body")
+			}
+		})
+	}
+}
+
+func TestWeatherHandlerCepOnlyCacheLifetime(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedCache string
+	}{
+		{"Invalid Zipcode", domain.ErrInvalidZipcode, "public, max-age=86400"},
+		{"Zipcode Not Found", domain.ErrZipcodeNotFound, "public, max-age=86400"},
+		{"Weather Service Error", domain.ErrWeatherService, "no-store"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWeatherHandler(&mock.MockWeatherByCepUsecase{
+				GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+					return domain.WeatherResponse{}, tt.err
+				},
+			})
+
+			w := httptest.NewRecorder()
+			handler.GetWeatherByCep(w, httptest.NewRequest(http.MethodGet, "/weather/01001000", nil))
+
+			assert.Equal(t, tt.expectedCache, w.Header().Get("Cache-Control"))
+		})
+	}
+}
+
+func TestWeatherHandlerWithoutLastUpdated(t *testing.T) {
+	handler := NewWeatherHandler(&mock.MockWeatherByCepUsecase{
+		GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+			return domain.WeatherResponse{Current: domain.CurrentWeather{TempC: 25.0}}, nil
+		},
+	})
+
+	w := httptest.NewRecorder()
+	handler.GetWeatherByCep(w, httptest.NewRequest(http.MethodGet, "/weather/01001000", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
+
+func TestWeatherHandlerDegradedResponses(t *testing.T) {
+	location := domain.CepResponse{Cep: "01001-000", Logradouro: "Praça da Sé", Bairro: "Sé", Localidade: "São Paulo", Uf: "SP"}
+	stepErr := &domain.WeatherStepError{Location: location, Err: domain.ErrUpstreamUnavailable}
+
+	tests := []struct {
+		name           string
+		degradedMode   bool
+		query          string
+		err            error
+		expectedStatus int
+		expectDegraded bool
+	}{
+		{"Disabled by default", false, "", stepErr, http.StatusServiceUnavailable, false},
+		{"Enabled by config", true, "", stepErr, http.StatusOK, true},
+		{"Enabled by query", false, "?degraded=true", stepErr, http.StatusOK, true},
+		{"Disabled by query", true, "?degraded=false", stepErr, http.StatusServiceUnavailable, false},
+		{"Invalid query falls back to config", true, "?degraded=maybe", stepErr, http.StatusOK, true},
+		{"Cep step failure is not degraded", true, "", domain.ErrZipcodeNotFound, http.StatusNotFound, false},
+		{"Unknown location is not degraded", true, "", &domain.WeatherStepError{Location: location, Err: domain.ErrLocationNotFound}, http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewWeatherHandler(&mock.MockWeatherByCepUsecase{
+				GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+					return domain.WeatherResponse{}, tt.err
+				},
+			})
+			handler.DegradedMode = tt.degradedMode
+
+			ctx, annotations := logging.WithAnnotations(context.Background())
+			req := httptest.NewRequest(http.MethodGet, "/weather/01001000"+tt.query, nil).WithContext(ctx)
+			w := httptest.NewRecorder()
+			rr := &middleware.ResponseRecorder{ResponseWriter: w}
+			handler.GetWeatherByCep(rr, withURLParam(req, "cep", "01001000"))
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			_, annotated := annotations.Value("degraded")
+			assert.Equal(t, tt.expectDegraded, annotated)
+			if !tt.expectDegraded {
+				return
+			}
+
+			assert.Empty(t, rr.ReadError(), "Degraded responses should not be logged as errors")
+			reason, _ := annotations.Value("degraded_reason")
+			assert.Equal(t, "upstream_unavailable", reason.String())
+			assert.Equal(t, "true", w.Header().Get("X-Degraded"))
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.JSONEq(t, `{
+				"degraded": true,
+				"address": {"zipcode":"01001-000","street":"Praça da Sé","neighborhood":"Sé","city":"São Paulo","state":"SP"},
+				"weather": null,
+				"errors": [{"source":"weather","code":"upstream_unavailable","message":"weather data unavailable"}]
+			}`, w.Body.String())
+		})
+	}
+}
@@ -4,7 +4,11 @@ import "net/http"
 
 type WebServerInterface interface {
 	AddHandler(path string, handler http.HandlerFunc, method string)
+	Use(middlewares ...Middleware)
+	Group(prefix string, fn func(group *RouteGroup)) *RouteGroup
+	Handle(method, path string, handler http.Handler) *Route
+	Mount(prefix string, handler http.Handler) *Route
 	Start()
-	Run()
+	Run() error
 	Stop() error
 }
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AccessLogConfig struct {
+	Logger      *slog.Logger
+	TrustedHops int
+}
+
+func AccessLog(config AccessLogConfig) func(http.Handler) http.Handler {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx, annotations := logging.WithAnnotations(r.Context())
+			rr := NewResponseRecorder(w)
+			next.ServeHTTP(rr, r.WithContext(ctx))
+
+			attrs := []slog.Attr{
+				slog.String("request_id", logging.RequestIDFromContext(ctx)),
+				slog.String("method", r.Method),
+				slog.String("route", routePattern(r)),
+				slog.Int("status", rr.statusCode),
+				slog.Int("bytes", rr.bytesWritten),
+				slog.Float64("latency_ms", logging.Milliseconds(time.Since(start))),
+				slog.String("client_ip", ClientIP(r, config.TrustedHops)),
+				slog.String("tenant", rr.tenant),
+			}
+			if rr.errorMessage != "" {
+				attrs = append(attrs, slog.String("error", rr.errorMessage))
+			}
+			attrs = append(attrs, annotations.Attrs()...)
+
+			logger.LogAttrs(ctx, accessLogLevel(rr.statusCode), "request", attrs...)
+		})
+	}
+}
+
+func routePattern(r *http.Request) string {
+	if routeContext := chi.RouteContext(r.Context()); routeContext != nil {
+		if pattern := routeContext.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+	return r.URL.Path
+}
+
+func accessLogLevel(status int) slog.Level {
+	switch {
+	case status >= 500:
+		return slog.LevelError
+	case status >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
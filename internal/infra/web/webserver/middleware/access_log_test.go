@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	router := chi.NewRouter()
+	router.Use(RequestID, AccessLog(AccessLogConfig{Logger: logger, TrustedHops: 1}))
+	router.Get("/weather/{cep}", func(w http.ResponseWriter, r *http.Request) {
+		SetTenant(w, "acme")
+		WriteError(w, "Zipcode not found")
+		logging.Annotate(r.Context(), "cep", logging.MaskCep(chi.URLParam(r, "cep")))
+		logging.RecordUpstream(r.Context(), logging.UpstreamCall{Host: "viacep.com.br", Method: http.MethodGet, Status: 200})
+		http.Error(w, "can not find zipcode", http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/weather/01001000", nil)
+	req.Header.Set(logging.RequestIDHeader, "req-1")
+	req.Header.Set("X-Forwarded-For", "203.0.113.9")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "request", entry["msg"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, "GET", entry["method"])
+	assert.Equal(t, "/weather/{cep}", entry["route"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["status"])
+	assert.Equal(t, "203.0.113.9", entry["client_ip"])
+	assert.Equal(t, "acme", entry["tenant"])
+	assert.Equal(t, "Zipcode not found", entry["error"])
+	assert.Equal(t, "01001***", entry["cep"])
+	assert.NotContains(t, buf.String(), "01001000")
+	assert.Contains(t, entry, "latency_ms")
+	assert.Greater(t, entry["bytes"], float64(0))
+	assert.Len(t, entry["upstream"], 1)
+}
+
+func TestAccessLogLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelInfo, accessLogLevel(http.StatusOK))
+	assert.Equal(t, slog.LevelWarn, accessLogLevel(http.StatusTooManyRequests))
+	assert.Equal(t, slog.LevelError, accessLogLevel(http.StatusBadGateway))
+}
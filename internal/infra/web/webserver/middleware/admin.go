@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+)
+
+func RequireAdminToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if principal, ok := PrincipalFromContext(r.Context()); ok && principal.HasScope(domain.ScopeAdmin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if token == "" {
+				WriteError(w, "Admin access disabled")
+				problem.Write(w, problem.New(http.StatusForbidden, "admin access is disabled"))
+				return
+			}
+
+			provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || provided == "" {
+				WriteError(w, "Missing admin token")
+				w.Header().Set("WWW-Authenticate", `Bearer realm="admin"`)
+				problem.Write(w, problem.New(http.StatusUnauthorized, "missing admin token"))
+				return
+			}
+
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				WriteError(w, "Invalid admin token")
+				problem.Write(w, problem.New(http.StatusForbidden, "invalid admin token"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/admission"
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+)
+
+type AdmissionConfig struct {
+	Controller *admission.Controller
+	Critical   []string
+	Low        []string
+	Cached     func(r *http.Request) bool
+}
+
+func Admission(config AdmissionConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			priority := admission.PriorityNormal
+			switch {
+			case isExempt(r.URL.Path, config.Critical):
+				priority = admission.PriorityCritical
+			case isExempt(r.URL.Path, config.Low):
+				priority = admission.PriorityLow
+			case config.Cached != nil && config.Cached(r):
+				priority = admission.PriorityCritical
+			}
+
+			release, ok := config.Controller.Admit(priority)
+			if !ok {
+				logging.Annotate(r.Context(), "shed_priority", priority.String())
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(config.Controller.Config.RetryAfter)))
+				WriteError(w, "Load shed")
+				problem.Write(w, problem.New(http.StatusServiceUnavailable, "server is overloaded, retry later"))
+				return
+			}
+			defer release()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/admission"
+	"github.com/vs0uz4/weatherzip/internal/infra/logging"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionShedsLowerPriorityFirst(t *testing.T) {
+	config := admission.DefaultConfig()
+	config.MaxInFlight = 10
+	config.CPUThreshold, config.MemoryThreshold = 0, 0
+	controller := admission.NewController(config, nil, nil)
+
+	var releases []func()
+	for i := 0; i < 8; i++ {
+		release, ok := controller.Admit(admission.PriorityNormal)
+		require.True(t, ok)
+		releases = append(releases, release)
+	}
+	defer func() {
+		for _, release := range releases {
+			release()
+		}
+	}()
+
+	handler := Admission(AdmissionConfig{
+		Controller: controller,
+		Critical:   []string{"/health"},
+		Low:        []string{"/subscriptions"},
+		Cached: func(r *http.Request) bool {
+			return r.URL.Path == "/weather/98807172"
+		},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	ctx, annotations := logging.WithAnnotations(context.Background())
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subscriptions", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	priority, ok := annotations.Value("shed_priority")
+	require.True(t, ok)
+	assert.Equal(t, "low", priority.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weather/01001000", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Normal requests should still be admitted")
+
+	for i := 0; i < 2; i++ {
+		release, ok := controller.Admit(admission.PriorityNormal)
+		require.True(t, ok)
+		releases = append(releases, release)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weather/01001000", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/weather/98807172", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Cached reads should not be shed")
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Health probes should never be shed")
+
+	assert.InDelta(t, 1.0, controller.Pressure(), 0.001, "Admitted requests should release their slot")
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+)
+
+const (
+	APIKeyHeader     = "X-API-Key"
+	APIKeyQueryParam = "api_key"
+)
+
+type principalContextKey struct{}
+
+type APIKeyAuthenticator func(key string) (domain.Principal, error)
+
+type TokenAuthenticator func(ctx context.Context, token string) (domain.Principal, error)
+
+type AuthConfig struct {
+	Authenticate  APIKeyAuthenticator
+	ValidateToken TokenAuthenticator
+	Required      bool
+	Exempt        []string
+}
+
+func Authenticate(config AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			exempt := isExempt(r.URL.Path, config.Exempt)
+
+			principal, r, err := authenticateRequest(config, r)
+			if err != nil && !exempt {
+				writeAuthError(w, err)
+				return
+			}
+
+			if principal == nil {
+				if config.Required && !exempt {
+					writeUnauthorized(w, "missing credentials", `APIKey realm="weatherzip"`)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			SetTenant(w, principal.Tenant)
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), *principal)))
+		})
+	}
+}
+
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				writeUnauthorized(w, "missing credentials", `Bearer realm="weatherzip", scope="`+scope+`"`)
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				WriteError(w, "Insufficient scope")
+				w.Header().Set("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+				problem.Write(w, problem.New(http.StatusForbidden, "missing required scope: "+scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func WithPrincipal(ctx context.Context, principal domain.Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (domain.Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(domain.Principal)
+	return principal, ok
+}
+
+func authenticateRequest(config AuthConfig, r *http.Request) (*domain.Principal, *http.Request, error) {
+	if token, ok := bearerToken(r); ok && config.ValidateToken != nil {
+		principal, err := config.ValidateToken(r.Context(), token)
+		if err != nil {
+			return nil, r, err
+		}
+		return &principal, r, nil
+	}
+
+	key, r := extractAPIKey(r)
+	if key == "" || config.Authenticate == nil {
+		return nil, r, nil
+	}
+
+	principal, err := config.Authenticate(key)
+	if err != nil {
+		return nil, r, err
+	}
+	return &principal, r, nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
+
+func extractAPIKey(r *http.Request) (string, *http.Request) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key, r
+	}
+
+	query := r.URL.Query()
+	key := query.Get(APIKeyQueryParam)
+	if key == "" {
+		return "", r
+	}
+
+	query.Del(APIKeyQueryParam)
+	r = r.Clone(r.Context())
+	r.URL.RawQuery = query.Encode()
+	r.RequestURI = r.URL.RequestURI()
+
+	return key, r
+}
+
+func writeAuthError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidToken):
+		writeUnauthorized(w, err.Error(), `Bearer error="invalid_token"`)
+	case errors.Is(err, domain.ErrInvalidAPIKey), errors.Is(err, domain.ErrAPIKeyExpired), errors.Is(err, domain.ErrAPIKeyRevoked):
+		writeUnauthorized(w, err.Error(), `APIKey realm="weatherzip"`)
+	case errors.Is(err, domain.ErrUpstreamUnavailable):
+		slog.Error("authentication unavailable", slog.String("error", err.Error()))
+		WriteError(w, "Authentication unavailable")
+		problem.Write(w, problem.New(http.StatusServiceUnavailable, "authentication temporarily unavailable"))
+	default:
+		slog.Warn("authentication failed", slog.String("error", err.Error()))
+		WriteError(w, "Authentication failed")
+		problem.Write(w, problem.New(http.StatusInternalServerError, "authentication failed"))
+	}
+}
+
+func writeUnauthorized(w http.ResponseWriter, detail, challenge string) {
+	WriteError(w, "Unauthorized")
+	w.Header().Set("WWW-Authenticate", challenge)
+	problem.Write(w, problem.New(http.StatusUnauthorized, detail))
+}
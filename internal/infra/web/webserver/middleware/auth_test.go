@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testAuthenticator(key string) (domain.Principal, error) {
+	switch key {
+	case "wz_valid":
+		return domain.Principal{ID: "key-1", Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}, Method: domain.AuthMethodAPIKey}, nil
+	case "wz_revoked":
+		return domain.Principal{}, domain.ErrAPIKeyRevoked
+	case "wz_broken":
+		return domain.Principal{}, errors.New("store unavailable")
+	default:
+		return domain.Principal{}, domain.ErrInvalidAPIKey
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	tests := []struct {
+		name           string
+		required       bool
+		target         string
+		header         string
+		expectedStatus int
+		expectedTenant string
+		expectedQuery  string
+	}{
+		{name: "Header Key", target: "/weather/01001000", header: "wz_valid", expectedStatus: http.StatusOK, expectedTenant: "partner"},
+		{name: "Query Key Is Stripped", target: "/weather/01001000?api_key=wz_valid&units=c", expectedStatus: http.StatusOK, expectedTenant: "partner", expectedQuery: "units=c"},
+		{name: "Invalid Key", target: "/weather/01001000", header: "wz_other", expectedStatus: http.StatusUnauthorized},
+		{name: "Revoked Key", target: "/weather/01001000", header: "wz_revoked", expectedStatus: http.StatusUnauthorized},
+		{name: "Store Failure", target: "/weather/01001000", header: "wz_broken", expectedStatus: http.StatusInternalServerError},
+		{name: "Anonymous Allowed", target: "/weather/01001000", expectedStatus: http.StatusOK},
+		{name: "Anonymous Rejected", required: true, target: "/weather/01001000", expectedStatus: http.StatusUnauthorized},
+		{name: "Exempt Path", required: true, target: "/health", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tenant, query string
+			handler := Authenticate(AuthConfig{
+				Authenticate: testAuthenticator,
+				Required:     tt.required,
+				Exempt:       []string{"/health"},
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if principal, ok := PrincipalFromContext(r.Context()); ok {
+					tenant = principal.Tenant
+				}
+				query = r.URL.RawQuery
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rr := &ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.header != "" {
+				req.Header.Set(APIKeyHeader, tt.header)
+			}
+			handler.ServeHTTP(rr, req)
+
+			recorder := rr.ResponseWriter.(*httptest.ResponseRecorder)
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.Equal(t, tt.expectedTenant, tenant)
+			assert.Equal(t, tt.expectedTenant, rr.Tenant(), "Tenant should be recorded for logging")
+			if tt.expectedQuery != "" {
+				assert.Equal(t, tt.expectedQuery, query)
+			}
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, problem.ContentType, recorder.Header().Get("Content-Type"))
+				assert.NotEmpty(t, recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestPrincipalFromContextMissing(t *testing.T) {
+	_, ok := PrincipalFromContext(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+
+	assert.False(t, ok)
+}
+
+func testTokenValidator(ctx context.Context, token string) (domain.Principal, error) {
+	switch token {
+	case "valid-jwt":
+		return domain.Principal{ID: "service-a", Tenant: "internal", Scopes: []string{domain.ScopeAdmin}, Method: domain.AuthMethodJWT}, nil
+	case "jwks-down":
+		return domain.Principal{}, fmt.Errorf("%w: jwks unavailable", domain.ErrUpstreamUnavailable)
+	case "broken":
+		return domain.Principal{}, errors.New("unexpected failure")
+	default:
+		return domain.Principal{}, domain.ErrInvalidToken
+	}
+}
+
+func TestAuthenticateBearerToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		authorization  string
+		expectedStatus int
+		expectedTenant string
+		expectedAuth   string
+	}{
+		{name: "Valid Token", target: "/weather/01001000", authorization: "Bearer valid-jwt", expectedStatus: http.StatusOK, expectedTenant: "internal"},
+		{name: "Invalid Token", target: "/weather/01001000", authorization: "Bearer forged", expectedStatus: http.StatusUnauthorized, expectedAuth: `Bearer error="invalid_token"`},
+		{name: "Key Set Unavailable", target: "/weather/01001000", authorization: "Bearer jwks-down", expectedStatus: http.StatusServiceUnavailable},
+		{name: "Unexpected Failure", target: "/weather/01001000", authorization: "Bearer broken", expectedStatus: http.StatusInternalServerError},
+		{name: "Invalid Token On Exempt Path", target: "/routes", authorization: "Bearer admin-token", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tenant string
+			handler := Authenticate(AuthConfig{
+				Authenticate:  testAuthenticator,
+				ValidateToken: testTokenValidator,
+				Exempt:        []string{"/routes"},
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if principal, ok := PrincipalFromContext(r.Context()); ok {
+					tenant = principal.Tenant
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Authorization", tt.authorization)
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedTenant, tenant)
+			if tt.expectedAuth != "" {
+				assert.Equal(t, tt.expectedAuth, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	tests := []struct {
+		name           string
+		principal      *domain.Principal
+		expectedStatus int
+	}{
+		{name: "Anonymous", expectedStatus: http.StatusUnauthorized},
+		{name: "Missing Scope", principal: &domain.Principal{Scopes: []string{domain.ScopeHealthDetail}}, expectedStatus: http.StatusForbidden},
+		{name: "Granted", principal: &domain.Principal{Scopes: []string{domain.ScopeWeatherRead}}, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := RequireScope(domain.ScopeWeatherRead)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/weather/01001000", nil)
+			if tt.principal != nil {
+				req = req.WithContext(WithPrincipal(req.Context(), *tt.principal))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+			}
+			if tt.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, w.Header().Get("WWW-Authenticate"), "insufficient_scope")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBodyBytes(t *testing.T) {
+	handler := MaxBodyBytes(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		body           string
+		unknownLength  bool
+		expectedStatus int
+	}{
+		{"Within Limit", "12345678", false, http.StatusOK},
+		{"Declared Length Too Large", "123456789", false, http.StatusRequestEntityTooLarge},
+		{"Streamed Body Too Large", "123456789", true, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(tt.body))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+
+			rr := &ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.ResponseWriter.(*httptest.ResponseRecorder).Code)
+		})
+	}
+}
+
+func TestWriteErrorIgnoresPlainWriters(t *testing.T) {
+	assert.NotPanics(t, func() {
+		WriteError(httptest.NewRecorder(), "ignored")
+	})
+}
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/ratelimit"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+)
+
+type RateLimitConfig struct {
+	Store       ratelimit.Store
+	IP          ratelimit.Limit
+	APIKey      ratelimit.Limit
+	Routes      []ratelimit.RouteLimit
+	Exempt      []string
+	TrustedHops int
+}
+
+type rateLimitBucket func(r *http.Request, scope string, ipLimit, keyLimit ratelimit.Limit) (string, ratelimit.Limit, bool)
+
+func RateLimitIP(config RateLimitConfig) func(http.Handler) http.Handler {
+	return rateLimit(config, func(r *http.Request, scope string, ipLimit, _ ratelimit.Limit) (string, ratelimit.Limit, bool) {
+		return "ip:" + scope + ":" + ClientIP(r, config.TrustedHops), ipLimit, true
+	})
+}
+
+func RateLimitAPIKey(config RateLimitConfig) func(http.Handler) http.Handler {
+	return rateLimit(config, func(r *http.Request, scope string, _, keyLimit ratelimit.Limit) (string, ratelimit.Limit, bool) {
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			return "", keyLimit, false
+		}
+		if principal.Quota > 0 {
+			keyLimit = ratelimit.Limit{Requests: principal.Quota, Window: time.Minute}
+		}
+		return "key:" + scope + ":" + principal.ID, keyLimit, true
+	})
+}
+
+func rateLimit(config RateLimitConfig, bucket rateLimitBucket) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isExempt(r.URL.Path, config.Exempt) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope := "*"
+			ipLimit, keyLimit := config.IP, config.APIKey
+			if route, ok := matchRouteLimit(r.URL.Path, config.Routes); ok {
+				scope = route.Prefix
+				ipLimit, keyLimit = route.IP, route.APIKey
+			}
+
+			key, limit, ok := bucket(r, scope, ipLimit, keyLimit)
+			if !ok || limit.IsZero() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			decision, err := config.Store.Allow(r.Context(), key, limit)
+			if err != nil {
+				slog.Warn("rate limit store failed, allowing request", slog.String("error", err.Error()))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+			header.Set("RateLimit-Policy", limit.String())
+
+			if !decision.Allowed {
+				retryAfter := ceilSeconds(decision.RetryAfter)
+				header.Set("Retry-After", strconv.Itoa(retryAfter))
+				WriteError(w, "Rate limit exceeded")
+				problem.Write(w, problem.New(http.StatusTooManyRequests, fmt.Sprintf("rate limit exceeded, retry in %d seconds", retryAfter)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func ClientIP(r *http.Request, trustedHops int) string {
+	if trustedHops > 0 {
+		var hops []string
+		for _, header := range r.Header.Values("X-Forwarded-For") {
+			for _, hop := range strings.Split(header, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+		if len(hops) >= trustedHops {
+			if ip := hops[len(hops)-trustedHops]; ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func isExempt(path string, exempt []string) bool {
+	for _, prefix := range exempt {
+		if matchesPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchRouteLimit(path string, routes []ratelimit.RouteLimit) (ratelimit.RouteLimit, bool) {
+	var match ratelimit.RouteLimit
+	found := false
+
+	for _, route := range routes {
+		if matchesPrefix(path, route.Prefix) && len(route.Prefix) > len(match.Prefix) {
+			match = route
+			found = true
+		}
+	}
+
+	return match, found
+}
+
+func matchesPrefix(path, prefix string) bool {
+	if prefix == "/" {
+		return path == prefix
+	}
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+func ceilSeconds(d time.Duration) int {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
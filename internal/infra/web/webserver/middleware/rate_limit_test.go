@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/ratelimit"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStore struct{}
+
+func (failingStore) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store unavailable")
+}
+
+func rateLimitedHandler(config RateLimitConfig) http.Handler {
+	return RateLimitIP(config)(RateLimitAPIKey(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+}
+
+func performRateLimited(handler http.Handler, path, remoteAddr string, principal *domain.Principal) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	if principal != nil {
+		req = req.WithContext(WithPrincipal(req.Context(), *principal))
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitPerIP(t *testing.T) {
+	handler := rateLimitedHandler(RateLimitConfig{
+		Store: ratelimit.NewMemoryStore(),
+		IP:    ratelimit.Limit{Requests: 2, Window: time.Minute},
+	})
+
+	for i := 0; i < 2; i++ {
+		w := performRateLimited(handler, "/weather/01001000", "10.0.0.1:1234", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+	}
+
+	w := performRateLimited(handler, "/weather/01001000", "10.0.0.1:1234", nil)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	w = performRateLimited(handler, "/weather/01001000", "10.0.0.2:1234", nil)
+	assert.Equal(t, http.StatusOK, w.Code, "Other clients should have their own bucket")
+}
+
+func TestRateLimitPerAPIKeyAndRoute(t *testing.T) {
+	handler := rateLimitedHandler(RateLimitConfig{
+		Store:  ratelimit.NewMemoryStore(),
+		IP:     ratelimit.Limit{Requests: 100, Window: time.Minute},
+		APIKey: ratelimit.Limit{Requests: 100, Window: time.Minute},
+		Routes: []ratelimit.RouteLimit{
+			{Prefix: "/weather", IP: ratelimit.Limit{Requests: 3, Window: time.Minute}, APIKey: ratelimit.Limit{Requests: 2, Window: time.Minute}},
+		},
+	})
+	principal := &domain.Principal{ID: "key-1", Tenant: "partner"}
+
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/weather/1", "10.0.0.1:1", principal).Code)
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/weather/2", "10.0.0.1:1", principal).Code)
+	assert.Equal(t, http.StatusTooManyRequests, performRateLimited(handler, "/weather/3", "10.0.0.2:1", principal).Code, "Key bucket is shared across IPs")
+
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/weather/1", "10.0.0.1:1", nil).Code, "Authenticated requests also count against the IP bucket")
+	assert.Equal(t, http.StatusTooManyRequests, performRateLimited(handler, "/weather/1", "10.0.0.1:1", nil).Code)
+
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/subscriptions", "10.0.0.1:1", nil).Code, "Other routes use the default limit")
+}
+
+func TestRateLimitPrincipalQuota(t *testing.T) {
+	handler := rateLimitedHandler(RateLimitConfig{
+		Store:  ratelimit.NewMemoryStore(),
+		APIKey: ratelimit.Limit{Requests: 100, Window: time.Minute},
+	})
+	principal := &domain.Principal{ID: "key-1", Quota: 1}
+
+	w := performRateLimited(handler, "/weather/1", "10.0.0.1:1", principal)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Limit"), "Key quota should override the default limit")
+	assert.Equal(t, http.StatusTooManyRequests, performRateLimited(handler, "/weather/1", "10.0.0.1:1", principal).Code)
+}
+
+func TestRateLimitExemptAndFailOpen(t *testing.T) {
+	handler := rateLimitedHandler(RateLimitConfig{
+		Store:  ratelimit.NewMemoryStore(),
+		IP:     ratelimit.Limit{Requests: 1, Window: time.Minute},
+		Exempt: []string{"/health"},
+	})
+
+	for i := 0; i < 3; i++ {
+		w := performRateLimited(handler, "/health", "10.0.0.1:1", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+
+	handler = rateLimitedHandler(RateLimitConfig{
+		Store: failingStore{},
+		IP:    ratelimit.Limit{Requests: 1, Window: time.Minute},
+	})
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/weather/1", "10.0.0.1:1", nil).Code, "Store failures should not block traffic")
+
+	handler = rateLimitedHandler(RateLimitConfig{Store: ratelimit.NewMemoryStore()})
+	assert.Equal(t, http.StatusOK, performRateLimited(handler, "/weather/1", "10.0.0.1:1", nil).Code, "Zero limits disable limiting")
+}
+
+func TestRateLimitIPRunsBeforeAuthentication(t *testing.T) {
+	handler := RateLimitIP(RateLimitConfig{
+		Store: ratelimit.NewMemoryStore(),
+		IP:    ratelimit.Limit{Requests: 2, Window: time.Minute},
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+
+	codes := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/weather/1", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", fmt.Sprintf("invalid-%d", i))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests}, codes, "Invalid credentials should be limited per IP")
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.2")
+
+	assert.Equal(t, "10.0.0.1", ClientIP(req, 0))
+	assert.Equal(t, "10.0.0.2", ClientIP(req, 1))
+	assert.Equal(t, "203.0.113.7", ClientIP(req, 2))
+	assert.Equal(t, "10.0.0.1", ClientIP(req, 3), "Shorter chains should fall back to the peer address")
+
+	req.Header.Add("X-Forwarded-For", "198.51.100.4")
+	assert.Equal(t, "198.51.100.4", ClientIP(req, 1), "Repeated headers should be read as one chain")
+
+	req.RemoteAddr = "invalid"
+	req.Header.Del("X-Forwarded-For")
+	assert.Equal(t, "invalid", ClientIP(req, 1))
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	handler := rateLimitedHandler(RateLimitConfig{
+		Store:       ratelimit.NewMemoryStore(),
+		IP:          ratelimit.Limit{Requests: 2, Window: time.Minute},
+		TrustedHops: 1,
+	})
+
+	codes := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/weather/1", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-Forwarded-For", fmt.Sprintf("198.51.100.%d, 203.0.113.7", i))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes, "Client-supplied entries must not create new buckets")
+}
+
+func TestMatchesPrefix(t *testing.T) {
+	assert.True(t, matchesPrefix("/", "/"))
+	assert.False(t, matchesPrefix("/weather", "/"), "Root prefix should only match the root path")
+	assert.True(t, matchesPrefix("/health/live", "/health"))
+	assert.False(t, matchesPrefix("/healthz", "/health"))
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import "net/http"
+
+type ResponseRecorder struct {
+	http.ResponseWriter
+	statusCode   int
+	bytesWritten int
+	errorMessage string
+	tenant       string
+}
+
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+}
+
+func (rr *ResponseRecorder) WriteHeader(code int) {
+	rr.statusCode = code
+
+	if code != http.StatusOK && rr.bytesWritten == 0 {
+		rr.ResponseWriter.WriteHeader(code)
+	}
+}
+
+func (rr *ResponseRecorder) Write(data []byte) (int, error) {
+	size, err := rr.ResponseWriter.Write(data)
+	rr.bytesWritten += size
+	return size, err
+}
+
+func (rr *ResponseRecorder) Status() int {
+	return rr.statusCode
+}
+
+func (rr *ResponseRecorder) BytesWritten() int {
+	return rr.bytesWritten
+}
+
+func (rr *ResponseRecorder) ReadError() string {
+	return rr.errorMessage
+}
+
+func (rr *ResponseRecorder) WriteError(message string) {
+	rr.errorMessage = message
+	WriteError(rr.ResponseWriter, message)
+}
+
+func (rr *ResponseRecorder) SetTenant(tenant string) {
+	rr.tenant = tenant
+	SetTenant(rr.ResponseWriter, tenant)
+}
+
+func (rr *ResponseRecorder) Tenant() string {
+	return rr.tenant
+}
+
+type ErrorWriter interface {
+	WriteError(message string)
+}
+
+func WriteError(w http.ResponseWriter, message string) {
+	if ew, ok := w.(ErrorWriter); ok {
+		ew.WriteError(message)
+	}
+}
+
+type TenantWriter interface {
+	SetTenant(tenant string)
+}
+
+func SetTenant(w http.ResponseWriter, tenant string) {
+	if tw, ok := w.(TenantWriter); ok {
+		tw.SetTenant(tenant)
+	}
+}
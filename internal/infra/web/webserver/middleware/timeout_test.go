@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutPassesThroughFastHandlers(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		WriteError(w, "Zipcode not found")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"ok":false}`))
+	}))
+
+	rr := &ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	recorder := rr.ResponseWriter.(*httptest.ResponseRecorder)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `{"ok":false}`, recorder.Body.String())
+	assert.Equal(t, "Zipcode not found", rr.ReadError(), "Error messages should reach the recorder")
+}
+
+func TestTimeoutDefaultsToStatusOK(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTimeoutWritesProblemResponse(t *testing.T) {
+	finished := make(chan error, 1)
+	handler := Timeout(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		WriteError(w, "ignored")
+		_, err := w.Write([]byte("late"))
+		finished <- err
+	}))
+
+	rr := &ResponseRecorder{ResponseWriter: httptest.NewRecorder()}
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	recorder := rr.ResponseWriter.(*httptest.ResponseRecorder)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, problem.ContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "Request timeout", rr.ReadError())
+
+	var body problem.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusServiceUnavailable, body.Status)
+	assert.Equal(t, "request timed out after 20ms", body.Detail)
+
+	assert.ErrorIs(t, <-finished, http.ErrHandlerTimeout, "Writes after the timeout should fail")
+	assert.Equal(t, "Request timeout", rr.ReadError())
+}
+
+func TestTimeoutPropagatesPanics(t *testing.T) {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	assert.PanicsWithValue(t, "boom", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+	})
+}
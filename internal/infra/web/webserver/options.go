@@ -0,0 +1,43 @@
+package webserver
+
+import (
+	"log/slog"
+	"time"
+)
+
+type Option func(*WebServer)
+
+func WithTimeouts(readHeader, read, write, idle time.Duration) Option {
+	return func(s *WebServer) {
+		s.Server.ReadHeaderTimeout = readHeader
+		s.Server.ReadTimeout = read
+		s.Server.WriteTimeout = write
+		s.Server.IdleTimeout = idle
+	}
+}
+
+func WithMaxHeaderBytes(limit int) Option {
+	return func(s *WebServer) {
+		s.Server.MaxHeaderBytes = limit
+	}
+}
+
+func WithMaxBodyBytes(limit int64) Option {
+	return func(s *WebServer) {
+		s.MaxBodyBytes = limit
+	}
+}
+
+func WithShutdownTimeouts(preStopDelay, drainTimeout time.Duration) Option {
+	return func(s *WebServer) {
+		s.PreStopDelay = preStopDelay
+		s.DrainTimeout = drainTimeout
+	}
+}
+
+func WithLogger(logger *slog.Logger, trustedHops int) Option {
+	return func(s *WebServer) {
+		s.Logger = logger
+		s.TrustedHops = trustedHops
+	}
+}
@@ -0,0 +1,57 @@
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWebServerDefaults(t *testing.T) {
+	webServer := NewWebServer(testPort)
+
+	assert.Equal(t, DefaultReadHeaderTimeout, webServer.Server.ReadHeaderTimeout)
+	assert.Equal(t, DefaultReadTimeout, webServer.Server.ReadTimeout)
+	assert.Equal(t, DefaultWriteTimeout, webServer.Server.WriteTimeout)
+	assert.Equal(t, DefaultIdleTimeout, webServer.Server.IdleTimeout)
+	assert.Equal(t, DefaultMaxHeaderBytes, webServer.Server.MaxHeaderBytes)
+	assert.Equal(t, int64(DefaultMaxBodyBytes), webServer.MaxBodyBytes)
+	assert.Equal(t, DefaultDrainTimeout, webServer.DrainTimeout)
+}
+
+func TestNewWebServerOptions(t *testing.T) {
+	webServer := NewWebServer(testPort,
+		WithTimeouts(time.Second, 2*time.Second, 3*time.Second, 4*time.Second),
+		WithMaxHeaderBytes(4096),
+		WithMaxBodyBytes(512),
+		WithShutdownTimeouts(6*time.Second, 7*time.Second),
+	)
+
+	assert.Equal(t, time.Second, webServer.Server.ReadHeaderTimeout)
+	assert.Equal(t, 2*time.Second, webServer.Server.ReadTimeout)
+	assert.Equal(t, 3*time.Second, webServer.Server.WriteTimeout)
+	assert.Equal(t, 4*time.Second, webServer.Server.IdleTimeout)
+	assert.Equal(t, 4096, webServer.Server.MaxHeaderBytes)
+	assert.Equal(t, int64(512), webServer.MaxBodyBytes)
+	assert.Equal(t, 6*time.Second, webServer.PreStopDelay)
+	assert.Equal(t, 7*time.Second, webServer.DrainTimeout)
+}
+
+func TestWebServerMaxBodyBytes(t *testing.T) {
+	webServer := NewWebServer(testPort, WithMaxBodyBytes(4))
+	webServer.AddHandler(testEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}, "POST")
+	webServer.Start()
+
+	w := httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, testEndpoint, strings.NewReader("too large")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, testEndpoint, strings.NewReader("ok")))
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
@@ -0,0 +1,275 @@
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
+)
+
+type Middleware struct {
+	Name string
+	Wrap func(http.Handler) http.Handler
+}
+
+func NamedMiddleware(name string, wrap func(http.Handler) http.Handler) Middleware {
+	return Middleware{Name: name, Wrap: wrap}
+}
+
+type Route struct {
+	Method      string
+	Path        string
+	Handler     http.Handler
+	RouteName   string
+	Middlewares []Middleware
+	RouteTTL    time.Duration
+	group       *RouteGroup
+	mount       bool
+}
+
+func (r *Route) Name(name string) *Route {
+	r.RouteName = name
+	return r
+}
+
+func (r *Route) Use(middlewares ...Middleware) *Route {
+	r.Middlewares = append(r.Middlewares, middlewares...)
+	return r
+}
+
+func (r *Route) Timeout(timeout time.Duration) *Route {
+	r.RouteTTL = timeout
+	return r
+}
+
+type RouteGroup struct {
+	server      *WebServer
+	parent      *RouteGroup
+	prefix      string
+	middlewares []Middleware
+}
+
+func (g *RouteGroup) Use(middlewares ...Middleware) *RouteGroup {
+	g.middlewares = append(g.middlewares, middlewares...)
+	return g
+}
+
+func (g *RouteGroup) Group(prefix string, fn func(group *RouteGroup)) *RouteGroup {
+	group := &RouteGroup{
+		server: g.server,
+		parent: g,
+		prefix: joinPath(g.prefix, prefix),
+	}
+	if fn != nil {
+		fn(group)
+	}
+	return group
+}
+
+func (g *RouteGroup) Handle(method, path string, handler http.Handler) *Route {
+	return g.server.addRoute(&Route{
+		Method:  strings.ToUpper(method),
+		Path:    joinPath(g.prefix, path),
+		Handler: handler,
+		group:   g,
+	})
+}
+
+func (g *RouteGroup) HandleFunc(method, path string, handler http.HandlerFunc) *Route {
+	return g.Handle(method, path, handler)
+}
+
+func (g *RouteGroup) Get(path string, handler http.HandlerFunc) *Route {
+	return g.Handle(http.MethodGet, path, handler)
+}
+
+func (g *RouteGroup) Post(path string, handler http.HandlerFunc) *Route {
+	return g.Handle(http.MethodPost, path, handler)
+}
+
+func (g *RouteGroup) Put(path string, handler http.HandlerFunc) *Route {
+	return g.Handle(http.MethodPut, path, handler)
+}
+
+func (g *RouteGroup) Patch(path string, handler http.HandlerFunc) *Route {
+	return g.Handle(http.MethodPatch, path, handler)
+}
+
+func (g *RouteGroup) Delete(path string, handler http.HandlerFunc) *Route {
+	return g.Handle(http.MethodDelete, path, handler)
+}
+
+func (g *RouteGroup) Mount(prefix string, handler http.Handler) *Route {
+	return g.server.addRoute(&Route{
+		Method:  "*",
+		Path:    joinPath(g.prefix, prefix),
+		Handler: handler,
+		group:   g,
+		mount:   true,
+	})
+}
+
+func (g *RouteGroup) chain() []Middleware {
+	if g == nil {
+		return nil
+	}
+	return append(g.parent.chain(), g.middlewares...)
+}
+
+type RouteInfo struct {
+	Name        string   `json:"name,omitempty"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Middlewares []string `json:"middlewares"`
+}
+
+func (s *WebServer) Use(middlewares ...Middleware) {
+	s.Middlewares = append(s.Middlewares, middlewares...)
+}
+
+func (s *WebServer) Group(prefix string, fn func(group *RouteGroup)) *RouteGroup {
+	return s.root.Group(prefix, fn)
+}
+
+func (s *WebServer) Handle(method, path string, handler http.Handler) *Route {
+	return s.root.Handle(method, path, handler)
+}
+
+func (s *WebServer) Get(path string, handler http.HandlerFunc) *Route {
+	return s.root.Get(path, handler)
+}
+
+func (s *WebServer) Post(path string, handler http.HandlerFunc) *Route {
+	return s.root.Post(path, handler)
+}
+
+func (s *WebServer) Put(path string, handler http.HandlerFunc) *Route {
+	return s.root.Put(path, handler)
+}
+
+func (s *WebServer) Patch(path string, handler http.HandlerFunc) *Route {
+	return s.root.Patch(path, handler)
+}
+
+func (s *WebServer) Delete(path string, handler http.HandlerFunc) *Route {
+	return s.root.Delete(path, handler)
+}
+
+func (s *WebServer) Mount(prefix string, handler http.Handler) *Route {
+	return s.root.Mount(prefix, handler)
+}
+
+func (s *WebServer) RouteByName(name string) (*Route, bool) {
+	for _, route := range s.Routes {
+		if route.RouteName == name {
+			return route, true
+		}
+	}
+	return nil, false
+}
+
+func (s *WebServer) URLFor(name string, params map[string]string) (string, error) {
+	route, ok := s.RouteByName(name)
+	if !ok {
+		return "", fmt.Errorf("route %q not found", name)
+	}
+
+	path := route.Path
+	for key, value := range params {
+		path = strings.ReplaceAll(path, "{"+key+"}", value)
+	}
+
+	if strings.ContainsAny(path, "{}") {
+		return "", fmt.Errorf("missing parameters for route %q: %s", name, path)
+	}
+
+	return path, nil
+}
+
+func (s *WebServer) RouteInfos() []RouteInfo {
+	infos := make([]RouteInfo, 0, len(s.Routes))
+	for _, route := range s.Routes {
+		names := []string{}
+		for _, mw := range s.routeMiddlewares(route) {
+			names = append(names, mw.Name)
+		}
+
+		infos = append(infos, RouteInfo{
+			Name:        route.RouteName,
+			Method:      route.Method,
+			Path:        route.Path,
+			Middlewares: names,
+		})
+	}
+	return infos
+}
+
+func (s *WebServer) RoutesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s.RouteInfos()); err != nil {
+		problem.Write(w, problem.New(http.StatusInternalServerError, "internal server error"))
+	}
+}
+
+func (s *WebServer) addRoute(route *Route) *Route {
+	for i, existing := range s.Routes {
+		if existing.Method == route.Method && existing.Path == route.Path {
+			s.Routes[i] = route
+			return route
+		}
+	}
+
+	s.Routes = append(s.Routes, route)
+	return route
+}
+
+func (s *WebServer) globalMiddlewares() []Middleware {
+	middlewares := []Middleware{
+		NamedMiddleware("request_id", middleware.RequestID),
+		NamedMiddleware("access_log", middleware.AccessLog(middleware.AccessLogConfig{Logger: s.Logger, TrustedHops: s.TrustedHops})),
+	}
+	if s.MaxBodyBytes > 0 {
+		middlewares = append(middlewares, NamedMiddleware("max_body_bytes", middleware.MaxBodyBytes(s.MaxBodyBytes)))
+	}
+	return append(middlewares, s.Middlewares...)
+}
+
+func (s *WebServer) routeMiddlewares(route *Route) []Middleware {
+	middlewares := s.globalMiddlewares()
+	middlewares = append(middlewares, route.group.chain()...)
+	middlewares = append(middlewares, route.Middlewares...)
+
+	if route.RouteTTL > 0 {
+		middlewares = append(middlewares, NamedMiddleware("timeout", middleware.Timeout(route.RouteTTL)))
+	}
+
+	return middlewares
+}
+
+func (s *WebServer) routeHandler(route *Route) http.Handler {
+	local := s.routeMiddlewares(route)[len(s.globalMiddlewares()):]
+
+	handler := route.Handler
+	for i := len(local) - 1; i >= 0; i-- {
+		handler = local[i].Wrap(handler)
+	}
+	return handler
+}
+
+func joinPath(prefix, path string) string {
+	prefix = strings.TrimSuffix(prefix, "/")
+	if path == "" || path == "/" {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return prefix + path
+}
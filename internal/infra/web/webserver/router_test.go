@@ -0,0 +1,171 @@
+package webserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/problem"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func headerMiddleware(name, value string) Middleware {
+	return NamedMiddleware(name, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("X-Chain", value)
+			next.ServeHTTP(w, r)
+		})
+	})
+}
+
+func okHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestRouterPreservesRegistrationOrder(t *testing.T) {
+	webServer := NewWebServer(testPort)
+	webServer.Get("/b", okHandler)
+	webServer.Post("/a", okHandler)
+	webServer.Delete("/c", okHandler)
+
+	var paths []string
+	for _, route := range webServer.Routes {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+
+	assert.Equal(t, []string{"GET /b", "POST /a", "DELETE /c"}, paths)
+}
+
+func TestRouterGroupsAndMiddlewareOrder(t *testing.T) {
+	webServer := NewWebServer(testPort)
+	webServer.Use(headerMiddleware("server", "server"))
+	webServer.Group("/api", func(api *RouteGroup) {
+		api.Use(headerMiddleware("api", "api"))
+		api.Group("/v1", func(v1 *RouteGroup) {
+			v1.Use(headerMiddleware("v1", "v1"))
+			v1.Get("/items/{id}", okHandler).Use(headerMiddleware("route", "route"))
+			v1.Put("/", okHandler)
+		})
+	})
+	webServer.Start()
+
+	w := httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/items/1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"server", "api", "v1", "route"}, w.Header().Values("X-Chain"))
+
+	w = httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []string{"server", "api", "v1"}, w.Header().Values("X-Chain"))
+
+	w = httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, []string{"server"}, w.Header().Values("X-Chain"), "Server middleware should wrap unmatched requests")
+}
+
+func TestRouterMount(t *testing.T) {
+	subRouter := chi.NewRouter()
+	subRouter.Get("/ping", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("pong"))
+	})
+
+	webServer := NewWebServer(testPort)
+	webServer.Group("/internal", func(group *RouteGroup) {
+		group.Use(headerMiddleware("internal", "internal"))
+		group.Mount("/debug", subRouter).Name("debug")
+	})
+	webServer.Start()
+
+	w := httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/internal/debug/ping", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "pong", w.Body.String())
+	assert.Equal(t, []string{"internal"}, w.Header().Values("X-Chain"))
+}
+
+func TestRouterNamedRoutes(t *testing.T) {
+	webServer := NewWebServer(testPort)
+	webServer.Group("/subscriptions", func(group *RouteGroup) {
+		group.Get("/{id}", okHandler).Name("subscriptions.get")
+	})
+
+	route, ok := webServer.RouteByName("subscriptions.get")
+	require.True(t, ok)
+	assert.Equal(t, "/subscriptions/{id}", route.Path)
+
+	url, err := webServer.URLFor("subscriptions.get", map[string]string{"id": "sub-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/subscriptions/sub-1", url)
+
+	_, err = webServer.URLFor("subscriptions.get", nil)
+	assert.Error(t, err, "Missing parameters should fail")
+
+	_, err = webServer.URLFor("missing", nil)
+	assert.Error(t, err, "Unknown route should fail")
+}
+
+func TestRouterRouteTimeout(t *testing.T) {
+	webServer := NewWebServer(testPort)
+	webServer.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}).Timeout(20 * time.Millisecond)
+	webServer.Get("/fast", okHandler)
+	webServer.Start()
+
+	w := httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var body problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "Service Unavailable", body.Title)
+
+	w = httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusOK, w.Code, "Routes without a timeout should not be wrapped")
+}
+
+func TestRoutesHandler(t *testing.T) {
+	webServer := NewWebServer(testPort)
+	webServer.Use(headerMiddleware("custom", "custom"))
+	webServer.Get("/weather/{cep}", okHandler).Name("weather").Timeout(time.Second)
+	webServer.Group("", func(admin *RouteGroup) {
+		admin.Use(headerMiddleware("admin", "admin"))
+		admin.Get("/routes", webServer.RoutesHandler).Name("routes")
+	})
+	webServer.Start()
+
+	w := httptest.NewRecorder()
+	webServer.Router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/routes", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "application/json"))
+
+	var routes []RouteInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &routes))
+	assert.Equal(t, []RouteInfo{
+		{Name: "weather", Method: "GET", Path: "/weather/{cep}", Middlewares: []string{"request_id", "access_log", "max_body_bytes", "custom", "timeout"}},
+		{Name: "routes", Method: "GET", Path: "/routes", Middlewares: []string{"request_id", "access_log", "max_body_bytes", "custom", "admin"}},
+	}, routes)
+}
+
+func TestJoinPath(t *testing.T) {
+	assert.Equal(t, "/", joinPath("", ""))
+	assert.Equal(t, "/", joinPath("", "/"))
+	assert.Equal(t, "/api", joinPath("/api/", "/"))
+	assert.Equal(t, "/api/items", joinPath("/api", "items"))
+	assert.Equal(t, "/items", joinPath("", "/items"))
+}
@@ -1,44 +1,86 @@
 package webserver
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
-	"github.com/vs0uz4/weatherzip/internal/infra/web/webserver/middleware"
 	"github.com/vs0uz4/weatherzip/internal/service"
 
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultReadTimeout       = 15 * time.Second
+	DefaultWriteTimeout      = 30 * time.Second
+	DefaultIdleTimeout       = 60 * time.Second
+	DefaultMaxHeaderBytes    = 1 << 20
+	DefaultMaxBodyBytes      = 1 << 20
+	DefaultDrainTimeout      = 10 * time.Second
+)
+
+var ErrServerNotStarted = errors.New("server not started: call Start() before Run()")
+
+type ShutdownHook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
 type WebServer struct {
 	UptimeService service.UptimeService
 	WebServerPort string
 	Router        *chi.Mux
 	Server        *http.Server
-	Handlers      map[string]struct {
-		Handler http.HandlerFunc
-		Method  string
-	}
-	isStarted bool
+	Routes        []*Route
+	Middlewares   []Middleware
+	MaxBodyBytes  int64
+	Logger        *slog.Logger
+	TrustedHops   int
+	PreStopDelay  time.Duration
+	DrainTimeout  time.Duration
+	ShutdownHooks []ShutdownHook
+	root          *RouteGroup
+	isStarted     bool
+	draining      atomic.Bool
+	shutdownOnce  sync.Once
+	shutdownDone  chan struct{}
+	shutdownErr   error
 }
 
-func NewWebServer(port string) *WebServer {
+func NewWebServer(port string, opts ...Option) *WebServer {
 	router := chi.NewRouter()
 
 	server := &WebServer{
 		WebServerPort: port,
 		Router:        router,
 		Server: &http.Server{
-			Addr:    port,
-			Handler: router,
+			Addr:              port,
+			Handler:           router,
+			ReadHeaderTimeout: DefaultReadHeaderTimeout,
+			ReadTimeout:       DefaultReadTimeout,
+			WriteTimeout:      DefaultWriteTimeout,
+			IdleTimeout:       DefaultIdleTimeout,
+			MaxHeaderBytes:    DefaultMaxHeaderBytes,
 		},
-		Handlers: make(map[string]struct {
-			Handler http.HandlerFunc
-			Method  string
-		}),
-		isStarted: false,
+		MaxBodyBytes: DefaultMaxBodyBytes,
+		DrainTimeout: DefaultDrainTimeout,
+		isStarted:    false,
+		shutdownDone: make(chan struct{}),
 	}
+	server.root = &RouteGroup{server: server}
 	server.setupDependencies()
 
+	for _, opt := range opts {
+		opt(server)
+	}
+
 	return server
 }
 
@@ -47,49 +89,117 @@ func (s *WebServer) setupDependencies() {
 }
 
 func (s *WebServer) AddHandler(path string, handler http.HandlerFunc, method string) {
-	key := path + "_" + method
-	s.Handlers[key] = struct {
-		Handler http.HandlerFunc
-		Method  string
-	}{Handler: handler, Method: method}
+	s.Handle(method, path, handler)
+}
+
+func (s *WebServer) RegisterShutdownHook(name string, fn func(ctx context.Context) error) {
+	s.ShutdownHooks = append(s.ShutdownHooks, ShutdownHook{Name: name, Fn: fn})
+}
+
+func (s *WebServer) IsDraining() bool {
+	return s.draining.Load()
 }
 
 func (s *WebServer) Start() {
-	s.Router.Use(middleware.ErrorLogger)
-
-	for key, entry := range s.Handlers {
-		switch entry.Method {
-		case "GET":
-			s.Router.Get(key[:len(key)-len("_GET")], entry.Handler)
-		case "POST":
-			s.Router.Post(key[:len(key)-len("_POST")], entry.Handler)
-		case "PUT":
-			s.Router.Put(key[:len(key)-len("_PUT")], entry.Handler)
-		case "PATCH":
-			s.Router.Patch(key[:len(key)-len("_PATCH")], entry.Handler)
-		case "DELETE":
-			s.Router.Delete(key[:len(key)-len("_DELETE")], entry.Handler)
-		default:
-			s.Router.Method(entry.Method, key[:len(key)-len("_"+entry.Method)], entry.Handler)
+	for _, mw := range s.globalMiddlewares() {
+		s.Router.Use(mw.Wrap)
+	}
+
+	for _, route := range s.Routes {
+		handler := s.routeHandler(route)
+
+		if route.mount {
+			s.Router.Mount(route.Path, handler)
+			continue
 		}
+		s.Router.Method(route.Method, route.Path, handler)
 	}
 
 	s.isStarted = true
 }
 
-func (s *WebServer) Run() {
+func (s *WebServer) Run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	return s.RunContext(ctx)
+}
+
+func (s *WebServer) RunContext(ctx context.Context) error {
 	if !s.isStarted {
-		panic("server not started: call Start() before Run()")
+		return ErrServerNotStarted
 	}
 
-	if err := s.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		panic(err)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.Server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) || !s.IsDraining() {
+			return err
+		}
+		<-s.shutdownDone
+		return s.shutdownErr
+	case <-ctx.Done():
+		slog.Info("shutdown signal received, draining connections")
+		return s.Shutdown(context.Background())
 	}
 }
 
-func (s *WebServer) Stop() error {
+func (s *WebServer) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		defer close(s.shutdownDone)
+		s.shutdownErr = s.shutdown(ctx)
+	})
+
+	<-s.shutdownDone
+	return s.shutdownErr
+}
+
+func (s *WebServer) shutdown(ctx context.Context) error {
+	s.draining.Store(true)
+
+	if s.PreStopDelay > 0 {
+		timer := time.NewTimer(s.PreStopDelay)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+
+	var errs []error
 	if s.Server != nil {
-		return s.Server.Close()
+		drainCtx, cancel := context.WithTimeout(ctx, s.DrainTimeout)
+		err := s.Server.Shutdown(drainCtx)
+		cancel()
+
+		if err != nil {
+			slog.Warn("drain timeout exceeded, closing remaining connections", slog.String("error", err.Error()))
+			errs = append(errs, err, s.Server.Close())
+		}
+	}
+
+	for _, hook := range s.ShutdownHooks {
+		hookCtx, cancel := context.WithTimeout(ctx, s.DrainTimeout)
+		err := hook.Fn(hookCtx)
+		cancel()
+
+		if err != nil {
+			slog.Error("shutdown hook failed", slog.String("hook", hook.Name), slog.String("error", err.Error()))
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *WebServer) Stop() error {
+	if s.Server == nil {
+		return nil
 	}
-	return nil
+	return s.Shutdown(context.Background())
 }
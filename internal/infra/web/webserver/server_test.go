@@ -1,8 +1,10 @@
 package webserver
 
 import (
+	"context"
+	"errors"
 	"net/http"
-	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -35,7 +37,9 @@ func addHandlers(webServer *WebServer, routes []struct {
 func startServer(t *testing.T, webServer *WebServer) func() {
 	webServer.Start()
 
-	go webServer.Run()
+	go func() {
+		_ = webServer.Run()
+	}()
 	time.Sleep(500 * time.Millisecond)
 
 	return func() {
@@ -64,12 +68,9 @@ func TestAddHandler(t *testing.T) {
 
 	webServer.AddHandler(testEndpoint, handler, "GET")
 
-	key := testEndpoint + "_GET"
-
-	assert.Contains(t, webServer.Handlers, key)
-	assert.Len(t, webServer.Handlers, 1)
-	reflect.DeepEqual(handler, webServer.Handlers[key].Handler)
-	assert.Equal(t, "GET", webServer.Handlers[key].Method)
+	require.Len(t, webServer.Routes, 1)
+	assert.Equal(t, testEndpoint, webServer.Routes[0].Path)
+	assert.Equal(t, "GET", webServer.Routes[0].Method)
 }
 
 func TestWebServerLifecycle(t *testing.T) {
@@ -177,9 +178,8 @@ func TestAddHandlerWithDuplicateMethods(t *testing.T) {
 	webServer.AddHandler(testEndpoint, handler, "GET")
 	webServer.AddHandler(testEndpoint, handler, "GET")
 
-	key := testEndpoint + "_GET"
-	assert.Contains(t, webServer.Handlers, key)
-	assert.Len(t, webServer.Handlers, 1)
+	require.Len(t, webServer.Routes, 1, "Registering the same method and path twice should replace the route")
+	assert.Equal(t, testEndpoint, webServer.Routes[0].Path)
 }
 
 func TestInvalidMethods(t *testing.T) {
@@ -238,17 +238,20 @@ func TestWebServerErrorScenarios(t *testing.T) {
 			w.WriteHeader(http.StatusOK)
 		}
 		webServer.AddHandler(testEndpoint, handler, "GET")
-		assert.Panics(t, func() {
-			webServer.Start()
-			webServer.Run()
-		})
+		webServer.Start()
+
+		err := webServer.Run()
+
+		assert.Error(t, err, "Run should return listen errors instead of panicking")
 	})
 
 	t.Run("Run Without Start", func(t *testing.T) {
 		webServer := setupWebServer()
-		assert.PanicsWithValue(t, "server not started: call Start() before Run()", func() {
-			webServer.Run()
-		})
+
+		err := webServer.Run()
+
+		assert.ErrorIs(t, err, ErrServerNotStarted)
+		assert.EqualError(t, err, "server not started: call Start() before Run()")
 	})
 
 	t.Run("Run Start Without Error", func(t *testing.T) {
@@ -258,3 +261,125 @@ func TestWebServerErrorScenarios(t *testing.T) {
 		})
 	})
 }
+
+func TestWebServerGracefulShutdown(t *testing.T) {
+	t.Run("Drains In-Flight Requests", func(t *testing.T) {
+		webServer := setupWebServer()
+
+		started := make(chan struct{})
+		webServer.AddHandler(testEndpoint, func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			time.Sleep(300 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}, "GET")
+		webServer.Start()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- webServer.RunContext(ctx)
+		}()
+		time.Sleep(200 * time.Millisecond)
+
+		resCh := make(chan *http.Response, 1)
+		go func() {
+			res, err := performRequest(t, "GET", testEndpoint)
+			assert.NoError(t, err, "In-flight request should complete during shutdown")
+			resCh <- res
+		}()
+
+		<-started
+		cancel()
+
+		res := <-resCh
+		require.NotNil(t, res)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.NoError(t, <-runErr)
+		assert.True(t, webServer.IsDraining(), "Server should report draining after shutdown")
+	})
+
+	t.Run("Runs Pre-Stop Delay And Hooks In Order", func(t *testing.T) {
+		webServer := setupWebServer()
+		webServer.PreStopDelay = 100 * time.Millisecond
+		webServer.AddHandler(testEndpoint, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}, "GET")
+
+		var mu sync.Mutex
+		var order []string
+		var drainingDuringHooks bool
+		for _, name := range []string{"workers", "caches", "logs"} {
+			name := name
+			webServer.RegisterShutdownHook(name, func(ctx context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				order = append(order, name)
+				drainingDuringHooks = webServer.IsDraining()
+				return nil
+			})
+		}
+		webServer.Start()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		runErr := make(chan error, 1)
+		go func() {
+			runErr <- webServer.RunContext(ctx)
+		}()
+		time.Sleep(200 * time.Millisecond)
+
+		begin := time.Now()
+		cancel()
+
+		assert.NoError(t, <-runErr)
+		assert.GreaterOrEqual(t, time.Since(begin), webServer.PreStopDelay, "Shutdown should wait for the pre-stop delay")
+		assert.Equal(t, []string{"workers", "caches", "logs"}, order)
+		assert.True(t, drainingDuringHooks)
+	})
+
+	t.Run("Returns Hook Errors", func(t *testing.T) {
+		webServer := setupWebServer()
+		webServer.RegisterShutdownHook("failing", func(ctx context.Context) error {
+			return errors.New("mock hook error")
+		})
+
+		var called bool
+		webServer.RegisterShutdownHook("after failing", func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		err := webServer.Stop()
+
+		assert.EqualError(t, err, "mock hook error")
+		assert.True(t, called, "Hooks after a failing hook should still run")
+		assert.EqualError(t, webServer.Stop(), "mock hook error", "Shutdown should only run once")
+	})
+
+	t.Run("Closes Connections After Drain Timeout", func(t *testing.T) {
+		webServer := setupWebServer()
+		webServer.DrainTimeout = 100 * time.Millisecond
+
+		release := make(chan struct{})
+		started := make(chan struct{})
+		webServer.AddHandler(testEndpoint, func(w http.ResponseWriter, r *http.Request) {
+			close(started)
+			<-release
+		}, "GET")
+		webServer.Start()
+
+		go func() {
+			_ = webServer.Run()
+		}()
+		time.Sleep(200 * time.Millisecond)
+
+		go func() {
+			_, _ = performRequest(t, "GET", testEndpoint)
+		}()
+		<-started
+
+		err := webServer.Stop()
+		close(release)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,19 +17,19 @@ type CepService struct {
 	BaseURL    string
 }
 
-func NewCepService(client *http.Client, baseURL string) *CepService {
+func NewCepService(client contracts.HttpClient, baseURL string) *CepService {
 	return &CepService{
 		HttpClient: client,
 		BaseURL:    baseURL,
 	}
 }
 
-func (s *CepService) GetLocation(cep string) (domain.CepResponse, error) {
+func (s *CepService) GetLocation(ctx context.Context, cep string) (domain.CepResponse, error) {
 	var response domain.CepResponse
 	var raw map[string]interface{}
 
 	url := fmt.Sprintf(s.BaseURL, cep)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return response, fmt.Errorf("failed to create request: %w", err)
 	}
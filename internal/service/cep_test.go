@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -33,7 +34,7 @@ func TestCepServiceCreateRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &CepService{}
-			_, err := s.GetLocation(tt.inputURL)
+			_, err := s.GetLocation(context.Background(), tt.inputURL)
 
 			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
 				t.Errorf("Expected error containing %q, got %v", tt.expectErr, err)
@@ -66,7 +67,7 @@ func TestCepServiceExecuteRequest(t *testing.T) {
 				BaseURL:    cepServiceBaseURL,
 			}
 
-			_, err := service.GetLocation("12345678")
+			_, err := service.GetLocation(context.Background(), "12345678")
 
 			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
 				t.Errorf("Expected error containing %q, got %q", tt.expectErr, err.Error())
@@ -103,7 +104,7 @@ func TestCepServiceUnexpectedStatusCode(t *testing.T) {
 				HttpClient: mockClient,
 				BaseURL:    cepServiceBaseURL,
 			}
-			_, err := service.GetLocation("12345678")
+			_, err := service.GetLocation(context.Background(), "12345678")
 
 			if err == nil || err.Error() != tt.expectErr.Error() {
 				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
@@ -152,7 +153,7 @@ func TestCepServiceDecodeResponseError(t *testing.T) {
 		BaseURL:    cepServiceBaseURL,
 	}
 
-	_, err := service.GetLocation("12345678")
+	_, err := service.GetLocation(context.Background(), "12345678")
 
 	if err == nil || !strings.Contains(err.Error(), "failed to decode response") {
 		t.Errorf("Expected error containing %q, got %q", "failed to decode response", err.Error())
@@ -173,7 +174,7 @@ func TestCepServicePopulateMapError(t *testing.T) {
 		HttpClient: mockClient,
 		BaseURL:    cepServiceBaseURL,
 	}
-	_, err := service.GetLocation("12345678")
+	_, err := service.GetLocation(context.Background(), "12345678")
 
 	expectedError := "failed to map response"
 	if err == nil || !strings.Contains(err.Error(), expectedError) {
@@ -227,7 +228,7 @@ func TestCepServiceGetCepData(t *testing.T) {
 			defer mockServer.Close()
 
 			cepService := NewCepService(mockServer.Client(), mockServer.URL+"/%s")
-			result, err := cepService.GetLocation(tt.inputCep)
+			result, err := cepService.GetLocation(context.Background(), tt.inputCep)
 
 			if !errors.Is(err, tt.expectErr) {
 				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
@@ -0,0 +1,11 @@
+package contracts
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type APIKeyRepository interface {
+	Save(key domain.APIKey) error
+	Update(key domain.APIKey) error
+	FindAll() ([]domain.APIKey, error)
+	FindByID(id string) (domain.APIKey, error)
+	FindByHash(hash string) (domain.APIKey, error)
+}
@@ -0,0 +1,13 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+)
+
+type Checker interface {
+	Name() string
+	ComponentType() string
+	Check(ctx context.Context) health.CheckResult
+}
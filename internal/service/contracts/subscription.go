@@ -0,0 +1,16 @@
+package contracts
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type SubscriptionRepository interface {
+	Save(subscription domain.Subscription) error
+	UpdateAll(subscriptions []domain.Subscription) error
+	FindAll() ([]domain.Subscription, error)
+	FindByID(id string) (domain.Subscription, error)
+	Delete(id string) error
+}
+
+type DeadLetterRepository interface {
+	Add(letter domain.DeadLetter) error
+	FindAll() ([]domain.DeadLetter, error)
+}
@@ -0,0 +1,13 @@
+package contracts
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type KeyPool interface {
+	Acquire() (string, error)
+	Report(key string, err error)
+}
+
+type UpstreamKeyUsageRepository interface {
+	Save(usage domain.UpstreamKeyUsage) error
+	FindAll() ([]domain.UpstreamKeyUsage, error)
+}
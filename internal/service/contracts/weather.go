@@ -1,7 +1,11 @@
 package contracts
 
-import "github.com/vs0uz4/weatherzip/internal/domain"
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
 
 type WeatherService interface {
-	GetWeather(location string) (domain.WeatherResponse, error)
+	GetWeather(ctx context.Context, location string) (domain.WeatherResponse, error)
 }
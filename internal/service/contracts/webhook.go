@@ -0,0 +1,11 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
+
+type WebhookService interface {
+	Deliver(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error)
+}
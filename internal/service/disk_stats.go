@@ -0,0 +1,34 @@
+package service
+
+import (
+	"math"
+
+	"github.com/shirou/gopsutil/disk"
+)
+
+type DiskService interface {
+	GetDiskStats(path string) (uint64, uint64, uint64, float64, error)
+}
+
+type diskService struct {
+	usageFunc func(path string) (*disk.UsageStat, error)
+}
+
+func NewDiskService() DiskService {
+	return &diskService{
+		usageFunc: disk.Usage,
+	}
+}
+
+func (s *diskService) roundToOneDecimal(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+func (s *diskService) GetDiskStats(path string) (uint64, uint64, uint64, float64, error) {
+	usage, err := s.usageFunc(path)
+	if err != nil {
+		return 0, 0, 0, 0, err
+	}
+	percentUsed := s.roundToOneDecimal(usage.UsedPercent)
+	return usage.Total, usage.Used, usage.Free, percentUsed, nil
+}
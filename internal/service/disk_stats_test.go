@@ -0,0 +1,50 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/disk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDiskStats(t *testing.T) {
+	diskService := NewDiskService()
+
+	total, _, _, percentUsed, err := diskService.GetDiskStats("/")
+
+	assert.NoError(t, err, "Getting disk stats should not produce an error")
+	assert.Greater(t, total, uint64(0), "Total should be greater than 0")
+	assert.GreaterOrEqual(t, percentUsed, 0.0, "Usage percentage should be >= 0")
+	assert.LessOrEqual(t, percentUsed, 100.0, "Usage percentage should be <= 100")
+}
+
+func TestGetDiskStatsRoundsPercent(t *testing.T) {
+	mockDiskService := &diskService{
+		usageFunc: func(path string) (*disk.UsageStat, error) {
+			return &disk.UsageStat{Total: 100, Used: 42, Free: 58, UsedPercent: 42.04}, nil
+		},
+	}
+
+	total, used, free, percentUsed, err := mockDiskService.GetDiskStats("/data")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), total)
+	assert.Equal(t, uint64(42), used)
+	assert.Equal(t, uint64(58), free)
+	assert.Equal(t, 42.0, percentUsed)
+}
+
+func TestGetDiskStatsError(t *testing.T) {
+	mockDiskService := &diskService{
+		usageFunc: func(path string) (*disk.UsageStat, error) {
+			return nil, errors.New("mock error")
+		},
+	}
+
+	total, _, _, percentUsed, err := mockDiskService.GetDiskStats("/")
+
+	assert.Error(t, err, "An error should occur")
+	assert.Zero(t, total)
+	assert.Zero(t, percentUsed)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type MockAPIKeyRepository struct {
+	SaveFunc       func(key domain.APIKey) error
+	UpdateFunc     func(key domain.APIKey) error
+	FindAllFunc    func() ([]domain.APIKey, error)
+	FindByIDFunc   func(id string) (domain.APIKey, error)
+	FindByHashFunc func(hash string) (domain.APIKey, error)
+}
+
+func (m *MockAPIKeyRepository) Save(key domain.APIKey) error {
+	return m.SaveFunc(key)
+}
+
+func (m *MockAPIKeyRepository) Update(key domain.APIKey) error {
+	return m.UpdateFunc(key)
+}
+
+func (m *MockAPIKeyRepository) FindAll() ([]domain.APIKey, error) {
+	return m.FindAllFunc()
+}
+
+func (m *MockAPIKeyRepository) FindByID(id string) (domain.APIKey, error) {
+	return m.FindByIDFunc(id)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(hash string) (domain.APIKey, error) {
+	return m.FindByHashFunc(hash)
+}
@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockAPIKeyRepository(t *testing.T) {
+	mock := &MockAPIKeyRepository{
+		SaveFunc: func(key domain.APIKey) error {
+			return nil
+		},
+		UpdateFunc: func(key domain.APIKey) error {
+			return domain.ErrAPIKeyNotFound
+		},
+		FindAllFunc: func() ([]domain.APIKey, error) {
+			return []domain.APIKey{{ID: "key-1"}}, nil
+		},
+		FindByIDFunc: func(id string) (domain.APIKey, error) {
+			return domain.APIKey{ID: id}, nil
+		},
+		FindByHashFunc: func(hash string) (domain.APIKey, error) {
+			return domain.APIKey{Hash: hash}, nil
+		},
+	}
+
+	assert.NoError(t, mock.Save(domain.APIKey{}), "Expected no error from mock")
+	assert.ErrorIs(t, mock.Update(domain.APIKey{}), domain.ErrAPIKeyNotFound, "Expected error from mock")
+
+	all, err := mock.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1, "Expected keys to match mock value")
+
+	found, err := mock.FindByID("key-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "key-2", found.ID, "Expected id to match mock value")
+
+	found, err = mock.FindByHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "hash", found.Hash, "Expected hash to match mock value")
+}
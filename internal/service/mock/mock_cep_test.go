@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"testing"
 
 	"github.com/vs0uz4/weatherzip/internal/domain"
@@ -8,7 +9,7 @@ import (
 
 func TestMockCepService(t *testing.T) {
 	mock := MockCepService{
-		GetLocationFunc: func(cep string) (domain.CepResponse, error) {
+		GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
 			if cep == "12345678" {
 				return domain.CepResponse{Cep: "12345678"}, nil
 			}
@@ -16,12 +17,12 @@ func TestMockCepService(t *testing.T) {
 		},
 	}
 
-	response, err := mock.GetLocation("12345678")
+	response, err := mock.GetLocation(context.Background(), "12345678")
 	if response.Cep != "12345678" || err != nil {
 		t.Errorf("Expected Cep: 12345678, got: %v, err: %v", response.Cep, err)
 	}
 
-	_, err = mock.GetLocation("00000000")
+	_, err = mock.GetLocation(context.Background(), "00000000")
 	if err != domain.ErrZipcodeNotFound {
 		t.Errorf("Expected error: %v, got: %v", domain.ErrZipcodeNotFound, err)
 	}
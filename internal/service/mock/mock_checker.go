@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+)
+
+type MockChecker struct {
+	NameFunc          func() string
+	ComponentTypeFunc func() string
+	CheckFunc         func(ctx context.Context) health.CheckResult
+}
+
+func (m *MockChecker) Name() string {
+	return m.NameFunc()
+}
+
+func (m *MockChecker) ComponentType() string {
+	return m.ComponentTypeFunc()
+}
+
+func (m *MockChecker) Check(ctx context.Context) health.CheckResult {
+	return m.CheckFunc(ctx)
+}
@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockChecker(t *testing.T) {
+	mock := &MockChecker{
+		NameFunc:          func() string { return "queue:depth" },
+		ComponentTypeFunc: func() string { return "component" },
+		CheckFunc: func(ctx context.Context) health.CheckResult {
+			return health.CheckResult{Status: health.StatusWarn, ObservedValue: 42}
+		},
+	}
+
+	assert.Equal(t, "queue:depth", mock.Name())
+	assert.Equal(t, "component", mock.ComponentType())
+	assert.Equal(t, health.CheckResult{Status: health.StatusWarn, ObservedValue: 42}, mock.Check(context.Background()))
+}
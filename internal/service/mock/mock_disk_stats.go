@@ -0,0 +1,9 @@
+package mock
+
+type MockDiskService struct {
+	GetDiskStatsFunc func(path string) (uint64, uint64, uint64, float64, error)
+}
+
+func (m *MockDiskService) GetDiskStats(path string) (uint64, uint64, uint64, float64, error) {
+	return m.GetDiskStatsFunc(path)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockDiskService(t *testing.T) {
+	mock := &MockDiskService{
+		GetDiskStatsFunc: func(path string) (uint64, uint64, uint64, float64, error) {
+			return 100, 40, 60, 40, nil
+		},
+	}
+
+	total, used, free, percentUsed, err := mock.GetDiskStats("/")
+
+	assert.NoError(t, err, "Expected no error from mock")
+	assert.Equal(t, uint64(100), total)
+	assert.Equal(t, uint64(40), used)
+	assert.Equal(t, uint64(60), free)
+	assert.Equal(t, 40.0, percentUsed)
+
+	mock.GetDiskStatsFunc = func(path string) (uint64, uint64, uint64, float64, error) {
+		return 0, 0, 0, 0, errors.New("mock error")
+	}
+
+	_, _, _, _, err = mock.GetDiskStats("/")
+	assert.Error(t, err, "Expected error from mock")
+}
@@ -0,0 +1,44 @@
+package mock
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type MockSubscriptionRepository struct {
+	SaveFunc      func(subscription domain.Subscription) error
+	UpdateAllFunc func(subscriptions []domain.Subscription) error
+	FindAllFunc   func() ([]domain.Subscription, error)
+	FindByIDFunc  func(id string) (domain.Subscription, error)
+	DeleteFunc    func(id string) error
+}
+
+func (m *MockSubscriptionRepository) Save(subscription domain.Subscription) error {
+	return m.SaveFunc(subscription)
+}
+
+func (m *MockSubscriptionRepository) UpdateAll(subscriptions []domain.Subscription) error {
+	return m.UpdateAllFunc(subscriptions)
+}
+
+func (m *MockSubscriptionRepository) FindAll() ([]domain.Subscription, error) {
+	return m.FindAllFunc()
+}
+
+func (m *MockSubscriptionRepository) FindByID(id string) (domain.Subscription, error) {
+	return m.FindByIDFunc(id)
+}
+
+func (m *MockSubscriptionRepository) Delete(id string) error {
+	return m.DeleteFunc(id)
+}
+
+type MockDeadLetterRepository struct {
+	AddFunc     func(letter domain.DeadLetter) error
+	FindAllFunc func() ([]domain.DeadLetter, error)
+}
+
+func (m *MockDeadLetterRepository) Add(letter domain.DeadLetter) error {
+	return m.AddFunc(letter)
+}
+
+func (m *MockDeadLetterRepository) FindAll() ([]domain.DeadLetter, error) {
+	return m.FindAllFunc()
+}
@@ -0,0 +1,60 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockSubscriptionRepository(t *testing.T) {
+	mock := &MockSubscriptionRepository{
+		SaveFunc: func(subscription domain.Subscription) error {
+			return nil
+		},
+		UpdateAllFunc: func(subscriptions []domain.Subscription) error {
+			return domain.ErrSubscriptionNotFound
+		},
+		FindAllFunc: func() ([]domain.Subscription, error) {
+			return []domain.Subscription{{ID: "sub-1"}}, nil
+		},
+		FindByIDFunc: func(id string) (domain.Subscription, error) {
+			return domain.Subscription{ID: id}, nil
+		},
+		DeleteFunc: func(id string) error {
+			return domain.ErrSubscriptionNotFound
+		},
+	}
+
+	assert.NoError(t, mock.Save(domain.Subscription{}), "Expected no error from mock")
+	assert.ErrorIs(t, mock.UpdateAll([]domain.Subscription{{}}), domain.ErrSubscriptionNotFound, "Expected error from mock")
+
+	all, err := mock.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1, "Expected subscriptions to match mock value")
+
+	found, err := mock.FindByID("sub-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "sub-2", found.ID, "Expected id to match mock value")
+
+	assert.ErrorIs(t, mock.Delete("sub-3"), domain.ErrSubscriptionNotFound, "Expected error from mock")
+}
+
+func TestMockDeadLetterRepository(t *testing.T) {
+	mock := &MockDeadLetterRepository{
+		AddFunc: func(letter domain.DeadLetter) error {
+			return errors.New("mock error")
+		},
+		FindAllFunc: func() ([]domain.DeadLetter, error) {
+			return []domain.DeadLetter{{ID: "dl-1"}}, nil
+		},
+	}
+
+	assert.Error(t, mock.Add(domain.DeadLetter{}), "Expected error from mock")
+
+	letters, err := mock.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, letters, 1, "Expected dead letters to match mock value")
+}
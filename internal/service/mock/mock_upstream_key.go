@@ -0,0 +1,29 @@
+package mock
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type MockKeyPool struct {
+	AcquireFunc func() (string, error)
+	ReportFunc  func(key string, err error)
+}
+
+func (m *MockKeyPool) Acquire() (string, error) {
+	return m.AcquireFunc()
+}
+
+func (m *MockKeyPool) Report(key string, err error) {
+	m.ReportFunc(key, err)
+}
+
+type MockUpstreamKeyUsageRepository struct {
+	SaveFunc    func(usage domain.UpstreamKeyUsage) error
+	FindAllFunc func() ([]domain.UpstreamKeyUsage, error)
+}
+
+func (m *MockUpstreamKeyUsageRepository) Save(usage domain.UpstreamKeyUsage) error {
+	return m.SaveFunc(usage)
+}
+
+func (m *MockUpstreamKeyUsageRepository) FindAll() ([]domain.UpstreamKeyUsage, error) {
+	return m.FindAllFunc()
+}
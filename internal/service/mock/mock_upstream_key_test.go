@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockKeyPool(t *testing.T) {
+	var reported error
+	mock := &MockKeyPool{
+		AcquireFunc: func() (string, error) {
+			return "key-1", nil
+		},
+		ReportFunc: func(key string, err error) {
+			reported = err
+		},
+	}
+
+	key, err := mock.Acquire()
+	assert.NoError(t, err)
+	assert.Equal(t, "key-1", key, "Expected key to match mock value")
+
+	mock.Report(key, domain.ErrUpstreamQuotaExceeded)
+	assert.ErrorIs(t, reported, domain.ErrUpstreamQuotaExceeded, "Expected reported error to reach mock")
+}
+
+func TestMockUpstreamKeyUsageRepository(t *testing.T) {
+	mock := &MockUpstreamKeyUsageRepository{
+		SaveFunc: func(usage domain.UpstreamKeyUsage) error {
+			return errors.New("mock save error")
+		},
+		FindAllFunc: func() ([]domain.UpstreamKeyUsage, error) {
+			return []domain.UpstreamKeyUsage{{ID: "key-1"}}, nil
+		},
+	}
+
+	assert.Error(t, mock.Save(domain.UpstreamKeyUsage{}), "Expected error from mock")
+
+	all, err := mock.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1, "Expected usage to match mock value")
+}
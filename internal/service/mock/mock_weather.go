@@ -1,11 +1,15 @@
 package mock
 
-import "github.com/vs0uz4/weatherzip/internal/domain"
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
 
 type MockWeatherService struct {
-	GetWeatherFunc func(string) (domain.WeatherResponse, error)
+	GetWeatherFunc func(context.Context, string) (domain.WeatherResponse, error)
 }
 
-func (m *MockWeatherService) GetWeather(location string) (domain.WeatherResponse, error) {
-	return m.GetWeatherFunc(location)
+func (m *MockWeatherService) GetWeather(ctx context.Context, location string) (domain.WeatherResponse, error) {
+	return m.GetWeatherFunc(ctx, location)
 }
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"testing"
 
 	"github.com/vs0uz4/weatherzip/internal/domain"
@@ -8,7 +9,7 @@ import (
 
 func TestMockWeatherService(t *testing.T) {
 	mock := MockWeatherService{
-		GetWeatherFunc: func(location string) (domain.WeatherResponse, error) {
+		GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
 			if location == "Valid Location" {
 				return domain.WeatherResponse{Current: domain.CurrentWeather{TempC: 25.0}}, nil
 			}
@@ -16,12 +17,12 @@ func TestMockWeatherService(t *testing.T) {
 		},
 	}
 
-	response, err := mock.GetWeather("Valid Location")
+	response, err := mock.GetWeather(context.Background(), "Valid Location")
 	if response.Current.TempC != 25.0 || err != nil {
 		t.Errorf("Expected TempC: 25.0, got: %v, err: %v", response.Current.TempC, err)
 	}
 
-	_, err = mock.GetWeather("Invalid Location")
+	_, err = mock.GetWeather(context.Background(), "Invalid Location")
 	if err != domain.ErrUnexpectedBadRequest {
 		t.Errorf("Expected error: %v, got: %v", domain.ErrUnexpectedBadRequest, err)
 	}
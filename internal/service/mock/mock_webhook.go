@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
+
+type MockWebhookService struct {
+	DeliverFunc func(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error)
+}
+
+func (m *MockWebhookService) Deliver(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error) {
+	return m.DeliverFunc(ctx, callbackURL, secret, event)
+}
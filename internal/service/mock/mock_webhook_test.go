@@ -0,0 +1,31 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockWebhookService(t *testing.T) {
+	mock := &MockWebhookService{
+		DeliverFunc: func(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error) {
+			return 1, nil
+		},
+	}
+
+	attempts, err := mock.Deliver(context.Background(), "http://example.com", "secret", domain.WebhookEvent{})
+
+	assert.NoError(t, err, "Expected no error from mock")
+	assert.Equal(t, 1, attempts, "Expected attempts to match mock value")
+
+	mock.DeliverFunc = func(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error) {
+		return 3, errors.New("mock error")
+	}
+
+	_, err = mock.Deliver(context.Background(), "http://example.com", "secret", domain.WebhookEvent{})
+	assert.Error(t, err, "Expected error from mock")
+}
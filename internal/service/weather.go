@@ -1,8 +1,11 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -15,7 +18,12 @@ var _ contracts.WeatherService = (*WeatherService)(nil)
 var weatherErrorCodes = map[int]error{
 	1003: domain.ErrParameterNotProvided,
 	1005: domain.ErrApiUrlIsInvalid,
+	1002: domain.ErrUpstreamKeyRejected,
 	1006: domain.ErrLocationNotFound,
+	2006: domain.ErrUpstreamKeyRejected,
+	2007: domain.ErrUpstreamQuotaExceeded,
+	2008: domain.ErrUpstreamKeyRejected,
+	2009: domain.ErrUpstreamKeyRejected,
 	9000: domain.ErrJsonBodyIsInvalid,
 	9001: domain.ErrTooManyLocations,
 	9999: domain.ErrInternalApplication,
@@ -26,9 +34,10 @@ type WeatherService struct {
 	BaseURL    string
 	ApiKey     string
 	Language   string
+	Keys       contracts.KeyPool
 }
 
-func NewWeatherService(client *http.Client, baseURL, apiKey, language string) *WeatherService {
+func NewWeatherService(client contracts.HttpClient, baseURL, apiKey, language string) *WeatherService {
 	return &WeatherService{
 		HttpClient: client,
 		BaseURL:    baseURL,
@@ -37,37 +46,55 @@ func NewWeatherService(client *http.Client, baseURL, apiKey, language string) *W
 	}
 }
 
-func (s *WeatherService) GetWeather(location string) (domain.WeatherResponse, error) {
+func (s *WeatherService) GetWeather(ctx context.Context, location string) (domain.WeatherResponse, error) {
+	if s.Keys == nil {
+		return s.getWeather(ctx, location, s.ApiKey)
+	}
+
+	for {
+		key, err := s.Keys.Acquire()
+		if err != nil {
+			return domain.WeatherResponse{}, err
+		}
+
+		response, err := s.getWeather(ctx, location, key)
+		if errors.Is(err, domain.ErrUpstreamQuotaExceeded) || errors.Is(err, domain.ErrUpstreamKeyRejected) {
+			s.Keys.Report(key, err)
+			continue
+		}
+		return response, err
+	}
+}
+
+func (s *WeatherService) getWeather(ctx context.Context, location, apiKey string) (domain.WeatherResponse, error) {
 	var response domain.WeatherResponse
 
 	encodedLocation := url.QueryEscape(location)
-	url := fmt.Sprintf(s.BaseURL, s.ApiKey, encodedLocation, s.Language)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	url := fmt.Sprintf(s.BaseURL, apiKey, encodedLocation, s.Language)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return response, domain.NewFailedToCreateRequestError(err)
+		return response, domain.NewFailedToCreateRequestError(domain.RedactURLError(err))
 	}
 
 	res, err := s.HttpClient.Do(req)
 	if err != nil {
-		return response, domain.NewFailedToMakeRequestError(err)
+		return response, domain.NewFailedToMakeRequestError(domain.RedactURLError(err))
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == http.StatusBadRequest {
-		var errorResponse struct {
-			Error struct {
-				Code int `json:"code"`
-			} `json:"error"`
-		}
-		if err := json.NewDecoder(res.Body).Decode(&errorResponse); err == nil {
-			if apiErr, exists := weatherErrorCodes[errorResponse.Error.Code]; exists {
-				return response, apiErr
-			}
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusBadRequest:
+		if apiErr := decodeWeatherError(res.Body); apiErr != nil {
+			return response, apiErr
 		}
 		return response, domain.ErrUnexpectedBadRequest
-	}
-
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusBadRequest {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if apiErr := decodeWeatherError(res.Body); apiErr != nil {
+			return response, apiErr
+		}
+		fallthrough
+	default:
 		return response, domain.NewUnexpectedStatusCodeError(res.StatusCode)
 	}
 
@@ -79,3 +106,15 @@ func (s *WeatherService) GetWeather(location string) (domain.WeatherResponse, er
 
 	return response, nil
 }
+
+func decodeWeatherError(body io.Reader) error {
+	var errorResponse struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(body).Decode(&errorResponse); err != nil {
+		return nil
+	}
+	return weatherErrorCodes[errorResponse.Error.Code]
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -37,7 +38,7 @@ func TestWeatherServiceCreateRequest(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &WeatherService{}
-			_, err := s.GetWeather(tt.inputURL)
+			_, err := s.GetWeather(context.Background(), tt.inputURL)
 
 			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
 				t.Errorf("Expected error containing %q, got %v", tt.expectErr, err)
@@ -72,7 +73,7 @@ func TestWeatherServiceExecuteRequest(t *testing.T) {
 				Language:   weatherServiceLanguage,
 			}
 
-			_, err := service.GetWeather("valid-location")
+			_, err := service.GetWeather(context.Background(), "valid-location")
 
 			if err == nil || !strings.Contains(err.Error(), tt.expectErr) {
 				t.Errorf("Expected error containing %q, got %q", tt.expectErr, err.Error())
@@ -81,6 +82,28 @@ func TestWeatherServiceExecuteRequest(t *testing.T) {
 	}
 }
 
+func TestWeatherServiceRedactsKeyFromErrors(t *testing.T) {
+	service := WeatherService{
+		HttpClient: &mock.MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("circuit breaker: %w", &url.Error{Op: "Get", URL: req.URL.String(), Err: errors.New("connection refused")})
+			},
+		},
+		BaseURL:  weatherServiceBaseURL,
+		ApiKey:   weatherServiceApiKey,
+		Language: weatherServiceLanguage,
+	}
+
+	_, err := service.GetWeather(context.Background(), "valid-location")
+
+	if err == nil || strings.Contains(err.Error(), weatherServiceApiKey) {
+		t.Errorf("Expected the api key to be redacted, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected the cause to be kept, got %q", err.Error())
+	}
+}
+
 func TestWeatherServiceStatusCodeHandling(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -150,7 +173,7 @@ func TestWeatherServiceDecodeResponseError(t *testing.T) {
 		Language:   weatherServiceLanguage,
 	}
 
-	_, err := service.GetWeather("valid-location")
+	_, err := service.GetWeather(context.Background(), "valid-location")
 
 	if err == nil || !strings.Contains(err.Error(), "failed to decode response") {
 		t.Errorf("Expected error containing %q, got %q", "failed to decode response", err.Error())
@@ -187,7 +210,7 @@ func TestWeatherServiceBadRequestHandling(t *testing.T) {
 				ApiKey:     weatherServiceApiKey,
 				Language:   weatherServiceLanguage,
 			}
-			_, err := service.GetWeather("invalid-location")
+			_, err := service.GetWeather(context.Background(), "invalid-location")
 
 			if err == nil || err != domain.ErrUnexpectedBadRequest {
 				t.Errorf("Expected error %v, got %v", domain.ErrUnexpectedBadRequest, err)
@@ -226,7 +249,7 @@ func TestWeatherServiceUnexpectedStatusCode(t *testing.T) {
 				ApiKey:     weatherServiceApiKey,
 				Language:   weatherServiceLanguage,
 			}
-			_, err := service.GetWeather("valid-location")
+			_, err := service.GetWeather(context.Background(), "valid-location")
 
 			if err == nil || err.Error() != tt.expectErr.Error() {
 				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
@@ -274,7 +297,7 @@ func TestWeatherServiceGetWeatherData(t *testing.T) {
 
 			weatherService := NewWeatherService(mockServer.Client(), mockServer.URL+"?key=%s&q=%s&lang=%s&aqi=no", "APIKEY", "pt")
 			encodedInputLocation := url.QueryEscape(tt.inputLocation)
-			result, err := weatherService.GetWeather(encodedInputLocation)
+			result, err := weatherService.GetWeather(context.Background(), encodedInputLocation)
 
 			if !errors.Is(err, tt.expectErr) {
 				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
@@ -286,3 +309,93 @@ func TestWeatherServiceGetWeatherData(t *testing.T) {
 		})
 	}
 }
+
+func TestWeatherServiceKeyErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		expectErr  error
+	}{
+		{"Invalid Key", http.StatusUnauthorized, `{"error":{"code":2006}}`, domain.ErrUpstreamKeyRejected},
+		{"Quota Exceeded", http.StatusForbidden, `{"error":{"code":2007}}`, domain.ErrUpstreamQuotaExceeded},
+		{"Key Disabled", http.StatusForbidden, `{"error":{"code":2008}}`, domain.ErrUpstreamKeyRejected},
+		{"Unknown Code", http.StatusForbidden, `{"error":{"code":1}}`, domain.NewUnexpectedStatusCodeError(http.StatusForbidden)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := WeatherService{
+				HttpClient: &mock.MockHTTPClient{
+					DoFunc: func(req *http.Request) (*http.Response, error) {
+						return &http.Response{StatusCode: tt.statusCode, Body: io.NopCloser(strings.NewReader(tt.body))}, nil
+					},
+				},
+				BaseURL:  weatherServiceBaseURL,
+				ApiKey:   weatherServiceApiKey,
+				Language: weatherServiceLanguage,
+			}
+			_, err := service.GetWeather(context.Background(), "location")
+
+			if !errors.Is(err, tt.expectErr) && err.Error() != tt.expectErr.Error() {
+				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestWeatherServiceRotatesKeys(t *testing.T) {
+	keys := []string{"key-quota", "key-rejected", "key-ok"}
+	var reported []string
+	var requested []string
+
+	service := WeatherService{
+		HttpClient: &mock.MockHTTPClient{
+			DoFunc: func(req *http.Request) (*http.Response, error) {
+				key := req.URL.Query().Get("apikey")
+				requested = append(requested, key)
+				switch key {
+				case "key-quota":
+					return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{"error":{"code":2007}}`))}, nil
+				case "key-rejected":
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(strings.NewReader(`{"error":{"code":2006}}`))}, nil
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"current":{"temp_c":25}}`))}, nil
+			},
+		},
+		BaseURL:  weatherServiceBaseURL,
+		Language: weatherServiceLanguage,
+		Keys: &mock.MockKeyPool{
+			AcquireFunc: func() (string, error) {
+				if len(keys) == 0 {
+					return "", domain.ErrUpstreamUnavailable
+				}
+				key := keys[0]
+				keys = keys[1:]
+				return key, nil
+			},
+			ReportFunc: func(key string, err error) {
+				reported = append(reported, key+":"+domain.ErrorCode(err))
+			},
+		},
+	}
+
+	result, err := service.GetWeather(context.Background(), "location")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Current.TempC != 25 {
+		t.Errorf("Expected temperature from the healthy key, got %+v", result.Current)
+	}
+	if strings.Join(requested, ",") != "key-quota,key-rejected,key-ok" {
+		t.Errorf("Expected keys to be tried in order, got %v", requested)
+	}
+	if strings.Join(reported, ",") != "key-quota:upstream_quota_exceeded,key-rejected:upstream_key_rejected" {
+		t.Errorf("Expected failing keys to be reported, got %v", reported)
+	}
+
+	_, err = service.GetWeather(context.Background(), "location")
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Errorf("Expected error %v when no key is available, got %v", domain.ErrUpstreamUnavailable, err)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+)
+
+const (
+	WebhookSignatureHeader = "X-Weatherzip-Signature"
+	WebhookTimestampHeader = "X-Weatherzip-Timestamp"
+	WebhookEventHeader     = "X-Weatherzip-Event"
+)
+
+var _ contracts.WebhookService = (*WebhookService)(nil)
+
+type WebhookService struct {
+	HttpClient  contracts.HttpClient
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	sleepFunc   func(ctx context.Context, d time.Duration) error
+}
+
+func NewWebhookService(client contracts.HttpClient, maxAttempts int, baseBackoff, maxBackoff time.Duration) *WebhookService {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	return &WebhookService{
+		HttpClient:  client,
+		MaxAttempts: maxAttempts,
+		BaseBackoff: baseBackoff,
+		MaxBackoff:  maxBackoff,
+		sleepFunc:   sleepContext,
+	}
+}
+
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *WebhookService) Deliver(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return 0, err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= s.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := s.sleepFunc(ctx, s.backoff(attempt-1)); err != nil {
+				return attempt - 1, err
+			}
+		}
+
+		lastErr = s.send(ctx, callbackURL, secret, event.Type, body)
+		if lastErr == nil {
+			return attempt, nil
+		}
+	}
+
+	return s.MaxAttempts, lastErr
+}
+
+func (s *WebhookService) send(ctx context.Context, callbackURL, secret, eventType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return domain.NewFailedToCreateRequestError(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, eventType)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, timestamp, body))
+
+	res, err := s.HttpClient.Do(req)
+	if err != nil {
+		return domain.NewFailedToMakeRequestError(err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return domain.NewWebhookDeliveryError(res.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *WebhookService) backoff(retry int) time.Duration {
+	delay := s.BaseBackoff
+	for i := 1; i < retry; i++ {
+		delay *= 2
+		if s.MaxBackoff > 0 && delay >= s.MaxBackoff {
+			return s.MaxBackoff
+		}
+	}
+
+	if s.MaxBackoff > 0 && delay > s.MaxBackoff {
+		return s.MaxBackoff
+	}
+	return delay
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWebhookEvent() domain.WebhookEvent {
+	return domain.WebhookEvent{
+		ID:             "evt-1",
+		Type:           domain.EventConditionStarted,
+		SubscriptionID: "sub-1",
+		Cep:            "01001000",
+		Condition:      "temp_C > 35",
+		Weather:        domain.WebhookWeather{TempC: 36.5},
+		Timestamp:      time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	signature := SignWebhookPayload("secret", "1733846400", []byte(`{"id":"evt-1"}`))
+
+	assert.True(t, strings.HasPrefix(signature, "sha256="), "Signature should be prefixed with the algorithm")
+	assert.Len(t, signature, len("sha256=")+64)
+	assert.Equal(t, signature, SignWebhookPayload("secret", "1733846400", []byte(`{"id":"evt-1"}`)))
+	assert.NotEqual(t, signature, SignWebhookPayload("other", "1733846400", []byte(`{"id":"evt-1"}`)))
+	assert.NotEqual(t, signature, SignWebhookPayload("secret", "1733846401", []byte(`{"id":"evt-1"}`)))
+}
+
+func TestWebhookServiceDeliverSignsPayload(t *testing.T) {
+	var received domain.WebhookEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, domain.EventConditionStarted, r.Header.Get(WebhookEventHeader))
+		expected := SignWebhookPayload("secret", r.Header.Get(WebhookTimestampHeader), body)
+		assert.Equal(t, expected, r.Header.Get(WebhookSignatureHeader))
+
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookService(server.Client(), 3, time.Millisecond, 10*time.Millisecond)
+	attempts, err := webhook.Deliver(context.Background(), server.URL, "secret", newTestWebhookEvent())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "evt-1", received.ID)
+	assert.Equal(t, 36.5, received.Weather.TempC)
+}
+
+func TestWebhookServiceDeliverRetriesWithBackoff(t *testing.T) {
+	var calls int32
+	mockClient := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+
+	var delays []time.Duration
+	webhook := NewWebhookService(mockClient, 5, 100*time.Millisecond, 150*time.Millisecond)
+	webhook.sleepFunc = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	attempts, err := webhook.Deliver(context.Background(), "http://example.com/hook", "secret", newTestWebhookEvent())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}, delays)
+}
+
+func TestWebhookServiceDeliverExhaustsAttempts(t *testing.T) {
+	mockClient := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	webhook := NewWebhookService(mockClient, 3, 0, 0)
+	attempts, err := webhook.Deliver(context.Background(), "http://example.com/hook", "secret", newTestWebhookEvent())
+
+	assert.Equal(t, 3, attempts)
+	assert.ErrorContains(t, err, "failed to make request")
+}
+
+func TestWebhookServiceDeliverUnexpectedStatus(t *testing.T) {
+	mockClient := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(""))}, nil
+		},
+	}
+
+	webhook := NewWebhookService(mockClient, 0, 0, 0)
+	attempts, err := webhook.Deliver(context.Background(), "http://example.com/hook", "secret", newTestWebhookEvent())
+
+	assert.Equal(t, 1, attempts, "MaxAttempts below one should still deliver once")
+	assert.EqualError(t, err, domain.NewWebhookDeliveryError(http.StatusInternalServerError).Error())
+}
+
+func TestWebhookServiceDeliverInvalidURL(t *testing.T) {
+	webhook := NewWebhookService(&mock.MockHTTPClient{}, 1, 0, 0)
+
+	_, err := webhook.Deliver(context.Background(), "://invalid", "secret", newTestWebhookEvent())
+
+	assert.ErrorContains(t, err, "failed to create request")
+}
+
+func TestWebhookServiceDeliverContextCanceled(t *testing.T) {
+	mockClient := &mock.MockHTTPClient{
+		DoFunc: func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	webhook := NewWebhookService(mockClient, 3, time.Second, time.Second)
+	attempts, err := webhook.Deliver(ctx, "http://example.com/hook", "secret", newTestWebhookEvent())
+
+	assert.Equal(t, 1, attempts)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	serviceContracts "github.com/vs0uz4/weatherzip/internal/service/contracts"
+	"github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+)
+
+const (
+	apiKeyPrefix       = "wz_"
+	apiKeyPrefixLength = len(apiKeyPrefix) + 8
+)
+
+var _ contracts.APIKeyUsecase = (*apiKeyUsecase)(nil)
+
+type apiKeyUsecase struct {
+	Keys            serviceContracts.APIKeyRepository
+	nowFunc         func() time.Time
+	generateIDFunc  func() string
+	generateKeyFunc func() (string, error)
+}
+
+func NewAPIKeyUsecase(keys serviceContracts.APIKeyRepository) *apiKeyUsecase {
+	return &apiKeyUsecase{
+		Keys:            keys,
+		nowFunc:         time.Now,
+		generateIDFunc:  generateID,
+		generateKeyFunc: generateAPIKey,
+	}
+}
+
+func (uc *apiKeyUsecase) CreateAPIKey(request domain.APIKeyRequest) (domain.IssuedAPIKey, error) {
+	tenant := strings.TrimSpace(request.Tenant)
+	if tenant == "" {
+		return domain.IssuedAPIKey{}, domain.ErrInvalidTenant
+	}
+
+	if len(request.Scopes) == 0 {
+		return domain.IssuedAPIKey{}, domain.ErrInvalidScope
+	}
+	for _, scope := range request.Scopes {
+		if !domain.IsKnownScope(scope) {
+			return domain.IssuedAPIKey{}, domain.ErrInvalidScope
+		}
+	}
+
+	if request.Quota < 0 {
+		return domain.IssuedAPIKey{}, domain.ErrInvalidQuota
+	}
+
+	now := uc.nowFunc().UTC()
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(now) {
+		return domain.IssuedAPIKey{}, domain.ErrAPIKeyExpired
+	}
+
+	raw, err := uc.generateKeyFunc()
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	key := domain.APIKey{
+		ID:        uc.generateIDFunc(),
+		Tenant:    tenant,
+		Scopes:    request.Scopes,
+		Quota:     request.Quota,
+		Prefix:    raw[:apiKeyPrefixLength],
+		Hash:      hashAPIKey(raw),
+		ExpiresAt: request.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	if err := uc.Keys.Save(key); err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	return domain.IssuedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func (uc *apiKeyUsecase) ListAPIKeys() ([]domain.APIKey, error) {
+	return uc.Keys.FindAll()
+}
+
+func (uc *apiKeyUsecase) RotateAPIKey(id string) (domain.IssuedAPIKey, error) {
+	key, err := uc.Keys.FindByID(id)
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	if key.IsRevoked() {
+		return domain.IssuedAPIKey{}, domain.ErrAPIKeyRevoked
+	}
+
+	raw, err := uc.generateKeyFunc()
+	if err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	now := uc.nowFunc().UTC()
+	key.Prefix = raw[:apiKeyPrefixLength]
+	key.Hash = hashAPIKey(raw)
+	key.RotatedAt = &now
+
+	if err := uc.Keys.Update(key); err != nil {
+		return domain.IssuedAPIKey{}, err
+	}
+
+	return domain.IssuedAPIKey{APIKey: key, Key: raw}, nil
+}
+
+func (uc *apiKeyUsecase) RevokeAPIKey(id string) error {
+	key, err := uc.Keys.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if key.IsRevoked() {
+		return nil
+	}
+
+	now := uc.nowFunc().UTC()
+	key.RevokedAt = &now
+
+	return uc.Keys.Update(key)
+}
+
+func (uc *apiKeyUsecase) Authenticate(raw string) (domain.Principal, error) {
+	if !strings.HasPrefix(raw, apiKeyPrefix) || len(raw) <= apiKeyPrefixLength {
+		return domain.Principal{}, domain.ErrInvalidAPIKey
+	}
+
+	key, err := uc.Keys.FindByHash(hashAPIKey(raw))
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return domain.Principal{}, domain.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return domain.Principal{}, err
+	}
+
+	if key.IsRevoked() {
+		return domain.Principal{}, domain.ErrAPIKeyRevoked
+	}
+
+	if key.IsExpired(uc.nowFunc()) {
+		return domain.Principal{}, domain.ErrAPIKeyExpired
+	}
+
+	return key.Principal(), nil
+}
+
+func hashAPIKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPIKeyUsecaseFixture(now time.Time) (*apiKeyUsecase, map[string]domain.APIKey) {
+	keys := map[string]domain.APIKey{}
+
+	repo := &mock.MockAPIKeyRepository{
+		SaveFunc: func(key domain.APIKey) error {
+			keys[key.ID] = key
+			return nil
+		},
+		UpdateFunc: func(key domain.APIKey) error {
+			if _, ok := keys[key.ID]; !ok {
+				return domain.ErrAPIKeyNotFound
+			}
+			keys[key.ID] = key
+			return nil
+		},
+		FindAllFunc: func() ([]domain.APIKey, error) {
+			all := []domain.APIKey{}
+			for _, key := range keys {
+				all = append(all, key)
+			}
+			return all, nil
+		},
+		FindByIDFunc: func(id string) (domain.APIKey, error) {
+			key, ok := keys[id]
+			if !ok {
+				return domain.APIKey{}, domain.ErrAPIKeyNotFound
+			}
+			return key, nil
+		},
+		FindByHashFunc: func(hash string) (domain.APIKey, error) {
+			for _, key := range keys {
+				if key.Hash == hash {
+					return key, nil
+				}
+			}
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		},
+	}
+
+	uc := NewAPIKeyUsecase(repo)
+	uc.nowFunc = func() time.Time { return now }
+	ids := 0
+	uc.generateIDFunc = func() string {
+		ids++
+		return fmt.Sprintf("key-%d", ids)
+	}
+
+	return uc, keys
+}
+
+func TestAPIKeyUsecaseCreateAPIKey(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	uc, keys := newAPIKeyUsecaseFixture(now)
+
+	issued, err := uc.CreateAPIKey(domain.APIKeyRequest{Tenant: " partner ", Scopes: []string{domain.ScopeWeatherRead}, Quota: 120})
+	require.NoError(t, err)
+
+	assert.Equal(t, "key-1", issued.ID)
+	assert.Equal(t, "partner", issued.Tenant)
+	assert.True(t, strings.HasPrefix(issued.Key, "wz_"))
+	assert.Equal(t, issued.Key[:11], issued.Prefix)
+	assert.Equal(t, now, issued.CreatedAt)
+
+	stored := keys["key-1"]
+	assert.NotContains(t, stored.Hash, issued.Key, "Plaintext key must not be stored")
+	assert.Equal(t, hashAPIKey(issued.Key), stored.Hash)
+}
+
+func TestAPIKeyUsecaseCreateAPIKeyValidation(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		request     domain.APIKeyRequest
+		expectedErr error
+	}{
+		{name: "Missing Tenant", request: domain.APIKeyRequest{Scopes: []string{domain.ScopeWeatherRead}}, expectedErr: domain.ErrInvalidTenant},
+		{name: "Missing Scopes", request: domain.APIKeyRequest{Tenant: "partner"}, expectedErr: domain.ErrInvalidScope},
+		{name: "Unknown Scope", request: domain.APIKeyRequest{Tenant: "partner", Scopes: []string{"root"}}, expectedErr: domain.ErrInvalidScope},
+		{name: "Negative Quota", request: domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}, Quota: -1}, expectedErr: domain.ErrInvalidQuota},
+		{name: "Expired", request: domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}, ExpiresAt: &past}, expectedErr: domain.ErrAPIKeyExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _ := newAPIKeyUsecaseFixture(now)
+
+			_, err := uc.CreateAPIKey(tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAPIKeyUsecaseCreateAPIKeyGenerateError(t *testing.T) {
+	uc, _ := newAPIKeyUsecaseFixture(time.Now())
+	uc.generateKeyFunc = func() (string, error) {
+		return "", errors.New("entropy exhausted")
+	}
+
+	_, err := uc.CreateAPIKey(domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}})
+
+	assert.EqualError(t, err, "entropy exhausted")
+}
+
+func TestAPIKeyUsecaseAuthenticate(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	uc, _ := newAPIKeyUsecaseFixture(now)
+	expiresAt := now.Add(time.Hour)
+
+	issued, err := uc.CreateAPIKey(domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}, Quota: 10, ExpiresAt: &expiresAt})
+	require.NoError(t, err)
+
+	principal, err := uc.Authenticate(issued.Key)
+	require.NoError(t, err)
+	assert.Equal(t, domain.Principal{ID: issued.ID, Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}, Quota: 10, Method: domain.AuthMethodAPIKey}, principal)
+
+	_, err = uc.Authenticate("wz_unknownkey")
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+
+	_, err = uc.Authenticate("not-a-key")
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+
+	uc.nowFunc = func() time.Time { return expiresAt }
+	_, err = uc.Authenticate(issued.Key)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyExpired)
+}
+
+func TestAPIKeyUsecaseRotateAPIKey(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	uc, _ := newAPIKeyUsecaseFixture(now)
+
+	issued, err := uc.CreateAPIKey(domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}})
+	require.NoError(t, err)
+
+	rotated, err := uc.RotateAPIKey(issued.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, issued.ID, rotated.ID)
+	assert.NotEqual(t, issued.Key, rotated.Key)
+	require.NotNil(t, rotated.RotatedAt)
+	assert.Equal(t, now, *rotated.RotatedAt)
+
+	_, err = uc.Authenticate(issued.Key)
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, "Old key should stop working after rotation")
+
+	_, err = uc.Authenticate(rotated.Key)
+	assert.NoError(t, err)
+
+	_, err = uc.RotateAPIKey("missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyUsecaseRevokeAPIKey(t *testing.T) {
+	now := time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	uc, keys := newAPIKeyUsecaseFixture(now)
+
+	issued, err := uc.CreateAPIKey(domain.APIKeyRequest{Tenant: "partner", Scopes: []string{domain.ScopeWeatherRead}})
+	require.NoError(t, err)
+
+	require.NoError(t, uc.RevokeAPIKey(issued.ID))
+	require.NoError(t, uc.RevokeAPIKey(issued.ID), "Revoking twice should be a no-op")
+	assert.Equal(t, now, *keys[issued.ID].RevokedAt)
+
+	_, err = uc.Authenticate(issued.Key)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyRevoked)
+
+	_, err = uc.RotateAPIKey(issued.ID)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyRevoked, "Revoked keys cannot be rotated")
+
+	assert.ErrorIs(t, uc.RevokeAPIKey("missing"), domain.ErrAPIKeyNotFound)
+
+	all, err := uc.ListAPIKeys()
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+}
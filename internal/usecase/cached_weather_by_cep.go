@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/contracts"
+	usecasecontracts "github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+)
+
+const weatherCacheKeyPrefix = "weather:"
+
+type WeatherCacheConfig struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+	RefreshTimeout       time.Duration
+}
+
+type cachedWeatherEntry struct {
+	Weather  domain.WeatherResponse `json:"weather"`
+	StoredAt time.Time              `json:"stored_at"`
+}
+
+type cachedWeatherByCepUsecase struct {
+	Next       usecasecontracts.WeatherByCepUsecase
+	Cache      contracts.Cache
+	Config     WeatherCacheConfig
+	mu         sync.Mutex
+	refreshing map[string]bool
+	nowFunc    func() time.Time
+}
+
+func NewCachedWeatherByCepUsecase(next usecasecontracts.WeatherByCepUsecase, cache contracts.Cache, config WeatherCacheConfig) *cachedWeatherByCepUsecase {
+	return &cachedWeatherByCepUsecase{
+		Next:       next,
+		Cache:      cache,
+		Config:     config,
+		refreshing: make(map[string]bool),
+		nowFunc:    time.Now,
+	}
+}
+
+func (uc *cachedWeatherByCepUsecase) GetWeatherByCep(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+	entry, found := uc.load(ctx, cep)
+	if !found {
+		return uc.fetch(ctx, cep)
+	}
+
+	age := uc.nowFunc().Sub(entry.StoredAt)
+	switch {
+	case age < uc.Config.TTL:
+		return withCacheInfo(entry.Weather, domain.CacheStatusHit, age), nil
+	case age < uc.Config.TTL+uc.Config.StaleWhileRevalidate:
+		uc.refreshInBackground(ctx, cep)
+		return withCacheInfo(entry.Weather, domain.CacheStatusStale, age), nil
+	}
+
+	weather, err := uc.fetch(ctx, cep)
+	if err != nil && domain.IsTransientWeatherError(err) && age < uc.Config.TTL+uc.Config.StaleIfError {
+		return withCacheInfo(entry.Weather, domain.CacheStatusStaleIfError, age), nil
+	}
+	return weather, err
+}
+
+func (uc *cachedWeatherByCepUsecase) Cached(ctx context.Context, cep string) bool {
+	entry, found := uc.load(ctx, cep)
+	return found && uc.nowFunc().Sub(entry.StoredAt) < uc.Config.TTL+uc.Config.StaleWhileRevalidate
+}
+
+func (uc *cachedWeatherByCepUsecase) fetch(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+	weather, err := uc.Next.GetWeatherByCep(ctx, cep)
+	if err != nil {
+		return domain.WeatherResponse{}, err
+	}
+
+	uc.store(ctx, cep, weather)
+	return withCacheInfo(weather, domain.CacheStatusMiss, 0), nil
+}
+
+func (uc *cachedWeatherByCepUsecase) refreshInBackground(ctx context.Context, cep string) {
+	uc.mu.Lock()
+	if uc.refreshing[cep] {
+		uc.mu.Unlock()
+		return
+	}
+	uc.refreshing[cep] = true
+	uc.mu.Unlock()
+
+	refreshCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), uc.refreshTimeout())
+	go func() {
+		defer cancel()
+		defer func() {
+			uc.mu.Lock()
+			delete(uc.refreshing, cep)
+			uc.mu.Unlock()
+		}()
+
+		if _, err := uc.fetch(refreshCtx, cep); err != nil {
+			slog.Warn("weather cache background refresh failed", slog.String("error", err.Error()))
+		}
+	}()
+}
+
+func (uc *cachedWeatherByCepUsecase) load(ctx context.Context, cep string) (cachedWeatherEntry, bool) {
+	var entry cachedWeatherEntry
+
+	data, found, err := uc.Cache.Get(ctx, weatherCacheKeyPrefix+cep)
+	if err != nil || !found {
+		return entry, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+func (uc *cachedWeatherByCepUsecase) store(ctx context.Context, cep string, weather domain.WeatherResponse) {
+	data, err := json.Marshal(cachedWeatherEntry{Weather: weather, StoredAt: uc.nowFunc()})
+	if err != nil {
+		return
+	}
+
+	retention := uc.Config.TTL + max(uc.Config.StaleWhileRevalidate, uc.Config.StaleIfError)
+	if err := uc.Cache.Set(ctx, weatherCacheKeyPrefix+cep, data, retention); err != nil {
+		slog.Warn("weather cache failed to store entry", slog.String("error", err.Error()))
+	}
+}
+
+func (uc *cachedWeatherByCepUsecase) refreshTimeout() time.Duration {
+	if uc.Config.RefreshTimeout > 0 {
+		return uc.Config.RefreshTimeout
+	}
+	return 10 * time.Second
+}
+
+func withCacheInfo(weather domain.WeatherResponse, status string, age time.Duration) domain.WeatherResponse {
+	weather.Cache = domain.CacheInfo{Status: status, Age: age}
+	return weather
+}
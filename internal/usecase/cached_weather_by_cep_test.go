@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/infra/cache"
+	"github.com/vs0uz4/weatherzip/internal/usecase/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedWeatherFixture struct {
+	usecase *cachedWeatherByCepUsecase
+	mu      sync.Mutex
+	now     time.Time
+	calls   int
+	err     error
+	temp    float64
+}
+
+func newCachedWeatherFixture() *cachedWeatherFixture {
+	f := &cachedWeatherFixture{
+		now:  time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC),
+		temp: 25.0,
+	}
+
+	next := &mock.MockWeatherByCepUsecase{
+		GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			f.calls++
+			if f.err != nil {
+				return domain.WeatherResponse{}, f.err
+			}
+			return domain.WeatherResponse{Current: domain.CurrentWeather{TempC: f.temp}}, nil
+		},
+	}
+
+	f.usecase = NewCachedWeatherByCepUsecase(next, cache.NewMemoryCache(), WeatherCacheConfig{
+		TTL:                  10 * time.Minute,
+		StaleWhileRevalidate: 5 * time.Minute,
+		StaleIfError:         time.Hour,
+	})
+	f.usecase.nowFunc = func() time.Time {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.now
+	}
+
+	return f
+}
+
+func (f *cachedWeatherFixture) set(fn func(f *cachedWeatherFixture)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn(f)
+}
+
+func (f *cachedWeatherFixture) advance(d time.Duration) {
+	f.set(func(f *cachedWeatherFixture) { f.now = f.now.Add(d) })
+}
+
+func (f *cachedWeatherFixture) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *cachedWeatherFixture) waitRefresh(t *testing.T) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		f.usecase.mu.Lock()
+		defer f.usecase.mu.Unlock()
+		return len(f.usecase.refreshing) == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestCachedWeatherByCepFreshHit(t *testing.T) {
+	f := newCachedWeatherFixture()
+
+	first, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusMiss, first.Cache.Status)
+
+	f.advance(time.Minute)
+	second, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusHit, second.Cache.Status)
+	assert.Equal(t, time.Minute, second.Cache.Age)
+	assert.False(t, second.Cache.IsStale())
+	assert.Equal(t, 1, f.callCount())
+}
+
+func TestCachedWeatherByCepCached(t *testing.T) {
+	f := newCachedWeatherFixture()
+	assert.False(t, f.usecase.Cached(context.Background(), "01001000"))
+
+	_, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.True(t, f.usecase.Cached(context.Background(), "01001000"))
+	assert.False(t, f.usecase.Cached(context.Background(), "98807172"))
+
+	f.advance(14 * time.Minute)
+	assert.True(t, f.usecase.Cached(context.Background(), "01001000"), "Entries within stale-while-revalidate are served from cache")
+
+	f.advance(time.Minute)
+	assert.False(t, f.usecase.Cached(context.Background(), "01001000"), "Expired entries need an upstream call")
+}
+
+func TestCachedWeatherByCepStaleWhileRevalidate(t *testing.T) {
+	f := newCachedWeatherFixture()
+	_, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+
+	f.set(func(f *cachedWeatherFixture) { f.temp = 30.0 })
+	f.advance(12 * time.Minute)
+	stale, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusStale, stale.Cache.Status)
+	assert.Equal(t, 12*time.Minute, stale.Cache.Age)
+	assert.Equal(t, 25.0, stale.Current.TempC, "Stale entry should be served immediately")
+
+	f.waitRefresh(t)
+	refreshed, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusHit, refreshed.Cache.Status)
+	assert.Equal(t, 30.0, refreshed.Current.TempC)
+	assert.Equal(t, 2, f.callCount())
+}
+
+func TestCachedWeatherByCepStaleIfError(t *testing.T) {
+	f := newCachedWeatherFixture()
+	_, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+
+	f.set(func(f *cachedWeatherFixture) {
+		f.err = domain.NewFailedToMakeRequestError(domain.ErrUpstreamUnavailable)
+	})
+	f.advance(20 * time.Minute)
+	weather, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusStaleIfError, weather.Cache.Status)
+	assert.Equal(t, 20*time.Minute, weather.Cache.Age)
+
+	f.advance(time.Hour)
+	_, err = f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	assert.ErrorIs(t, err, domain.ErrUpstreamUnavailable, "Entries beyond the maximum staleness must not be served")
+}
+
+func TestCachedWeatherByCepDoesNotServeStaleOnDefinitiveErrors(t *testing.T) {
+	f := newCachedWeatherFixture()
+	_, err := f.usecase.GetWeatherByCep(context.Background(), "01001000")
+	require.NoError(t, err)
+
+	f.set(func(f *cachedWeatherFixture) { f.err = domain.ErrZipcodeNotFound })
+	f.advance(20 * time.Minute)
+	_, err = f.usecase.GetWeatherByCep(context.Background(), "01001000")
+
+	assert.ErrorIs(t, err, domain.ErrZipcodeNotFound)
+}
+
+func TestCachedWeatherByCepDoesNotCacheErrors(t *testing.T) {
+	f := newCachedWeatherFixture()
+	f.set(func(f *cachedWeatherFixture) { f.err = domain.ErrInvalidZipcode })
+
+	_, err := f.usecase.GetWeatherByCep(context.Background(), "0100")
+	assert.ErrorIs(t, err, domain.ErrInvalidZipcode)
+	_, err = f.usecase.GetWeatherByCep(context.Background(), "0100")
+	assert.ErrorIs(t, err, domain.ErrInvalidZipcode)
+
+	assert.Equal(t, 2, f.callCount())
+}
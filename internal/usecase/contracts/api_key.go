@@ -0,0 +1,11 @@
+package contracts
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type APIKeyUsecase interface {
+	CreateAPIKey(request domain.APIKeyRequest) (domain.IssuedAPIKey, error)
+	ListAPIKeys() ([]domain.APIKey, error)
+	RotateAPIKey(id string) (domain.IssuedAPIKey, error)
+	RevokeAPIKey(id string) error
+	Authenticate(key string) (domain.Principal, error)
+}
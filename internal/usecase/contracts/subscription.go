@@ -0,0 +1,16 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
+
+type SubscriptionUsecase interface {
+	CreateSubscription(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error)
+	ListSubscriptions(tenant string) ([]domain.Subscription, error)
+	GetSubscription(tenant, id string) (domain.Subscription, error)
+	DeleteSubscription(tenant, id string) error
+	ListDeadLetters(tenant string) ([]domain.DeadLetter, error)
+	EvaluateSubscriptions(ctx context.Context)
+}
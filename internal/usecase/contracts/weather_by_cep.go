@@ -1,7 +1,11 @@
 package contracts
 
-import "github.com/vs0uz4/weatherzip/internal/domain"
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
 
 type WeatherByCepUsecase interface {
-	GetWeatherByCep(cep string) (domain.WeatherResponse, error)
+	GetWeatherByCep(ctx context.Context, cep string) (domain.WeatherResponse, error)
 }
@@ -1,62 +1,50 @@
 package usecase
 
 import (
+	"context"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/vs0uz4/weatherzip/internal/infra/checker"
 	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
 	"github.com/vs0uz4/weatherzip/internal/service"
 )
 
 type HealthCheckUseCase interface {
-	GetHealth() (health.HealthStats, error)
+	GetHealth(ctx context.Context) (health.HealthStats, error)
 }
 
 type healthCheckUseCase struct {
-	cpuService    service.CPUService
-	memoryService service.MemoryService
+	registry      *checker.Registry
 	uptimeService service.UptimeService
 }
 
-func NewHealthCheckUseCase(cpu service.CPUService, memory service.MemoryService, uptime service.UptimeService) HealthCheckUseCase {
-	return &healthCheckUseCase{cpu, memory, uptime}
+func NewHealthCheckUseCase(registry *checker.Registry, uptime service.UptimeService) HealthCheckUseCase {
+	return &healthCheckUseCase{registry, uptime}
 }
 
-func (h *healthCheckUseCase) GetHealth() (health.HealthStats, error) {
+func (h *healthCheckUseCase) GetHealth(ctx context.Context) (health.HealthStats, error) {
 	start := time.Now()
 
+	checks := h.registry.Run(ctx)
 	healthStats := health.HealthStats{
-		Status:   "pass",
-		Duration: "",
+		Checks: checks,
+		Status: checker.Status(checks),
 	}
 
-	cores, percentUsed, err := h.cpuService.GetCPUStats()
-	if err != nil {
-		healthStats.Status = "fail"
-	} else {
-		healthStats.CPU = health.CPUStats{
-			Cores:       cores,
-			PercentUsed: percentUsed,
+	for _, result := range checks {
+		switch details := result.Details.(type) {
+		case health.CPUStats:
+			healthStats.CPU = details
+		case health.MemoryStats:
+			healthStats.Memory = details
+		case health.ContainerStats:
+			healthStats.Container = &details
 		}
 	}
 
-	total, used, free, available, percentUsedMem, err := h.memoryService.GetMemoryStats()
-	if err != nil {
-		healthStats.Status = "fail"
-	} else {
-		healthStats.Memory = health.MemoryStats{
-			Total:       total,
-			Used:        used,
-			Free:        free,
-			Available:   available,
-			PercentUsed: percentUsedMem,
-		}
-	}
-
-	if healthStats.Status == "pass" {
-		healthStats.Message = "Alive and kicking!"
-	} else {
-		healthStats.Message = "Still alive, but not kicking!"
-	}
+	healthStats.Message = healthMessage(healthStats.Status, checks)
 
 	healthStats.Uptime = h.uptimeService.GetUptime()
 	healthStats.Duration = time.Since(start).String()
@@ -64,3 +52,25 @@ func (h *healthCheckUseCase) GetHealth() (health.HealthStats, error) {
 
 	return healthStats, nil
 }
+
+func healthMessage(status string, checks map[string]health.CheckResult) string {
+	message := "Alive and kicking!"
+	switch status {
+	case health.StatusFail:
+		message = "Still alive, but not kicking!"
+	case health.StatusWarn:
+		message = "Alive, but under pressure!"
+	}
+
+	var reasons []string
+	for name, result := range checks {
+		if result.Status != health.StatusPass && result.ObservedValue != nil && result.Output != "" {
+			reasons = append(reasons, name+": "+result.Output)
+		}
+	}
+	if len(reasons) == 0 {
+		return message
+	}
+	slices.Sort(reasons)
+	return message + " " + strings.Join(reasons, "; ")
+}
@@ -1,23 +1,35 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/vs0uz4/weatherzip/internal/infra/checker"
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
 	"github.com/vs0uz4/weatherzip/internal/service"
 	"github.com/vs0uz4/weatherzip/internal/service/mock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func newHealthRegistry(t *testing.T, cpu service.CPUService, memory service.MemoryService) *checker.Registry {
+	registry := checker.NewRegistry(time.Second)
+	require.NoError(t, registry.Register(checker.NewCPUChecker(cpu)))
+	require.NoError(t, registry.Register(checker.NewMemoryChecker(memory)))
+	return registry
+}
+
 func TestGetHealth(t *testing.T) {
 	cpuService := service.NewCPUService()
 	memoryService := service.NewMemoryService()
 	uptimeService := service.NewUptimeService()
 
-	healthCheck := NewHealthCheckUseCase(cpuService, memoryService, uptimeService)
+	healthCheck := NewHealthCheckUseCase(newHealthRegistry(t, cpuService, memoryService), uptimeService)
 
-	healthStats, err := healthCheck.GetHealth()
+	healthStats, err := healthCheck.GetHealth(context.Background())
 
 	assert.NoError(t, err, "HealthCheck should not produce an error")
 	assert.Equal(t, "pass", healthStats.Status, "Default status should be 'pass'")
@@ -25,6 +37,8 @@ func TestGetHealth(t *testing.T) {
 	assert.NotEmpty(t, healthStats.Memory, "Memory stats should not be empty")
 	assert.NotEmpty(t, healthStats.Uptime, "Uptime should not be empty")
 	assert.NotEmpty(t, healthStats.Message, "Message should not be empty")
+	assert.Contains(t, healthStats.Checks, "cpu:utilization")
+	assert.Contains(t, healthStats.Checks, "memory:utilization")
 }
 
 func TestGetHealthCpuServiceError(t *testing.T) {
@@ -36,13 +50,14 @@ func TestGetHealthCpuServiceError(t *testing.T) {
 	mockMemoryService := service.NewMemoryService()
 	mockUptimeService := service.NewUptimeService()
 
-	healthCheck := NewHealthCheckUseCase(mockCPUService, mockMemoryService, mockUptimeService)
+	healthCheck := NewHealthCheckUseCase(newHealthRegistry(t, mockCPUService, mockMemoryService), mockUptimeService)
 
-	healthStats, err := healthCheck.GetHealth()
+	healthStats, err := healthCheck.GetHealth(context.Background())
 
 	assert.NoError(t, err, "HealthCheck should not return an error")
 	assert.Equal(t, "fail", healthStats.Status, "Status should be 'fail' when CPU service fails")
 	assert.Equal(t, "Still alive, but not kicking!", healthStats.Message, "Message should reflect the 'fail' status")
+	assert.Equal(t, "mock CPU error", healthStats.Checks["cpu:utilization"].Output)
 }
 
 func TestGetHealthMemoryServiceError(t *testing.T) {
@@ -54,9 +69,9 @@ func TestGetHealthMemoryServiceError(t *testing.T) {
 	}
 	mockUptimeService := service.NewUptimeService()
 
-	healthCheck := NewHealthCheckUseCase(mockCPUService, mockMemoryService, mockUptimeService)
+	healthCheck := NewHealthCheckUseCase(newHealthRegistry(t, mockCPUService, mockMemoryService), mockUptimeService)
 
-	healthStats, err := healthCheck.GetHealth()
+	healthStats, err := healthCheck.GetHealth(context.Background())
 
 	assert.NoError(t, err, "HealthCheck should not return an error")
 	assert.Equal(t, "fail", healthStats.Status, "Status should be 'fail' when memory service fails")
@@ -76,11 +91,77 @@ func TestGetHealthMessageWhenNotPass(t *testing.T) {
 	}
 	mockUptimeService := service.NewUptimeService()
 
-	healthCheck := NewHealthCheckUseCase(mockCPUService, mockMemoryService, mockUptimeService)
+	healthCheck := NewHealthCheckUseCase(newHealthRegistry(t, mockCPUService, mockMemoryService), mockUptimeService)
 
-	healthStats, err := healthCheck.GetHealth()
+	healthStats, err := healthCheck.GetHealth(context.Background())
 
 	assert.NoError(t, err, "HealthCheck should not return an error")
 	assert.Equal(t, "fail", healthStats.Status, "Status should be 'fail' when both services fail")
 	assert.Equal(t, "Still alive, but not kicking!", healthStats.Message, "Message should be 'Still alive, but not kicking!' when status is 'fail'")
 }
+
+func TestGetHealthCustomCheckers(t *testing.T) {
+	registry := checker.NewRegistry(time.Second)
+	require.NoError(t, registry.Register(&mock.MockChecker{
+		NameFunc:          func() string { return "queue:depth" },
+		ComponentTypeFunc: func() string { return "component" },
+		CheckFunc: func(ctx context.Context) health.CheckResult {
+			return health.CheckResult{Status: health.StatusWarn, ObservedValue: 120, ObservedUnit: "messages"}
+		},
+	}))
+
+	healthCheck := NewHealthCheckUseCase(registry, service.NewUptimeService())
+
+	healthStats, err := healthCheck.GetHealth(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "warn", healthStats.Status)
+	assert.Equal(t, "Alive, but under pressure!", healthStats.Message, "Warnings should not report the service as failing")
+	assert.Equal(t, "component", healthStats.Checks["queue:depth"].ComponentType)
+	assert.Equal(t, 120, healthStats.Checks["queue:depth"].ObservedValue)
+}
+
+func TestGetHealthExplainsTrippedThresholds(t *testing.T) {
+	memoryChecker := checker.NewMemoryChecker(&mock.MockMemoryService{
+		GetMemoryStatsFunc: func() (uint64, uint64, uint64, uint64, float64, error) {
+			return 8 << 30, 7 << 30, 1 << 30, 100 << 20, 96.3, nil
+		},
+	})
+	memoryChecker.Percent = checker.NewThreshold(85, 95, 0.05)
+	memoryChecker.AvailableMB = checker.NewLowerThreshold(256, 64, 0.05)
+	cpuChecker := checker.NewCPUChecker(&mock.MockCPUService{
+		GetCPUStatsFunc: func() (int, []float64, error) {
+			return 2, []float64{85, 75}, nil
+		},
+	})
+	cpuChecker.Average = checker.NewThreshold(80, 95, 0.05)
+
+	registry := checker.NewRegistry(time.Second)
+	require.NoError(t, registry.Register(memoryChecker))
+	require.NoError(t, registry.Register(cpuChecker))
+
+	healthStats, err := NewHealthCheckUseCase(registry, service.NewUptimeService()).GetHealth(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "fail", healthStats.Status)
+	assert.Equal(t, "Still alive, but not kicking! "+
+		"cpu:utilization: cpu average 80% is above the warn threshold of 80%; "+
+		"memory:utilization: memory usage 96.3% is above the fail threshold of 95%; available memory 100MB is below the warn threshold of 256MB",
+		healthStats.Message)
+}
+
+func TestGetHealthReportsContainerStats(t *testing.T) {
+	registry := checker.NewRegistry(time.Second)
+	require.NoError(t, registry.Register(&mock.MockChecker{
+		NameFunc:          func() string { return "container:cpuThrottling" },
+		ComponentTypeFunc: func() string { return "system" },
+		CheckFunc: func(ctx context.Context) health.CheckResult {
+			return health.CheckResult{ObservedValue: 0.0, Details: health.ContainerStats{CgroupVersion: 2, MemoryLimit: 512 << 20}}
+		},
+	}))
+
+	healthStats, err := NewHealthCheckUseCase(registry, service.NewUptimeService()).GetHealth(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &health.ContainerStats{CgroupVersion: 2, MemoryLimit: 512 << 20}, healthStats.Container)
+}
@@ -0,0 +1,31 @@
+package mock
+
+import "github.com/vs0uz4/weatherzip/internal/domain"
+
+type MockAPIKeyUsecase struct {
+	CreateAPIKeyFunc func(request domain.APIKeyRequest) (domain.IssuedAPIKey, error)
+	ListAPIKeysFunc  func() ([]domain.APIKey, error)
+	RotateAPIKeyFunc func(id string) (domain.IssuedAPIKey, error)
+	RevokeAPIKeyFunc func(id string) error
+	AuthenticateFunc func(key string) (domain.Principal, error)
+}
+
+func (m *MockAPIKeyUsecase) CreateAPIKey(request domain.APIKeyRequest) (domain.IssuedAPIKey, error) {
+	return m.CreateAPIKeyFunc(request)
+}
+
+func (m *MockAPIKeyUsecase) ListAPIKeys() ([]domain.APIKey, error) {
+	return m.ListAPIKeysFunc()
+}
+
+func (m *MockAPIKeyUsecase) RotateAPIKey(id string) (domain.IssuedAPIKey, error) {
+	return m.RotateAPIKeyFunc(id)
+}
+
+func (m *MockAPIKeyUsecase) RevokeAPIKey(id string) error {
+	return m.RevokeAPIKeyFunc(id)
+}
+
+func (m *MockAPIKeyUsecase) Authenticate(key string) (domain.Principal, error) {
+	return m.AuthenticateFunc(key)
+}
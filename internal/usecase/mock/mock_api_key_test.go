@@ -0,0 +1,47 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockAPIKeyUsecase(t *testing.T) {
+	mock := &MockAPIKeyUsecase{
+		CreateAPIKeyFunc: func(request domain.APIKeyRequest) (domain.IssuedAPIKey, error) {
+			return domain.IssuedAPIKey{APIKey: domain.APIKey{Tenant: request.Tenant}, Key: "wz_key"}, nil
+		},
+		ListAPIKeysFunc: func() ([]domain.APIKey, error) {
+			return []domain.APIKey{{ID: "key-1"}}, nil
+		},
+		RotateAPIKeyFunc: func(id string) (domain.IssuedAPIKey, error) {
+			return domain.IssuedAPIKey{}, domain.ErrAPIKeyNotFound
+		},
+		RevokeAPIKeyFunc: func(id string) error {
+			return errors.New("mock error")
+		},
+		AuthenticateFunc: func(key string) (domain.Principal, error) {
+			return domain.Principal{Tenant: "partner"}, nil
+		},
+	}
+
+	issued, err := mock.CreateAPIKey(domain.APIKeyRequest{Tenant: "partner"})
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", issued.Tenant, "Expected tenant to match mock value")
+
+	keys, err := mock.ListAPIKeys()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1, "Expected keys to match mock value")
+
+	_, err = mock.RotateAPIKey("key-1")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound, "Expected error from mock")
+
+	assert.EqualError(t, mock.RevokeAPIKey("key-1"), "mock error")
+
+	principal, err := mock.Authenticate("wz_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "partner", principal.Tenant, "Expected tenant to match mock value")
+}
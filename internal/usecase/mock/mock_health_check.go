@@ -1,11 +1,15 @@
 package mock
 
-import "github.com/vs0uz4/weatherzip/internal/infra/web/health"
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/infra/web/health"
+)
 
 type MockHealthCheckUseCase struct {
-	GetHealthFunc func() (health.HealthStats, error)
+	GetHealthFunc func(ctx context.Context) (health.HealthStats, error)
 }
 
-func (m *MockHealthCheckUseCase) GetHealth() (health.HealthStats, error) {
-	return m.GetHealthFunc()
+func (m *MockHealthCheckUseCase) GetHealth(ctx context.Context) (health.HealthStats, error) {
+	return m.GetHealthFunc(ctx)
 }
@@ -1,6 +1,7 @@
 package mock
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -11,20 +12,20 @@ import (
 
 func TestMockHealthCheckUseCase(t *testing.T) {
 	mock := &MockHealthCheckUseCase{
-		GetHealthFunc: func() (health.HealthStats, error) {
+		GetHealthFunc: func(ctx context.Context) (health.HealthStats, error) {
 			return health.HealthStats{Status: "pass"}, nil
 		},
 	}
 
-	healthStats, err := mock.GetHealth()
+	healthStats, err := mock.GetHealth(context.Background())
 
 	assert.NoError(t, err, "Expected no error from mock")
 	assert.Equal(t, "pass", healthStats.Status, "Expected status to match mock value")
 
-	mock.GetHealthFunc = func() (health.HealthStats, error) {
+	mock.GetHealthFunc = func(ctx context.Context) (health.HealthStats, error) {
 		return health.HealthStats{}, errors.New("mock error")
 	}
 
-	_, err = mock.GetHealth()
+	_, err = mock.GetHealth(context.Background())
 	assert.Error(t, err, "Expected error from mock")
 }
@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+)
+
+type MockSubscriptionUsecase struct {
+	CreateSubscriptionFunc    func(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error)
+	ListSubscriptionsFunc     func(tenant string) ([]domain.Subscription, error)
+	GetSubscriptionFunc       func(tenant, id string) (domain.Subscription, error)
+	DeleteSubscriptionFunc    func(tenant, id string) error
+	ListDeadLettersFunc       func(tenant string) ([]domain.DeadLetter, error)
+	EvaluateSubscriptionsFunc func(ctx context.Context)
+}
+
+func (m *MockSubscriptionUsecase) CreateSubscription(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error) {
+	return m.CreateSubscriptionFunc(tenant, request)
+}
+
+func (m *MockSubscriptionUsecase) ListSubscriptions(tenant string) ([]domain.Subscription, error) {
+	return m.ListSubscriptionsFunc(tenant)
+}
+
+func (m *MockSubscriptionUsecase) GetSubscription(tenant, id string) (domain.Subscription, error) {
+	return m.GetSubscriptionFunc(tenant, id)
+}
+
+func (m *MockSubscriptionUsecase) DeleteSubscription(tenant, id string) error {
+	return m.DeleteSubscriptionFunc(tenant, id)
+}
+
+func (m *MockSubscriptionUsecase) ListDeadLetters(tenant string) ([]domain.DeadLetter, error) {
+	return m.ListDeadLettersFunc(tenant)
+}
+
+func (m *MockSubscriptionUsecase) EvaluateSubscriptions(ctx context.Context) {
+	m.EvaluateSubscriptionsFunc(ctx)
+}
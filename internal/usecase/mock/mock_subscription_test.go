@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockSubscriptionUsecase(t *testing.T) {
+	evaluated := false
+	mock := &MockSubscriptionUsecase{
+		CreateSubscriptionFunc: func(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error) {
+			return domain.Subscription{ID: "sub-1", Cep: request.Cep}, nil
+		},
+		ListSubscriptionsFunc: func(tenant string) ([]domain.Subscription, error) {
+			return []domain.Subscription{{ID: "sub-1"}}, nil
+		},
+		GetSubscriptionFunc: func(tenant, id string) (domain.Subscription, error) {
+			return domain.Subscription{}, domain.ErrSubscriptionNotFound
+		},
+		DeleteSubscriptionFunc: func(tenant, id string) error {
+			return nil
+		},
+		ListDeadLettersFunc: func(tenant string) ([]domain.DeadLetter, error) {
+			return nil, errors.New("mock error")
+		},
+		EvaluateSubscriptionsFunc: func(ctx context.Context) {
+			evaluated = true
+		},
+	}
+
+	created, err := mock.CreateSubscription("acme", domain.SubscriptionRequest{Cep: "01001000"})
+	assert.NoError(t, err, "Expected no error from mock")
+	assert.Equal(t, "01001000", created.Cep, "Expected cep to match mock value")
+
+	all, err := mock.ListSubscriptions("acme")
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	_, err = mock.GetSubscription("acme", "missing")
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound, "Expected error from mock")
+
+	assert.NoError(t, mock.DeleteSubscription("acme", "sub-1"))
+
+	_, err = mock.ListDeadLetters("acme")
+	assert.Error(t, err, "Expected error from mock")
+
+	mock.EvaluateSubscriptions(context.Background())
+	assert.True(t, evaluated, "Expected evaluate function to be called")
+}
@@ -1,13 +1,15 @@
 package mock
 
 import (
+	"context"
+
 	"github.com/vs0uz4/weatherzip/internal/domain"
 )
 
 type MockWeatherByCepUsecase struct {
-	GetWeatherByCepFunc func(cep string) (domain.WeatherResponse, error)
+	GetWeatherByCepFunc func(ctx context.Context, cep string) (domain.WeatherResponse, error)
 }
 
-func (m *MockWeatherByCepUsecase) GetWeatherByCep(cep string) (domain.WeatherResponse, error) {
-	return m.GetWeatherByCepFunc(cep)
+func (m *MockWeatherByCepUsecase) GetWeatherByCep(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+	return m.GetWeatherByCepFunc(ctx, cep)
 }
@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	serviceContracts "github.com/vs0uz4/weatherzip/internal/service/contracts"
+	"github.com/vs0uz4/weatherzip/internal/usecase/contracts"
+)
+
+var _ contracts.SubscriptionUsecase = (*subscriptionUsecase)(nil)
+
+type subscriptionUsecase struct {
+	Subscriptions  serviceContracts.SubscriptionRepository
+	DeadLetters    serviceContracts.DeadLetterRepository
+	Webhook        serviceContracts.WebhookService
+	WeatherByCep   contracts.WeatherByCepUsecase
+	nowFunc        func() time.Time
+	generateIDFunc func() string
+}
+
+func NewSubscriptionUsecase(
+	subscriptions serviceContracts.SubscriptionRepository,
+	deadLetters serviceContracts.DeadLetterRepository,
+	webhook serviceContracts.WebhookService,
+	weatherByCep contracts.WeatherByCepUsecase,
+) *subscriptionUsecase {
+	return &subscriptionUsecase{
+		Subscriptions:  subscriptions,
+		DeadLetters:    deadLetters,
+		Webhook:        webhook,
+		WeatherByCep:   weatherByCep,
+		nowFunc:        time.Now,
+		generateIDFunc: generateID,
+	}
+}
+
+func (uc *subscriptionUsecase) CreateSubscription(tenant string, request domain.SubscriptionRequest) (domain.Subscription, error) {
+	if len(request.Cep) != 8 || !isNumeric(request.Cep) {
+		return domain.Subscription{}, domain.ErrInvalidZipcode
+	}
+
+	condition, err := domain.ParseCondition(request.Condition)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	callback, err := url.Parse(request.CallbackURL)
+	if err != nil || (callback.Scheme != "http" && callback.Scheme != "https") || !domain.IsPublicCallbackHost(callback.Hostname()) {
+		return domain.Subscription{}, domain.ErrInvalidCallbackURL
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		secret = uc.generateIDFunc()
+	}
+
+	subscription := domain.Subscription{
+		ID:          uc.generateIDFunc(),
+		Tenant:      tenant,
+		Cep:         request.Cep,
+		Condition:   condition,
+		Expression:  request.Condition,
+		CallbackURL: callback.String(),
+		Secret:      secret,
+		CreatedAt:   uc.nowFunc().UTC(),
+	}
+
+	if err := uc.Subscriptions.Save(subscription); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	return subscription, nil
+}
+
+func (uc *subscriptionUsecase) ListSubscriptions(tenant string) ([]domain.Subscription, error) {
+	subscriptions, err := uc.Subscriptions.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]domain.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if subscription.Tenant == tenant {
+			owned = append(owned, subscription)
+		}
+	}
+	return owned, nil
+}
+
+func (uc *subscriptionUsecase) GetSubscription(tenant, id string) (domain.Subscription, error) {
+	subscription, err := uc.Subscriptions.FindByID(id)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	if subscription.Tenant != tenant {
+		return domain.Subscription{}, domain.ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (uc *subscriptionUsecase) DeleteSubscription(tenant, id string) error {
+	if _, err := uc.GetSubscription(tenant, id); err != nil {
+		return err
+	}
+	return uc.Subscriptions.Delete(id)
+}
+
+func (uc *subscriptionUsecase) ListDeadLetters(tenant string) ([]domain.DeadLetter, error) {
+	letters, err := uc.DeadLetters.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]domain.DeadLetter, 0, len(letters))
+	for _, letter := range letters {
+		if letter.Tenant == tenant {
+			owned = append(owned, letter)
+		}
+	}
+	return owned, nil
+}
+
+func (uc *subscriptionUsecase) EvaluateSubscriptions(ctx context.Context) {
+	subscriptions, err := uc.Subscriptions.FindAll()
+	if err != nil {
+		slog.Error("failed to load subscriptions", slog.String("error", err.Error()))
+		return
+	}
+
+	type lookup struct {
+		weather domain.WeatherResponse
+		err     error
+	}
+	type trigger struct {
+		subscription domain.Subscription
+		event        domain.WebhookEvent
+	}
+	lookups := make(map[string]lookup)
+	evaluated := make([]domain.Subscription, 0, len(subscriptions))
+	var triggers []trigger
+
+	for _, subscription := range subscriptions {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result, ok := lookups[subscription.Cep]
+		if !ok {
+			result.weather, result.err = uc.WeatherByCep.GetWeatherByCep(ctx, subscription.Cep)
+			lookups[subscription.Cep] = result
+		}
+		if result.err != nil {
+			slog.Warn("failed to evaluate subscription", slog.String("subscription", subscription.ID), slog.String("error", result.err.Error()))
+			continue
+		}
+
+		event, triggered := uc.evaluate(&subscription, result.weather.Current)
+		evaluated = append(evaluated, subscription)
+		if triggered {
+			triggers = append(triggers, trigger{subscription: subscription, event: event})
+		}
+	}
+
+	if len(evaluated) == 0 {
+		return
+	}
+	if err := uc.Subscriptions.UpdateAll(evaluated); err != nil {
+		slog.Error("failed to update subscriptions", slog.String("error", err.Error()))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, trigger := range triggers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			uc.deliver(ctx, trigger.subscription, trigger.event)
+		}()
+	}
+	wg.Wait()
+}
+
+func (uc *subscriptionUsecase) evaluate(subscription *domain.Subscription, current domain.CurrentWeather) (domain.WebhookEvent, bool) {
+	condition := subscription.Condition
+	observed := condition.Observe(current)
+	firstCheck := subscription.LastCheckedAt.IsZero()
+
+	eventType := ""
+	if condition.IsChangeTrigger() {
+		if !firstCheck && observed != subscription.LastObserved {
+			eventType = domain.EventConditionChanged
+		}
+	} else {
+		matches := condition.Matches(current)
+		if matches && !subscription.Matching {
+			eventType = domain.EventConditionStarted
+		}
+		if !matches && subscription.Matching {
+			eventType = domain.EventConditionStopped
+		}
+		subscription.Matching = matches
+	}
+
+	now := uc.nowFunc().UTC()
+	subscription.LastObserved = observed
+	subscription.LastCheckedAt = now
+
+	if eventType == "" {
+		return domain.WebhookEvent{}, false
+	}
+
+	return domain.WebhookEvent{
+		ID:             uc.generateIDFunc(),
+		Type:           eventType,
+		SubscriptionID: subscription.ID,
+		Cep:            subscription.Cep,
+		Condition:      subscription.Expression,
+		Weather:        domain.NewWebhookWeather(current),
+		Timestamp:      now,
+	}, true
+}
+
+func (uc *subscriptionUsecase) deliver(ctx context.Context, subscription domain.Subscription, event domain.WebhookEvent) {
+	attempts, err := uc.Webhook.Deliver(ctx, subscription.CallbackURL, subscription.Secret, event)
+	if err == nil {
+		return
+	}
+
+	slog.Warn("subscription webhook failed", slog.String("event", event.ID), slog.String("subscription", subscription.ID), slog.Int("attempts", attempts), slog.String("error", err.Error()))
+
+	letter := domain.DeadLetter{
+		ID:             uc.generateIDFunc(),
+		Tenant:         subscription.Tenant,
+		SubscriptionID: subscription.ID,
+		CallbackURL:    subscription.CallbackURL,
+		Event:          event,
+		Attempts:       attempts,
+		LastError:      err.Error(),
+		FailedAt:       uc.nowFunc().UTC(),
+	}
+	if err := uc.DeadLetters.Add(letter); err != nil {
+		slog.Error("failed to store dead letter", slog.String("event", event.ID), slog.String("error", err.Error()))
+	}
+}
+
+func generateID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return hex.EncodeToString([]byte(time.Now().Format(time.RFC3339Nano)))
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,424 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vs0uz4/weatherzip/internal/domain"
+	"github.com/vs0uz4/weatherzip/internal/service/mock"
+	usecaseMock "github.com/vs0uz4/weatherzip/internal/usecase/mock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subscriptionFixture struct {
+	mu            sync.Mutex
+	subscriptions map[string]domain.Subscription
+	deadLetters   []domain.DeadLetter
+	deliveries    []domain.WebhookEvent
+	deliverErr    error
+	weather       func(cep string) (domain.WeatherResponse, error)
+	lookups       int
+	updates       int
+	ids           int
+}
+
+func newSubscriptionFixture() *subscriptionFixture {
+	return &subscriptionFixture{subscriptions: map[string]domain.Subscription{}}
+}
+
+func (f *subscriptionFixture) usecase() *subscriptionUsecase {
+	repo := &mock.MockSubscriptionRepository{
+		SaveFunc: func(subscription domain.Subscription) error {
+			f.subscriptions[subscription.ID] = subscription
+			return nil
+		},
+		UpdateAllFunc: func(subscriptions []domain.Subscription) error {
+			f.updates++
+			for _, subscription := range subscriptions {
+				if _, ok := f.subscriptions[subscription.ID]; ok {
+					f.subscriptions[subscription.ID] = subscription
+				}
+			}
+			return nil
+		},
+		FindAllFunc: func() ([]domain.Subscription, error) {
+			all := []domain.Subscription{}
+			for _, subscription := range f.subscriptions {
+				all = append(all, subscription)
+			}
+			return all, nil
+		},
+		FindByIDFunc: func(id string) (domain.Subscription, error) {
+			subscription, ok := f.subscriptions[id]
+			if !ok {
+				return domain.Subscription{}, domain.ErrSubscriptionNotFound
+			}
+			return subscription, nil
+		},
+		DeleteFunc: func(id string) error {
+			delete(f.subscriptions, id)
+			return nil
+		},
+	}
+
+	deadLetters := &mock.MockDeadLetterRepository{
+		AddFunc: func(letter domain.DeadLetter) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.deadLetters = append(f.deadLetters, letter)
+			return nil
+		},
+		FindAllFunc: func() ([]domain.DeadLetter, error) {
+			return f.deadLetters, nil
+		},
+	}
+
+	webhook := &mock.MockWebhookService{
+		DeliverFunc: func(ctx context.Context, callbackURL, secret string, event domain.WebhookEvent) (int, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.deliveries = append(f.deliveries, event)
+			if f.deliverErr != nil {
+				return 3, f.deliverErr
+			}
+			return 1, nil
+		},
+	}
+
+	weatherByCep := &usecaseMock.MockWeatherByCepUsecase{
+		GetWeatherByCepFunc: func(ctx context.Context, cep string) (domain.WeatherResponse, error) {
+			f.lookups++
+			return f.weather(cep)
+		},
+	}
+
+	uc := NewSubscriptionUsecase(repo, deadLetters, webhook, weatherByCep)
+	uc.nowFunc = func() time.Time {
+		return time.Date(2024, 12, 10, 16, 0, 0, 0, time.UTC)
+	}
+	uc.generateIDFunc = func() string {
+		f.ids++
+		return fmt.Sprintf("id-%d", f.ids)
+	}
+
+	return uc
+}
+
+func weatherWith(tempC float64, text string) func(string) (domain.WeatherResponse, error) {
+	return func(string) (domain.WeatherResponse, error) {
+		return domain.WeatherResponse{
+			Current: domain.CurrentWeather{TempC: tempC, Condition: domain.WeatherCondition{Text: text}},
+		}, nil
+	}
+}
+
+func TestCreateSubscription(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   domain.SubscriptionRequest
+		expectErr error
+	}{
+		{
+			name:    "Valid Subscription",
+			request: domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "https://example.com/hook"},
+		},
+		{
+			name:      "Invalid CEP",
+			request:   domain.SubscriptionRequest{Cep: "0100", Condition: "temp_C > 35", CallbackURL: "https://example.com/hook"},
+			expectErr: domain.ErrInvalidZipcode,
+		},
+		{
+			name:      "Invalid Condition",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C is hot", CallbackURL: "https://example.com/hook"},
+			expectErr: domain.ErrInvalidCondition,
+		},
+		{
+			name:      "Invalid Callback Scheme",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "ftp://example.com/hook"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+		{
+			name:      "Relative Callback URL",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "/hook"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+		{
+			name:      "Loopback Callback URL",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "http://127.0.0.1:8080/hook"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+		{
+			name:      "Metadata Callback URL",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "http://169.254.169.254/latest/meta-data"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+		{
+			name:      "Private Callback URL",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "https://[fd00::1]/hook"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+		{
+			name:      "Localhost Callback URL",
+			request:   domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "http://localhost/hook"},
+			expectErr: domain.ErrInvalidCallbackURL,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := newSubscriptionFixture()
+			uc := fixture.usecase()
+
+			subscription, err := uc.CreateSubscription("acme", tt.request)
+
+			assert.ErrorIs(t, err, tt.expectErr)
+			if tt.expectErr != nil {
+				assert.Empty(t, fixture.subscriptions, "Invalid subscriptions should not be stored")
+				return
+			}
+
+			assert.Equal(t, "id-2", subscription.ID)
+			assert.Equal(t, "acme", subscription.Tenant)
+			assert.Equal(t, "id-1", subscription.Secret, "Secret should be generated when not provided")
+			assert.Equal(t, domain.Condition{Field: "temp_C", Operator: ">", Value: "35"}, subscription.Condition)
+			assert.Contains(t, fixture.subscriptions, subscription.ID)
+		})
+	}
+}
+
+func TestCreateSubscriptionKeepsProvidedSecret(t *testing.T) {
+	uc := newSubscriptionFixture().usecase()
+
+	subscription, err := uc.CreateSubscription("acme", domain.SubscriptionRequest{
+		Cep:         "01001000",
+		Condition:   "condition changes",
+		CallbackURL: "https://example.com/hook",
+		Secret:      "my-secret",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "my-secret", subscription.Secret)
+}
+
+func TestCreateSubscriptionRepositoryError(t *testing.T) {
+	uc := newSubscriptionFixture().usecase()
+	uc.Subscriptions.(*mock.MockSubscriptionRepository).SaveFunc = func(domain.Subscription) error {
+		return errors.New("mock save error")
+	}
+
+	_, err := uc.CreateSubscription("acme", domain.SubscriptionRequest{Cep: "01001000", Condition: "temp_C > 35", CallbackURL: "https://example.com/hook"})
+
+	assert.EqualError(t, err, "mock save error")
+}
+
+func TestSubscriptionQueries(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.subscriptions["sub-1"] = domain.Subscription{ID: "sub-1", Tenant: "acme"}
+	fixture.subscriptions["sub-2"] = domain.Subscription{ID: "sub-2", Tenant: "globex"}
+	fixture.deadLetters = []domain.DeadLetter{{ID: "dl-1", Tenant: "acme"}, {ID: "dl-2", Tenant: "globex"}}
+
+	all, err := uc.ListSubscriptions("acme")
+	assert.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "sub-1", all[0].ID)
+
+	found, err := uc.GetSubscription("acme", "sub-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "sub-1", found.ID)
+
+	letters, err := uc.ListDeadLetters("acme")
+	assert.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "dl-1", letters[0].ID)
+
+	assert.NoError(t, uc.DeleteSubscription("acme", "sub-1"))
+	assert.NotContains(t, fixture.subscriptions, "sub-1")
+}
+
+func TestSubscriptionQueriesAreScopedByTenant(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.subscriptions["sub-2"] = domain.Subscription{ID: "sub-2", Tenant: "globex"}
+
+	_, err := uc.GetSubscription("acme", "sub-2")
+	assert.ErrorIs(t, err, domain.ErrSubscriptionNotFound, "Other tenants' subscriptions should look missing")
+
+	assert.ErrorIs(t, uc.DeleteSubscription("acme", "sub-2"), domain.ErrSubscriptionNotFound)
+	assert.Contains(t, fixture.subscriptions, "sub-2", "Other tenants' subscriptions should not be deleted")
+
+	all, err := uc.ListSubscriptions("")
+	assert.NoError(t, err)
+	assert.Empty(t, all, "Anonymous callers should not see tenant subscriptions")
+}
+
+func TestEvaluateSubscriptionsThresholdTransitions(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.subscriptions["sub-1"] = domain.Subscription{
+		ID:          "sub-1",
+		Cep:         "01001000",
+		Condition:   domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+		Expression:  "temp_C > 35",
+		CallbackURL: "https://example.com/hook",
+	}
+
+	steps := []struct {
+		tempC        float64
+		expectEvents []string
+	}{
+		{30, nil},
+		{36, []string{domain.EventConditionStarted}},
+		{37, []string{domain.EventConditionStarted}},
+		{34, []string{domain.EventConditionStarted, domain.EventConditionStopped}},
+	}
+
+	for _, step := range steps {
+		fixture.weather = weatherWith(step.tempC, "Sunny")
+		uc.EvaluateSubscriptions(context.Background())
+
+		var types []string
+		for _, event := range fixture.deliveries {
+			types = append(types, event.Type)
+		}
+		assert.Equal(t, step.expectEvents, types, "Unexpected events after temp_C=%v", step.tempC)
+	}
+
+	require.Len(t, fixture.deliveries, 2)
+	assert.Equal(t, 36.0, fixture.deliveries[0].Weather.TempC)
+	assert.Equal(t, "temp_C > 35", fixture.deliveries[0].Condition)
+	assert.False(t, fixture.subscriptions["sub-1"].Matching)
+	assert.Equal(t, "34", fixture.subscriptions["sub-1"].LastObserved)
+}
+
+func TestEvaluateSubscriptionsConditionChanges(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.subscriptions["sub-1"] = domain.Subscription{
+		ID:        "sub-1",
+		Cep:       "01001000",
+		Condition: domain.Condition{Field: "condition", Operator: "changes"},
+	}
+
+	fixture.weather = weatherWith(20, "Sunny")
+	uc.EvaluateSubscriptions(context.Background())
+	assert.Empty(t, fixture.deliveries, "First observation should only record the condition")
+
+	uc.EvaluateSubscriptions(context.Background())
+	assert.Empty(t, fixture.deliveries, "Unchanged condition should not trigger")
+
+	fixture.weather = weatherWith(20, "Light rain")
+	uc.EvaluateSubscriptions(context.Background())
+	require.Len(t, fixture.deliveries, 1)
+	assert.Equal(t, domain.EventConditionChanged, fixture.deliveries[0].Type)
+	assert.Equal(t, "Light rain", fixture.deliveries[0].Weather.Condition)
+}
+
+func TestEvaluateSubscriptionsDeadLetters(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.deliverErr = errors.New("mock delivery error")
+	fixture.weather = weatherWith(40, "Sunny")
+	fixture.subscriptions["sub-1"] = domain.Subscription{
+		ID:          "sub-1",
+		Tenant:      "acme",
+		Cep:         "01001000",
+		Condition:   domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+		CallbackURL: "https://example.com/hook",
+	}
+
+	uc.EvaluateSubscriptions(context.Background())
+
+	require.Len(t, fixture.deadLetters, 1)
+	assert.Equal(t, "sub-1", fixture.deadLetters[0].SubscriptionID)
+	assert.Equal(t, "acme", fixture.deadLetters[0].Tenant, "Dead letters should keep the subscription tenant")
+	assert.Equal(t, 3, fixture.deadLetters[0].Attempts)
+	assert.Equal(t, "mock delivery error", fixture.deadLetters[0].LastError)
+	assert.Equal(t, domain.EventConditionStarted, fixture.deadLetters[0].Event.Type)
+}
+
+func TestEvaluateSubscriptionsSharesLookupsPerCep(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.weather = weatherWith(20, "Sunny")
+	for _, id := range []string{"sub-1", "sub-2", "sub-3"} {
+		fixture.subscriptions[id] = domain.Subscription{
+			ID:        id,
+			Cep:       "01001000",
+			Condition: domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+		}
+	}
+
+	uc.EvaluateSubscriptions(context.Background())
+
+	assert.Equal(t, 1, fixture.lookups, "Subscriptions for the same CEP should share a lookup")
+	assert.Equal(t, 1, fixture.updates, "Evaluated subscriptions should be persisted in a single write")
+}
+
+func TestEvaluateSubscriptionsLookupError(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.weather = func(string) (domain.WeatherResponse, error) {
+		return domain.WeatherResponse{}, domain.ErrWeatherService
+	}
+	fixture.subscriptions["sub-1"] = domain.Subscription{
+		ID:        "sub-1",
+		Cep:       "01001000",
+		Condition: domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+	}
+
+	uc.EvaluateSubscriptions(context.Background())
+
+	assert.Empty(t, fixture.deliveries)
+	assert.True(t, fixture.subscriptions["sub-1"].LastCheckedAt.IsZero(), "Failed lookups should not update state")
+}
+
+func TestEvaluateSubscriptionsUpdateError(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.weather = weatherWith(40, "Sunny")
+	fixture.subscriptions["sub-1"] = domain.Subscription{
+		ID:        "sub-1",
+		Cep:       "01001000",
+		Condition: domain.Condition{Field: "temp_C", Operator: ">", Value: "35"},
+	}
+	uc.Subscriptions.(*mock.MockSubscriptionRepository).UpdateAllFunc = func([]domain.Subscription) error {
+		return errors.New("mock update error")
+	}
+
+	uc.EvaluateSubscriptions(context.Background())
+
+	assert.Empty(t, fixture.deliveries, "Webhooks should not fire when the new state was not persisted")
+}
+
+func TestEvaluateSubscriptionsLoadError(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	uc.Subscriptions.(*mock.MockSubscriptionRepository).FindAllFunc = func() ([]domain.Subscription, error) {
+		return nil, errors.New("mock load error")
+	}
+
+	assert.NotPanics(t, func() {
+		uc.EvaluateSubscriptions(context.Background())
+	})
+	assert.Zero(t, fixture.lookups)
+}
+
+func TestEvaluateSubscriptionsCanceledContext(t *testing.T) {
+	fixture := newSubscriptionFixture()
+	uc := fixture.usecase()
+	fixture.weather = weatherWith(40, "Sunny")
+	fixture.subscriptions["sub-1"] = domain.Subscription{ID: "sub-1", Cep: "01001000"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	uc.EvaluateSubscriptions(ctx)
+
+	assert.Zero(t, fixture.lookups, "Canceled evaluations should not query the weather")
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+
 	"github.com/vs0uz4/weatherzip/internal/domain"
 	"github.com/vs0uz4/weatherzip/internal/service/contracts"
 )
@@ -17,19 +19,19 @@ func NewWeatherByCepUsecase(cepService contracts.CepService, weatherService cont
 	}
 }
 
-func (uc *weatherByCepUsecase) GetWeatherByCep(cep string) (domain.WeatherResponse, error) {
+func (uc *weatherByCepUsecase) GetWeatherByCep(ctx context.Context, cep string) (domain.WeatherResponse, error) {
 	if len(cep) != 8 || !isNumeric(cep) {
 		return domain.WeatherResponse{}, domain.ErrInvalidZipcode
 	}
 
-	location, err := uc.CepService.GetLocation(cep)
+	location, err := uc.CepService.GetLocation(ctx, cep)
 	if err != nil {
 		return domain.WeatherResponse{}, err
 	}
 
-	weather, err := uc.WeatherService.GetWeather(location.Localidade)
+	weather, err := uc.WeatherService.GetWeather(ctx, location.Localidade)
 	if err != nil {
-		return domain.WeatherResponse{}, err
+		return domain.WeatherResponse{}, &domain.WeatherStepError{Location: location, Err: err}
 	}
 
 	return weather, nil
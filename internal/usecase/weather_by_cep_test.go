@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -67,7 +68,7 @@ func TestGetWeatherByCep(t *testing.T) {
 			inputCep: "99999999",
 			mockCepSvc: func() *mock.MockCepService {
 				return &mock.MockCepService{
-					GetLocationFunc: func(cep string) (domain.CepResponse, error) {
+					GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
 						return domain.CepResponse{}, domain.ErrZipcodeNotFound
 					},
 				}
@@ -82,7 +83,7 @@ func TestGetWeatherByCep(t *testing.T) {
 			inputCep: "12345678",
 			mockCepSvc: func() *mock.MockCepService {
 				return &mock.MockCepService{
-					GetLocationFunc: func(cep string) (domain.CepResponse, error) {
+					GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
 						return domain.CepResponse{
 							Localidade: "City",
 							Uf:         "State",
@@ -92,7 +93,7 @@ func TestGetWeatherByCep(t *testing.T) {
 			},
 			mockWeatherSvc: func() *mock.MockWeatherService {
 				return &mock.MockWeatherService{
-					GetWeatherFunc: func(location string) (domain.WeatherResponse, error) {
+					GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{}, domain.ErrWeatherService
 					},
 				}
@@ -104,7 +105,7 @@ func TestGetWeatherByCep(t *testing.T) {
 			inputCep: "12345678",
 			mockCepSvc: func() *mock.MockCepService {
 				return &mock.MockCepService{
-					GetLocationFunc: func(cep string) (domain.CepResponse, error) {
+					GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
 						return domain.CepResponse{
 							Localidade: "City",
 							Uf:         "State",
@@ -114,7 +115,7 @@ func TestGetWeatherByCep(t *testing.T) {
 			},
 			mockWeatherSvc: func() *mock.MockWeatherService {
 				return &mock.MockWeatherService{
-					GetWeatherFunc: func(location string) (domain.WeatherResponse, error) {
+					GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
 						return domain.WeatherResponse{
 							Current: domain.CurrentWeather{
 								TempC: 25.0,
@@ -138,7 +139,7 @@ func TestGetWeatherByCep(t *testing.T) {
 				WeatherService: tt.mockWeatherSvc(),
 			}
 
-			result, err := usecase.GetWeatherByCep(tt.inputCep)
+			result, err := usecase.GetWeatherByCep(context.Background(), tt.inputCep)
 
 			if !errors.Is(err, tt.expectErr) {
 				t.Errorf("Expected error %v, got %v", tt.expectErr, err)
@@ -150,3 +151,32 @@ func TestGetWeatherByCep(t *testing.T) {
 		})
 	}
 }
+
+func TestGetWeatherByCepPreservesLocationOnWeatherError(t *testing.T) {
+	location := domain.CepResponse{Cep: "12345-678", Localidade: "City", Uf: "State"}
+	usecase := weatherByCepUsecase{
+		CepService: &mock.MockCepService{
+			GetLocationFunc: func(ctx context.Context, cep string) (domain.CepResponse, error) {
+				return location, nil
+			},
+		},
+		WeatherService: &mock.MockWeatherService{
+			GetWeatherFunc: func(ctx context.Context, location string) (domain.WeatherResponse, error) {
+				return domain.WeatherResponse{}, domain.ErrUpstreamUnavailable
+			},
+		},
+	}
+
+	_, err := usecase.GetWeatherByCep(context.Background(), "12345678")
+
+	var stepErr *domain.WeatherStepError
+	if !errors.As(err, &stepErr) {
+		t.Fatalf("Expected WeatherStepError, got %v", err)
+	}
+	if stepErr.Location != location {
+		t.Errorf("Expected location %+v, got %+v", location, stepErr.Location)
+	}
+	if !errors.Is(err, domain.ErrUpstreamUnavailable) {
+		t.Errorf("Expected error to wrap %v, got %v", domain.ErrUpstreamUnavailable, err)
+	}
+}